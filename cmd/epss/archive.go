@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/archive"
+	"github.com/urfave/cli/v2"
+)
+
+// handleFetchArchive downloads the daily CSV datasets from --start to --end into
+// --out-dir for use with --csv-dir. Valid files already present are skipped,
+// so an interrupted run is resumed by running it again.
+func handleFetchArchive(c *cli.Context) error {
+	dates, err := service.DatesBetween(c.String("start"), c.String("end"))
+	if err != nil {
+		return err
+	}
+
+	d := archive.NewDownloader(c.String("csv-mirror"), c.String("out-dir"), archive.WithInterval(c.Duration("interval")))
+	summary, err := d.FetchAll(dates, func(done, total int, r archive.Result) {
+		switch {
+		case r.Err != nil:
+			log.Printf("[%d/%d] %s: %s: %v", done, total, r.Date, r.Status, r.Err)
+		default:
+			log.Printf("[%d/%d] %s: %s (%d bytes)", done, total, r.Date, r.Status, r.Bytes)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.App.Writer, "Downloaded %d, skipped %d, missing %d, failed %d (%d bytes fetched)\n",
+		summary.Downloaded, summary.Skipped, summary.Missing, summary.Failed, summary.Bytes)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d dataset(s) failed to download; rerun to retry them", summary.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// handleBand retrieves every CVE whose percentile lies between --pct-min and --pct-max.
+func handleBand(c *cli.Context) error {
+	return printCVEsInRange(c, c.String("date"), "percentile", c.Float64("pct-min"), c.Float64("pct-max"))
+}
+
+// handleBetween retrieves every CVE in the latest data whose --field lies between --min and --max.
+func handleBetween(c *cli.Context) error {
+	return printCVEsInRange(c, "", c.String("field"), c.Float64("min"), c.Float64("max"))
+}
+
+// printCVEsInRange prints every CVE for date whose field lies strictly between min and max.
+func printCVEsInRange(c *cli.Context, date string, field string, min, max float64) error {
+	repo := newRepository(c)
+	cves, err := repo.GetCVEsInRange(c.Context, date, field, min, max)
+	if err != nil {
+		return fmt.Errorf("failed to get CVEs in %s band: %w", field, err)
+	}
+	if err := annotateRank(c, repo, cves, date); err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	return p.PrintCVEs(cves)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/urfave/cli/v2"
+)
+
+// handleScoreBatches scores --cves in batches, continuing past failed batches.
+func handleScoreBatches(c *cli.Context, cveIDs []string) error {
+	repo := newRepository(c)
+	date, err := scoresDate(c, repo)
+	if err != nil {
+		return err
+	}
+	result := service.ScoreBatches(c.Context, repo, cveIDs, date, c.Int("batch-size"))
+	return printBatchResult(c, cveIDs, result)
+}
+
+// handleRetryFailed rescores the CVEs that failed in a saved --keep-going run
+// and prints the earlier results merged with the new ones.
+func handleRetryFailed(c *cli.Context) error {
+	if c.IsSet("cves") || c.IsSet("file") {
+		return fmt.Errorf("--retry-failed takes its CVEs from the previous output and cannot be combined with --cves or --file")
+	}
+	path := c.String("retry-failed")
+	prev, err := readBatchResult(path)
+	if err != nil {
+		return err
+	}
+
+	failed := service.FailedIDs(prev)
+	merged := prev
+	if len(failed) == 0 {
+		log.Printf("No failed CVEs in %s; nothing to retry", path)
+	} else {
+		repo := newRepository(c)
+		date, err := scoresDate(c, repo)
+		if err != nil {
+			return err
+		}
+		log.Printf("Retrying %d failed CVE(s) from %s", len(failed), path)
+		retry := service.ScoreBatches(c.Context, repo, failed, date, c.Int("batch-size"))
+		merged = service.MergeBatchResults(prev, failed, retry)
+	}
+
+	ids := service.FailedIDs(merged)
+	for _, cve := range merged.Results {
+		ids = append(ids, cve.ID)
+	}
+	return printBatchResult(c, ids, merged)
+}
+
+// printBatchResult prints result and fails when CVEs could not be scored, so
+// scripts notice the partial run.
+func printBatchResult(c *cli.Context, cveIDs []string, result models.BatchResult) error {
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, result.Results)
+	if err := p.PrintBatchResult(result); err != nil {
+		return err
+	}
+	if err := checkGate(c, cveIDs, result.Results, nil); err != nil {
+		return err
+	}
+	if n := len(result.Failed); n > 0 {
+		return fmt.Errorf("%d CVE(s) could not be scored; rerun with --retry-failed on the saved JSON output", n)
+	}
+	return nil
+}
+
+// readBatchResult loads a batch result saved with --keep-going --output json,
+// with or without a --with-meta envelope.
+func readBatchResult(path string) (models.BatchResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.BatchResult{}, fmt.Errorf("failed to read previous output: %w", err)
+	}
+	var saved struct {
+		Data json.RawMessage `json:"data"`
+		models.BatchResult
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return models.BatchResult{}, fmt.Errorf("%s: failed to decode batch result: %w", path, err)
+	}
+	if saved.Data != nil {
+		saved.BatchResult = models.BatchResult{}
+		if err := json.Unmarshal(saved.Data, &saved.BatchResult); err != nil {
+			return models.BatchResult{}, fmt.Errorf("%s: failed to decode batch result: %w", path, err)
+		}
+	}
+	if saved.Results == nil && saved.Failed == nil {
+		return models.BatchResult{}, fmt.Errorf("%s: no batch result found (save a --keep-going run with --output json)", path)
+	}
+	return saved.BatchResult, nil
+}
+
+// handleBatch scores the CVE IDs listed in --input-file, one per line, or on
+// stdin when it is "-", and reports the IDs without data once the results are
+// printed.
+func handleBatch(c *cli.Context) error {
+	path := c.String("input-file")
+	in := c.App.Reader
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	cveIDs, err := readCVEList(in)
+	if err != nil {
+		return err
+	}
+	if len(cveIDs) == 0 {
+		return fmt.Errorf("no CVE IDs found in %s", path)
+	}
+
+	repo := newRepository(c)
+	date, err := scoresDate(c, repo)
+	if err != nil {
+		return err
+	}
+	cves, err := repo.GetCVEScores(c.Context, cveIDs, date)
+	if err != nil {
+		return fmt.Errorf("failed to get CVE scores: %w", err)
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	if err := p.PrintCVEs(cves); err != nil {
+		return err
+	}
+	if missing := service.UnscoredIDs(cveIDs, cves); len(missing) > 0 {
+		log.Printf("No EPSS score found for %d of %d CVE(s): %s", len(missing), len(cveIDs), strings.Join(missing, ", "))
+	}
+	return nil
+}
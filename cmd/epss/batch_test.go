@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	var queried []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queried = append(queried, r.URL.Query().Get("cve"))
+		fmt.Fprint(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":"2024-10-18"}]}`)
+	}))
+	defer mockServer.Close()
+	list := "# from the scanner\nCVE-2023-0001\n\n  cve-2023-0002  \nCVE-2023-0001\n"
+
+	t.Run("Success - Reads IDs From A File", func(t *testing.T) {
+		queried = nil
+		path := filepath.Join(t.TempDir(), "ids.txt")
+		require.NoError(t, os.WriteFile(path, []byte(list), 0o644))
+
+		require.NoError(t, newApp().Run([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "batch", "--input-file", path}))
+
+		assert.Equal(t, []string{"CVE-2023-0001,CVE-2023-0002"}, queried)
+	})
+
+	t.Run("Success - Reads IDs From Stdin", func(t *testing.T) {
+		queried = nil
+		var out bytes.Buffer
+		app := newApp()
+		app.Reader = strings.NewReader(list)
+		app.Writer = &out
+
+		require.NoError(t, app.Run([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "--output", "csv", "batch", "--input-file", "-"}))
+
+		assert.Equal(t, []string{"CVE-2023-0001,CVE-2023-0002"}, queried)
+	})
+
+	t.Run("Fail - Line Without A CVE ID", func(t *testing.T) {
+		app := newApp()
+		app.Reader = strings.NewReader("CVE-2023-0001\nGHSA-xxxx-yyyy\n")
+
+		err := app.Run([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "batch", "--input-file", "-"})
+
+		assert.ErrorContains(t, err, "line 2:")
+	})
+
+	t.Run("Fail - Only Comments", func(t *testing.T) {
+		app := newApp()
+		app.Reader = strings.NewReader("# nothing yet\n")
+
+		err := app.Run([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "batch", "--input-file", "-"})
+
+		assert.EqualError(t, err, "no CVE IDs found in -")
+	})
+}
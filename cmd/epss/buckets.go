@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/urfave/cli/v2"
+)
+
+// handleBucketCounts counts the CVEs above each --thresholds value for a date.
+func handleBucketCounts(c *cli.Context) error {
+	thresholds := c.Float64Slice("thresholds")
+	counts, err := service.CountsByThresholds(c.Context, newRepository(c), c.String("date"), thresholds, c.String("field"))
+	if err != nil {
+		return fmt.Errorf("failed to count CVEs by threshold: %w", err)
+	}
+
+	rows := make([]models.ThresholdCount, 0, len(counts))
+	for t, n := range counts {
+		rows = append(rows, models.ThresholdCount{Threshold: t, Count: n})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Threshold < rows[j].Threshold })
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintThresholdCounts(rows)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cache"
+	"github.com/urfave/cli/v2"
+)
+
+// defaultCacheDir returns the epss directory under the user's cache
+// directory, or "" when the platform has none.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "epss")
+}
+
+// cacheDir returns the directory API responses are cached in, or "" when
+// caching is disabled.
+func cacheDir(c *cli.Context) string {
+	if c.Bool("no-cache") {
+		return ""
+	}
+	return c.String("cache-dir")
+}
+
+// handleCacheClear removes the cached API responses and the remembered data
+// range from --cache-dir.
+func handleCacheClear(c *cli.Context) error {
+	dir := c.String("cache-dir")
+	if dir == "" {
+		return fmt.Errorf("no cache directory: set --cache-dir")
+	}
+	removed, err := cache.NewFileCache(dir, 0).Clear()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, dataRangeFile))
+	if err == nil {
+		removed++
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove cached data range: %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Removed %d cached file(s) from %s\n", removed, dir)
+	return nil
+}
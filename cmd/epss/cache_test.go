@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	var requests int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":"2024-10-18"}]}`)
+	}))
+	defer mockServer.Close()
+	score := func(args ...string) {
+		args = append([]string{"epss", "--base-url", mockServer.URL}, args...)
+		require.NoError(t, newApp().Run(append(args, "score", "--cve", "CVE-2023-0001", "--date", "2024-10-18")))
+	}
+
+	t.Run("Success - Later Runs Are Served From The Default Cache Dir", func(t *testing.T) {
+		t.Setenv("XDG_CACHE_HOME", t.TempDir())
+		t.Setenv("HOME", t.TempDir())
+		atomic.StoreInt32(&requests, 0)
+
+		score()
+		score()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Success - No Cache Always Fetches", func(t *testing.T) {
+		dir := t.TempDir()
+		atomic.StoreInt32(&requests, 0)
+
+		score("--cache-dir", dir, "--no-cache")
+		score("--cache-dir", dir, "--no-cache")
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Success - Cache Clear Forces A Refetch", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644))
+		atomic.StoreInt32(&requests, 0)
+
+		score("--cache-dir", dir)
+		require.NoError(t, newApp().Run([]string{"epss", "--cache-dir", dir, "cache", "clear"}))
+		score("--cache-dir", dir)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+		assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+	})
+
+	t.Run("Fail - Clear Without A Cache Dir", func(t *testing.T) {
+		err := newApp().Run([]string{"epss", "--cache-dir", "", "cache", "clear"})
+		assert.ErrorContains(t, err, "no cache directory")
+	})
+}
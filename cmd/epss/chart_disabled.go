@@ -0,0 +1,14 @@
+//go:build !charts
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// writeChart reports that chart output is not compiled in.
+func writeChart(path string, cves []models.CVE) error {
+	return fmt.Errorf("chart output is not available in this build; rebuild with -tags charts")
+}
@@ -0,0 +1,13 @@
+//go:build charts
+
+package main
+
+import (
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/chart"
+)
+
+// writeChart renders cves as a PNG line chart at path.
+func writeChart(path string, cves []models.CVE) error {
+	return chart.WriteTimeSeriesPNG(path, cves)
+}
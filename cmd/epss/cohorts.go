@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvefile"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/kev"
+	"github.com/urfave/cli/v2"
+)
+
+// handleCompareCohorts scores the CVEs of --set-a and --set-b and prints their
+// statistics side by side.
+func handleCompareCohorts(c *cli.Context) error {
+	a, err := readCVESet(c, c.String("set-a"))
+	if err != nil {
+		return err
+	}
+	b, err := readCVESet(c, c.String("set-b"))
+	if err != nil {
+		return err
+	}
+	thresholds := c.Float64Slice("thresholds")
+	if len(thresholds) == 0 {
+		thresholds = service.DefaultCohortThresholds
+	}
+
+	catalog, err := kev.NewClient(c.String("kev-url")).FetchCatalog()
+	if err != nil {
+		return err
+	}
+	cmp, err := service.CompareCohorts(c.Context, newRepository(c), a, b, c.String("date"), thresholds, catalog)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintCohortComparison(cmp)
+}
+
+// readCVESet reads the CVE IDs of a set file, naming the set after the file.
+func readCVESet(c *cli.Context, path string) (service.CVESet, error) {
+	table, err := cvefile.ReadFile(path, c.String("cve-column-name"))
+	if err != nil {
+		return service.CVESet{}, err
+	}
+	ids := table.IDs()
+	if len(ids) == 0 {
+		return service.CVESet{}, fmt.Errorf("no CVE IDs found in %s", path)
+	}
+	return service.CVESet{Name: filepath.Base(path), IDs: ids}, nil
+}
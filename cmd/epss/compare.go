@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/urfave/cli/v2"
+)
+
+// compareScoreDates prints the score of cveID on each --compare date.
+func compareScoreDates(c *cli.Context, repo ports.EPSSRepository, cveID string) error {
+	if c.IsSet("date") {
+		return fmt.Errorf("--date cannot be combined with --compare")
+	}
+	cmp, err := service.CompareScoreDates(c.Context, repo, cveID, c.StringSlice("compare"))
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintScoreComparison(*cmp)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvefile"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvss"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/nvd"
+	"github.com/urfave/cli/v2"
+)
+
+// handleCorrelate computes the correlation between the EPSS and CVSS base
+// scores of the CVEs listed in --file.
+func handleCorrelate(c *cli.Context) error {
+	table, err := cvefile.ReadFile(c.String("file"), c.String("cve-column-name"))
+	if err != nil {
+		return err
+	}
+	ids := table.IDs()
+	if len(ids) == 0 {
+		return fmt.Errorf("no CVE IDs found in %s", c.String("file"))
+	}
+
+	cves, err := newRepository(c).GetCVEScores(c.Context, ids, c.String("date"))
+	if err != nil {
+		return fmt.Errorf("failed to get CVE scores: %w", err)
+	}
+	pairs, missing, err := service.PairScores(c.Context, ids, cves, cvss.NewClient(newNVDClient(c)))
+	if err != nil {
+		return fmt.Errorf("failed to get CVSS scores: %w", err)
+	}
+
+	correlation, err := service.Correlate(pairs)
+	if err != nil {
+		return fmt.Errorf("failed to correlate scores: %w", err)
+	}
+	correlation.Missing = missing
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintCorrelation(correlation)
+}
+
+// newNVDClient builds the client for --nvd-url, pacing requests for the rate
+// limit that applies with or without --nvd-api-key. Each request is bounded
+// by --timeout.
+func newNVDClient(c *cli.Context) *nvd.Client {
+	interval := nvd.DefaultInterval
+	if c.String("nvd-api-key") != "" {
+		interval = nvd.DefaultKeyInterval
+	}
+	client := &http.Client{Timeout: time.Duration(c.Int("timeout")) * time.Second}
+	return nvd.NewClient(c.String("nvd-url"), c.String("nvd-api-key"), interval, nvd.WithHTTPClient(client))
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/urfave/cli/v2"
+)
+
+// handleCount prints how many CVEs match the query without downloading them.
+func handleCount(c *cli.Context) error {
+	counter, ok := newRepository(c).(ports.Counter)
+	if !ok {
+		return fmt.Errorf("count needs the API; local CSV datasets cannot be filtered server-side")
+	}
+
+	params := map[string]string{}
+	if c.IsSet("threshold") {
+		field := c.String("field")
+		if field != "epss" && field != "percentile" {
+			return fmt.Errorf("unsupported field: %s (expected epss or percentile)", field)
+		}
+		threshold := c.Float64("threshold")
+		if threshold < 0 || threshold > 1 {
+			return fmt.Errorf("threshold must be between 0 and 1, got %g", threshold)
+		}
+		params = service.CountParams("", field, threshold)
+	}
+	if date := c.String("date"); date != "" {
+		params["date"] = date
+	}
+	if q := c.String("search"); q != "" {
+		params["q"] = q
+	}
+
+	n, err := counter.Count(c.Context, params)
+	if err != nil {
+		return fmt.Errorf("failed to count CVEs: %w", err)
+	}
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintCount(n)
+}
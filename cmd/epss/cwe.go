@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cwe"
+	"github.com/urfave/cli/v2"
+)
+
+// handleCWE scores the CVEs mapped to --cwe and prints them under the CWE.
+func handleCWE(c *cli.Context) error {
+	mapper, err := newCWEMapper(c)
+	if err != nil {
+		return err
+	}
+	result, err := service.ScoreCWE(c.Context, mapper, newRepository(c), c.String("cwe"), c.String("date"), c.Int("batch-size"))
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintCWEResult(result)
+}
+
+// newCWEMapper reads the --mapping file when given and queries the NVD
+// otherwise.
+func newCWEMapper(c *cli.Context) (ports.CWEMapper, error) {
+	if path := c.String("mapping"); path != "" {
+		return cwe.ReadFile(path)
+	}
+	return cwe.NewNVDMapper(newNVDClient(c)), nil
+}
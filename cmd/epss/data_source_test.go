@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipDataset returns a gzipped daily CSV dataset holding rows.
+func gzipDataset(t *testing.T, rows string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte("#model_version:v2023.03.01,score_date:2024-10-18T00:00:00+0000\ncve,epss,percentile\n" + rows))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDataSource(t *testing.T) {
+	dataset := "CVE-2023-0001,0.60000,0.95000\n"
+
+	t.Run("Success - CSV Reads The Mirror", func(t *testing.T) {
+		var paths []string
+		mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			w.Write(gzipDataset(t, dataset))
+		}))
+		defer mirror.Close()
+		err := newApp().Run([]string{"epss", "--data-source", "csv", "--no-cache", "score", "--cve", "CVE-2023-0001", "--date", "2024-10-18", "--csv-mirror", mirror.URL})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/epss_scores-2024-10-18.csv.gz"}, paths)
+	})
+
+	t.Run("Success - CSV Reads The File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "epss_scores-2024-10-18.csv.gz")
+		require.NoError(t, os.WriteFile(path, gzipDataset(t, dataset), 0o644))
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected API request: %s", r.URL)
+		}))
+		defer api.Close()
+		app := newApp()
+		app.Reader = strings.NewReader("CVE-2023-0001\n")
+
+		err := app.Run([]string{"epss", "--base-url", api.URL, "--data-source", "csv", "--csv-file", path, "--no-cache", "batch", "--input-file", "-"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail - Unknown Source", func(t *testing.T) {
+		err := newApp().Run([]string{"epss", "--data-source", "nvd", "version"})
+
+		assert.EqualError(t, err, "unsupported data source: nvd (expected api or csv)")
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// handleGetCVEsForDateRange retrieves CVEs for every date in a range.
+func handleGetCVEsForDateRange(c *cli.Context) error {
+	dates, err := service.DatesBetween(c.String("start"), c.String("end"))
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	group, err := printer.ParseGroupKey(c.String("group-output-by"))
+	if err != nil {
+		return err
+	}
+	if group != printer.GroupNone && c.Bool("json-array-chunked") {
+		return fmt.Errorf("--group-output-by cannot be combined with --json-array-chunked")
+	}
+	p.WithGrouping(group)
+
+	// Results are ordered by date unless --ordered=false; raw JSON dumps default
+	// to arrival order since they are usually post-processed anyway.
+	ordered := c.String("output") != "json"
+	if c.IsSet("ordered") {
+		ordered = c.Bool("ordered")
+	}
+
+	// Plain text, CSV and chunked JSON can be written as each date arrives;
+	// other formats and --unique need the whole range first.
+	stream := streamsOutput(c) && !c.Bool("unique")
+
+	repo := newRepository(c)
+	var cves []models.CVE
+	fetch := func(date string) ([]models.CVE, error) {
+		return repo.GetCVEsForDate(c.Context, date)
+	}
+	err = service.FetchDates(dates, c.Int("parallel"), ordered, fetch, func(date string, daily []models.CVE) error {
+		if stream {
+			annotateResults(c, daily)
+			return p.PrintCVEs(daily)
+		}
+		cves = append(cves, daily...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get CVEs for date range: %w", err)
+	}
+	if stream {
+		return nil
+	}
+
+	if c.Bool("unique") {
+		policy, err := service.ParseDedupePolicy(c.String("keep"))
+		if err != nil {
+			return err
+		}
+		cves = service.Dedupe(cves, policy)
+	}
+	annotateResults(c, cves)
+	return p.PrintCVEs(cves)
+}
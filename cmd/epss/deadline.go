@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// deadlineCancelKey stores the cancel function of the --deadline context in
+// the app metadata so it is released once the command finishes.
+const deadlineCancelKey = "deadline-cancel"
+
+// applyDeadline bounds the context of the whole command by --deadline.
+// Repositories built from the context abort their requests once it expires.
+func applyDeadline(c *cli.Context) error {
+	d := c.Duration("deadline")
+	if d < 0 {
+		return fmt.Errorf("--deadline must not be negative")
+	}
+	if d == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(c.Context, d)
+	c.Context = ctx
+	if c.App.Metadata == nil {
+		c.App.Metadata = make(map[string]interface{})
+	}
+	c.App.Metadata[deadlineCancelKey] = cancel
+	return nil
+}
+
+// releaseDeadline cancels the --deadline context, if any.
+func releaseDeadline(c *cli.Context) {
+	if cancel, ok := c.App.Metadata[deadlineCancelKey].(context.CancelFunc); ok {
+		cancel()
+	}
+}
+
+// deadlineNotice explains an error caused by --deadline expiring or by the
+// command being interrupted.
+func deadlineNotice(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("deadline exceeded, any results already written are partial: %w", err)
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("interrupted, any results already written are partial: %w", err)
+	default:
+		return err
+	}
+}
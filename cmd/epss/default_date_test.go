@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDate(t *testing.T) {
+	var dates []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		dates = append(dates, date)
+		if date == "" {
+			date = "2024-10-17"
+		}
+		fmt.Fprintf(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":%q}]}`, date)
+	}))
+	defer mockServer.Close()
+
+	tests := []struct {
+		name        string
+		defaultDate string
+		wantDates   []string
+		wantErr     string
+	}{
+		{
+			name:        "Success - Latest Looks Up The Latest Data Date",
+			defaultDate: "latest",
+			wantDates:   []string{"", "2024-10-17"},
+		},
+		{
+			name:        "Success - Today Queries Today's Date",
+			defaultDate: "today",
+			wantDates:   []string{time.Now().Format("2006-01-02")},
+		},
+		{
+			name:        "Fail - Unsupported Value",
+			defaultDate: "yesterday",
+			wantErr:     "unsupported --default-date value: yesterday (expected latest or today)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates = nil
+
+			err := newApp().Run([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "score", "--cve", "CVE-2023-0001", "--default-date", tt.defaultDate})
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, dates)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDates, dates)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/pkg/epss"
+	"github.com/urfave/cli/v2"
+)
+
+// handleDiffReports compares two JSON result files saved from earlier runs.
+func handleDiffReports(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("expected two report files")
+	}
+	old, err := readReport(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	new, err := readReport(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("min-change") && !c.Bool("only-changed") {
+		return fmt.Errorf("--min-change requires --only-changed")
+	}
+	if c.Float64("min-change") < 0 {
+		return fmt.Errorf("--min-change must not be negative")
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	diff := service.DiffReports(old, new)
+	if c.Bool("only-changed") {
+		diff = service.OnlyChanged(diff, c.Float64("min-change"))
+	}
+	return p.PrintReportDiff(diff)
+}
+
+// readReport loads the CVEs of a saved JSON report.
+func readReport(path string) ([]models.CVE, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	cves, err := epss.DecodeCVEs(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cves, nil
+}
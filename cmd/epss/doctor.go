@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/health"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// secretFlagWords mark flag names whose values are never printed.
+var secretFlagWords = []string{"token", "secret", "password", "key", "webhook"}
+
+// effectiveConfig returns the tool version and the resolved value of every global flag, with
+// secrets and URL credentials redacted.
+func effectiveConfig(c *cli.Context) map[string]string {
+	config := map[string]string{"version": version}
+	for _, f := range c.App.Flags {
+		name := f.Names()[0]
+		// --help and --version are actions, not settings; the latter would
+		// also hide the tool version.
+		if name == "help" || name == "version" {
+			continue
+		}
+		config[name] = redact(name, fmt.Sprint(c.Value(name)))
+	}
+	return config
+}
+
+// isSecretFlag reports whether the flag called name holds a secret; see secretFlagWords.
+func isSecretFlag(name string) bool {
+	for _, word := range secretFlagWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact hides the value of secret flags and the password of URL values.
+func redact(name, value string) string {
+	if isSecretFlag(name) && value != "" {
+		return "REDACTED"
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return value
+}
+
+// handleDoctor checks that the environment is usable and exits non-zero when any check fails.
+func handleDoctor(c *cli.Context) error {
+	checks := []models.Check{health.CheckAPI(c.String("base-url"))}
+	if dir := cacheDir(c); dir != "" {
+		checks = append(checks, health.CheckWritableDir("cache dir writable", dir))
+	}
+	if dir := c.String("csv-dir"); dir != "" {
+		checks = append(checks, health.CheckReadableDir("CSV dir readable", dir))
+	}
+	if path := c.String("csv-file"); path != "" {
+		checks = append(checks, health.CheckReadableFile("CSV file readable", path))
+	}
+
+	// Invalid output flags are reported as a failed check, in plain text.
+	p, err := newPrinter(c)
+	if err != nil {
+		checks = append(checks, models.Check{Name: "output options valid", Detail: err.Error()})
+		p = printer.New(os.Stdout, printer.FormatText)
+	} else {
+		checks = append(checks, models.Check{Name: "output options valid", Passed: true, Detail: "ok"})
+	}
+	if err := p.PrintDiagnosis(effectiveConfig(c), checks); err != nil {
+		return err
+	}
+
+	var failed int
+	for _, check := range checks {
+		if !check.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d checks failed", failed, len(checks)), 1)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/elasticsearch"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/export"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/state"
+	"github.com/urfave/cli/v2"
+)
+
+// daySink stores the CVEs scored on one date and returns where they went.
+type daySink interface {
+	WriteDay(date string, cves []models.CVE) (string, error)
+}
+
+// newDaySink returns the export destination selected by --sink.
+func newDaySink(c *cli.Context) (daySink, error) {
+	switch c.String("sink") {
+	case "file":
+		if c.String("out") == "" {
+			return nil, fmt.Errorf("--out is required for the file sink")
+		}
+		opts := []export.Option{export.WithManifest(version, dataSource(c))}
+		switch c.String("partition-by") {
+		case "":
+		case "year":
+			opts = append(opts, export.WithPartitionByYear())
+		default:
+			return nil, fmt.Errorf("unsupported partition-by value: %s (expected year)", c.String("partition-by"))
+		}
+		return export.NewWriter(c.String("out"), opts...), nil
+	case "es":
+		if c.String("es-url") == "" {
+			return nil, fmt.Errorf("--es-url is required for the es sink")
+		}
+		return elasticsearch.NewIndexer(c.String("es-url"), c.String("es-index")), nil
+	default:
+		return nil, fmt.Errorf("unsupported sink: %s (expected file or es)", c.String("sink"))
+	}
+}
+
+// dataSource describes where the exported data comes from: the --csv-file
+// dataset, the --csv-dir archive or the API at --base-url, with any
+// credentials redacted.
+func dataSource(c *cli.Context) string {
+	if path := c.String("csv-file"); path != "" {
+		return path
+	}
+	if dir := c.String("csv-dir"); dir != "" {
+		return dir
+	}
+	return repository.RedactURL(c.String("base-url"))
+}
+
+// handleExport writes the CVEs of each date to the sink, by default one JSON
+// Lines file per date. With --since-last-run it exports only the dates
+// published since the previous run recorded in --state.
+func handleExport(c *cli.Context) error {
+	writer, err := newDaySink(c)
+	if err != nil {
+		return err
+	}
+	repo := newRepository(c)
+
+	end := c.String("end")
+	if end == "" {
+		latest, err := latestDataDate(c.Context, repo)
+		if err != nil {
+			return err
+		}
+		end = latest
+	}
+
+	var st service.IncrementalState
+	var dates []string
+	if c.Bool("since-last-run") {
+		if _, err := state.Load(statePath(c), &st); err != nil {
+			return err
+		}
+		dates, err = service.PendingDates(st.LastDate, c.String("start"), end)
+	} else {
+		if c.String("start") == "" {
+			return fmt.Errorf("--start is required unless --since-last-run is set")
+		}
+		dates, err = service.DatesBetween(c.String("start"), end)
+	}
+	if err != nil {
+		return err
+	}
+	if len(dates) == 0 {
+		log.Printf("Nothing to export; data is up to date as of %s", st.LastDate)
+		return nil
+	}
+
+	process := func(date string) error {
+		cves, err := repo.GetCVEsForDate(c.Context, date)
+		if err != nil {
+			return err
+		}
+		path, err := writer.WriteDay(date, cves)
+		if err != nil {
+			return err
+		}
+		log.Printf("Exported %d CVE(s) for %s to %s", len(cves), date, path)
+		return nil
+	}
+	save := func(st service.IncrementalState) error {
+		if !c.Bool("since-last-run") {
+			return nil
+		}
+		return state.Save(statePath(c), st)
+	}
+	return service.RunIncremental(&st, dates, process, save)
+}
+
+// statePath returns the incremental state file, defaulting to one in the
+// output directory (the working directory when exporting to a search index).
+func statePath(c *cli.Context) string {
+	if path := c.String("state"); path != "" {
+		return path
+	}
+	return filepath.Join(c.String("out"), "export-state.json")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/kev"
+	"github.com/urfave/cli/v2"
+)
+
+// exitGate is the exit status when --fail-on-kev or --fail-over rejects the
+// scanned CVEs.
+const exitGate = 2
+
+// gateFlags are the CI gate flags shared by scores and scan.
+func gateFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "fail-on-kev",
+			Usage: fmt.Sprintf("Exit with status %d if any CVE is listed in the CISA KEV catalog, regardless of EPSS", exitGate),
+		},
+		&cli.Float64Flag{
+			Name:  "fail-over",
+			Usage: fmt.Sprintf("Exit with status %d if any CVE's EPSS score exceeds this value (0-1)", exitGate),
+		},
+	}
+}
+
+// checkGate fails with exitGate, listing the offending CVEs, when any of ids
+// breaks --fail-on-kev or --fail-over. catalog is fetched from --kev-url when
+// --fail-on-kev is set and the caller has not already loaded it.
+func checkGate(c *cli.Context, ids []string, scores []models.CVE, catalog ports.KEVCatalog) error {
+	failOnKEV, failOver := c.Bool("fail-on-kev"), c.Float64("fail-over")
+	if !failOnKEV && failOver <= 0 {
+		return nil
+	}
+	if !failOnKEV {
+		catalog = nil
+	} else if catalog == nil {
+		fetched, err := kev.NewClient(c.String("kev-url")).FetchCatalog()
+		if err != nil {
+			return err
+		}
+		catalog = fetched
+	}
+
+	failures := service.CheckGate(ids, scores, catalog, failOver)
+	if len(failures) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "release gate failed for %d CVE(s):", len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(&b, "\n  %s: %s", f.CVE, strings.Join(f.Reasons, "; "))
+	}
+	return cli.Exit(b.String(), exitGate)
+}
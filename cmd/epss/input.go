@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+)
+
+// splitCVEs parses a comma-separated list of CVE IDs, dropping blanks.
+func splitCVEs(list string) []string {
+	var ids []string
+	for _, id := range strings.Split(list, ",") {
+		id = strings.ToUpper(strings.TrimSpace(id))
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// readCVEList reads one CVE ID per line, skipping blank lines, lines starting
+// with # and repeated IDs.
+func readCVEList(r io.Reader) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		id, err := service.ValidateCVEID(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CVE list: %w", err)
+	}
+	return ids, nil
+}
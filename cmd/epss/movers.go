@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/urfave/cli/v2"
+)
+
+// handlePercentileMovers ranks CVEs by how far their percentile moved between
+// the latest data and --days earlier.
+func handlePercentileMovers(c *cli.Context) error {
+	days := c.Int("days")
+	if days < 1 {
+		return fmt.Errorf("invalid days value: %d (must be at least 1)", days)
+	}
+	limit := c.Int("limit")
+	if limit < 1 {
+		return fmt.Errorf("invalid limit value: %d (must be at least 1)", limit)
+	}
+
+	repo := newRepository(c)
+	latest, err := latestDataDate(c.Context, repo)
+	if err != nil {
+		return err
+	}
+	end, err := time.Parse("2006-01-02", latest)
+	if err != nil {
+		return fmt.Errorf("invalid latest date: %w", err)
+	}
+	start := end.AddDate(0, 0, -days).Format("2006-01-02")
+
+	snapshots := make(map[string][]models.CVE, 2)
+	fetch := func(date string) ([]models.CVE, error) {
+		return repo.GetAllCVEsForDate(c.Context, date)
+	}
+	err = service.FetchDates([]string{start, latest}, 2, false, fetch, func(date string, cves []models.CVE) error {
+		snapshots[date] = cves
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get percentile changes: %w", err)
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintPercentileMovers(service.PercentileMovers(snapshots[start], snapshots[latest], end, limit))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvefile"
+	"github.com/urfave/cli/v2"
+)
+
+// handlePortfolioTrend prints the weighted risk of the portfolio in --file at
+// every --step from --start to --end.
+func handlePortfolioTrend(c *cli.Context) error {
+	portfolio, err := readPortfolio(c)
+	if err != nil {
+		return err
+	}
+	step, err := service.ParseStepDays(c.String("step"))
+	if err != nil {
+		return err
+	}
+	repo := newRepository(c)
+	end := c.String("end")
+	if end == "" {
+		if end, err = runLatestDate(c, repo); err != nil {
+			return err
+		}
+	}
+	dates, err := service.StepDates(c.String("start"), end, step)
+	if err != nil {
+		return err
+	}
+
+	points, err := service.PortfolioTrend(c.Context, repo, portfolio, dates, c.Int("parallel"))
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintPortfolioTrend(points)
+}
+
+// readPortfolio reads the CVEs of --file, weighting each by its
+// --weight-column value (1 without one). A CVE listed on several rows gets
+// the sum of their weights.
+func readPortfolio(c *cli.Context) (service.Portfolio, error) {
+	path := c.String("file")
+	table, err := cvefile.ReadFile(path, c.String("cve-column-name"))
+	if err != nil {
+		return nil, err
+	}
+	if len(table.Rows) == 0 {
+		return nil, fmt.Errorf("no CVE IDs found in %s", path)
+	}
+	column := -1
+	if name := c.String("weight-column"); name != "" {
+		for i, h := range table.Header {
+			if strings.EqualFold(h, name) {
+				column = i
+			}
+		}
+		if column < 0 {
+			return nil, fmt.Errorf("weight column %q not found in %s", name, path)
+		}
+	}
+
+	portfolio := make(service.Portfolio, len(table.Rows))
+	for i, row := range table.Rows {
+		weight := 1.0
+		if column >= 0 {
+			if weight, err = strconv.ParseFloat(strings.TrimSpace(row[column]), 64); err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight %q for %s in %s", row[column], table.IDs()[i], path)
+			}
+		}
+		portfolio[row[table.Column]] += weight
+	}
+	return portfolio, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/state"
+	"github.com/urfave/cli/v2"
+)
+
+// earliestProbeDate bounds the search for the first available date. EPSS data
+// starts in 2021, so the search has a little room on either side.
+const earliestProbeDate = "2020-01-01"
+
+// dataRangeFile is the file in --cache-dir that remembers the data range.
+const dataRangeFile = "data-range.json"
+
+// latestDataDate returns the most recent date the API has published data for.
+func latestDataDate(ctx context.Context, repo ports.EPSSRepository) (string, error) {
+	cves, err := repo.GetTopNCVEs(ctx, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine latest data date: %w", err)
+	}
+	if len(cves) == 0 || cves[0].Date == "" {
+		return "", fmt.Errorf("failed to determine latest data date: no data returned")
+	}
+	return cves[0].Date, nil
+}
+
+// latestDateKey stores the latest data date in the app metadata once resolved,
+// so every query of a run uses the same snapshot.
+const latestDateKey = "latest-date"
+
+// runLatestDate returns the latest data date, resolving it on first use and
+// reusing it for the rest of the run.
+func runLatestDate(c *cli.Context, repo ports.EPSSRepository) (string, error) {
+	if date, ok := c.App.Metadata[latestDateKey].(string); ok {
+		return date, nil
+	}
+	date, err := latestDataDate(c.Context, repo)
+	if err != nil {
+		return "", err
+	}
+	if c.App.Metadata == nil {
+		c.App.Metadata = make(map[string]interface{})
+	}
+	c.App.Metadata[latestDateKey] = date
+	return date, nil
+}
+
+// handleDataRange reports the earliest and latest dates with available data,
+// or with --cve the CVE's scores between --start and --end.
+// The earliest date never changes, so it is remembered in --cache-dir once found.
+func handleDataRange(c *cli.Context) error {
+	if c.IsSet("cve") {
+		return handleCVERange(c)
+	}
+	if c.IsSet("start") || c.IsSet("end") {
+		return fmt.Errorf("--start and --end require --cve")
+	}
+	repo := newRepository(c)
+	latest, err := latestDataDate(c.Context, repo)
+	if err != nil {
+		return err
+	}
+
+	var dataRange models.DataRange
+	var cachePath string
+	if dir := cacheDir(c); dir != "" {
+		cachePath = filepath.Join(dir, dataRangeFile)
+		if _, err := state.Load(cachePath, &dataRange); err != nil {
+			return err
+		}
+	}
+
+	if dataRange.Earliest == "" {
+		earliest, err := service.FindEarliestDate(earliestProbeDate, latest, func(date string) (bool, error) {
+			total, err := repo.GetTotalCVEs(c.Context, date)
+			return total > 0, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find earliest data date: %w", err)
+		}
+		dataRange.Earliest = earliest
+	}
+	dataRange.Latest = latest
+
+	if cachePath != "" {
+		if err := state.Save(cachePath, dataRange); err != nil {
+			return err
+		}
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintDataRange(dataRange)
+}
+
+// handleCVERange prints the daily scores of --cve from --start to --end, oldest first.
+func handleCVERange(c *cli.Context) error {
+	if !c.IsSet("start") || !c.IsSet("end") {
+		return fmt.Errorf("--cve requires --start and --end")
+	}
+	start, err := time.Parse("2006-01-02", c.String("start"))
+	if err != nil {
+		return fmt.Errorf("invalid start date format: %w", err)
+	}
+	end, err := time.Parse("2006-01-02", c.String("end"))
+	if err != nil {
+		return fmt.Errorf("invalid end date format: %w", err)
+	}
+
+	svc := service.NewEPSSService(newRepository(c), service.WithParallel(c.Int("parallel")), service.WithMaxIncreaseDays(c.Int("max-days"), c.Bool("force")))
+	cves, err := svc.GetScoresInRange(c.Context, c.String("cve"), start, end)
+	if errors.Is(err, service.ErrWindowTooLarge) {
+		return fmt.Errorf("%w; narrow --start and --end, raise --max-days or pass --force", err)
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get scores in range: %w", err)
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	return p.PrintCVEs(cves)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// registry holds the commands added with RegisterCommand, in registration order.
+var registry []*cli.Command
+
+// RegisterCommand adds cmd to the epss binary alongside the core commands.
+// Call it from an init function in a file of this package built only with a
+// build tag, such as a jira_sync.go starting with //go:build jirasync, so
+// organizations can ship internal subcommands without changing main.go. It
+// panics when a command with the same name is already registered.
+func RegisterCommand(cmd *cli.Command) {
+	for _, registered := range registry {
+		if registered.Name == cmd.Name {
+			panic(fmt.Sprintf("epss: command %q registered twice", cmd.Name))
+		}
+	}
+	registry = append(registry, cmd)
+}
+
+// withRegistered returns the core commands followed by the registered ones.
+// It panics when a registered command would shadow a core command.
+func withRegistered(core []*cli.Command) []*cli.Command {
+	names := make(map[string]bool, len(core))
+	for _, cmd := range core {
+		names[cmd.Name] = true
+	}
+	commands := append([]*cli.Command{}, core...)
+	for _, cmd := range registry {
+		if names[cmd.Name] {
+			panic(fmt.Sprintf("epss: registered command %q conflicts with a built-in command", cmd.Name))
+		}
+		commands = append(commands, cmd)
+	}
+	return commands
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// resetRegistry restores the registry once the test finishes.
+func resetRegistry(t *testing.T) {
+	saved := registry
+	t.Cleanup(func() { registry = saved })
+}
+
+func TestRegisterCommand(t *testing.T) {
+	t.Run("Success - Registered Command Appears After Core Commands", func(t *testing.T) {
+		resetRegistry(t)
+		RegisterCommand(&cli.Command{Name: "jira-sync", Usage: "Sync findings to Jira"})
+
+		app := newApp()
+
+		assert.NotNil(t, app.Command("jira-sync"))
+		assert.NotNil(t, app.Command("score"))
+		assert.Equal(t, "jira-sync", app.Commands[len(app.Commands)-1].Name)
+	})
+
+	t.Run("Fail - Duplicate Registration Panics", func(t *testing.T) {
+		resetRegistry(t)
+		RegisterCommand(&cli.Command{Name: "jira-sync"})
+
+		assert.Panics(t, func() { RegisterCommand(&cli.Command{Name: "jira-sync"}) })
+	})
+
+	t.Run("Fail - Shadowing A Core Command Panics", func(t *testing.T) {
+		resetRegistry(t)
+		RegisterCommand(&cli.Command{Name: "score"})
+
+		assert.Panics(t, func() { newApp() })
+	})
+}
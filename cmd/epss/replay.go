@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+)
+
+// savedQueryVersion is the format version written by --save-query.
+const savedQueryVersion = 1
+
+// savedQuery is an invocation stored by --save-query and re-run by replay.
+// Flags hold the explicitly set values by flag name; slice flags hold one
+// entry per value.
+type savedQuery struct {
+	Version     int                 `json:"version"`
+	Command     string              `json:"command"`
+	GlobalFlags map[string][]string `json:"global_flags,omitempty"`
+	Flags       map[string][]string `json:"flags,omitempty"`
+	Args        []string            `json:"args,omitempty"`
+}
+
+// withQuerySaving makes every command honor --save-query before it runs.
+func withQuerySaving(commands []*cli.Command) []*cli.Command {
+	for _, cmd := range commands {
+		before := cmd.Before
+		cmd.Before = func(c *cli.Context) error {
+			if err := saveQuery(c); err != nil {
+				return err
+			}
+			if before != nil {
+				return before(c)
+			}
+			return nil
+		}
+	}
+	return commands
+}
+
+// saveQuery writes the current invocation to the --save-query file, if set.
+func saveQuery(c *cli.Context) error {
+	path := c.String("save-query")
+	if path == "" {
+		return nil
+	}
+	if c.Command.Name == "replay" {
+		return fmt.Errorf("--save-query cannot be used with replay")
+	}
+	q := savedQuery{
+		Version:     savedQueryVersion,
+		Command:     c.Command.Name,
+		GlobalFlags: setFlags(c, c.App.Flags),
+		Flags:       setFlags(c, c.Command.Flags),
+		Args:        c.Args().Slice(),
+	}
+	delete(q.GlobalFlags, "save-query")
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to save query: %w", err)
+	}
+	return nil
+}
+
+// setFlags returns the values of the flags set in c or its parents, keyed by
+// flag name; global and command flags never share a name.
+// Flags that can come from the environment, such as --nvd-api-key, and those
+// doctor redacts, such as --webhook, hold secrets and are left out so saved
+// queries can be shared; credentials are removed from URL values.
+func setFlags(c *cli.Context, flags []cli.Flag) map[string][]string {
+	values := make(map[string][]string)
+	for _, f := range flags {
+		name := f.Names()[0]
+		if env, ok := f.(cli.DocGenerationFlag); ok && len(env.GetEnvVars()) > 0 {
+			continue
+		}
+		if isSecretFlag(name) {
+			continue
+		}
+		if !c.IsSet(name) {
+			continue
+		}
+		switch f.(type) {
+		case *cli.StringSliceFlag:
+			for _, v := range c.StringSlice(name) {
+				values[name] = append(values[name], stripCredentials(v))
+			}
+		case *cli.Float64SliceFlag:
+			for _, v := range c.Float64Slice(name) {
+				values[name] = append(values[name], strconv.FormatFloat(v, 'g', -1, 64))
+			}
+		default:
+			values[name] = []string{stripCredentials(fmt.Sprint(c.Value(name)))}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// stripCredentials removes the user and password from value when it is a URL
+// carrying them, and returns other values unchanged.
+func stripCredentials(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	u.User = nil
+	return u.String()
+}
+
+// handleReplay re-runs the query saved in the file given as argument. Global
+// flags passed to replay itself, such as --output, override the saved ones.
+func handleReplay(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("replay takes exactly one saved query file")
+	}
+	data, err := os.ReadFile(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read saved query: %w", err)
+	}
+	var q savedQuery
+	if err := json.Unmarshal(data, &q); err != nil {
+		return fmt.Errorf("invalid saved query: %w", err)
+	}
+	if q.Version != savedQueryVersion {
+		return fmt.Errorf("unsupported saved query version %d", q.Version)
+	}
+	if q.Command == "" || q.Command == "replay" || c.App.Command(q.Command) == nil {
+		return fmt.Errorf("saved query has unknown command %q", q.Command)
+	}
+
+	global := make(map[string][]string, len(q.GlobalFlags))
+	for name, values := range q.GlobalFlags {
+		global[name] = values
+	}
+	for name, values := range setFlags(c, c.App.Flags) {
+		global[name] = values
+	}
+	args := []string{c.App.Name}
+	args = appendFlags(args, global)
+	args = append(args, q.Command)
+	args = appendFlags(args, q.Flags)
+	if len(q.Args) > 0 {
+		args = append(append(args, "--"), q.Args...)
+	}
+	return newApp().RunContext(c.Context, args)
+}
+
+// appendFlags appends flags to args as --name=value, in name order.
+func appendFlags(args []string, flags map[string][]string) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range flags[name] {
+			args = append(args, "--"+name+"="+value)
+		}
+	}
+	return args
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/urfave/cli/v2"
+)
+
+// handleSample retrieves a fixed number of CVEs from each percentile band for a date.
+func handleSample(c *cli.Context) error {
+	repo := newRepository(c)
+	cves, err := service.SampleByPercentileBand(c.Context, repo, c.String("date"), c.Int("bands"), c.Int("per-band"))
+	if err != nil {
+		return fmt.Errorf("failed to sample CVEs: %w", err)
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	return p.PrintCVEs(cves)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/kev"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/scanners"
+	"github.com/urfave/cli/v2"
+)
+
+// handleScan enriches the CVEs in a scanner report with EPSS scores, keeping
+// each finding tied to its package. The report is read from stdin when the
+// argument is "-". With --aggregate-by-component, findings are summarized
+// per package version instead. --fail-on-kev and --fail-over turn the scan
+// into a CI gate.
+func handleScan(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected one report file (or - for stdin)")
+	}
+	path := c.Args().First()
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open report: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	findings, err := scanners.Parse(c.String("input-format"), r)
+	if err != nil {
+		return err
+	}
+
+	ids := service.FindingCVEs(findings)
+	var cves []models.CVE
+	if len(ids) > 0 {
+		cves, err = newRepository(c).GetCVEScores(c.Context, ids, c.String("date"))
+		if err != nil {
+			return fmt.Errorf("failed to get CVE scores: %w", err)
+		}
+		service.EnrichFindings(findings, cves)
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	var catalog ports.KEVCatalog
+	if !c.Bool("aggregate-by-component") {
+		if err := p.PrintFindings(findings); err != nil {
+			return err
+		}
+	} else {
+		fetched, err := kev.NewClient(c.String("kev-url")).FetchCatalog()
+		if err != nil {
+			return err
+		}
+		catalog = fetched
+		if err := p.PrintComponents(service.AggregateByComponent(findings, fetched)); err != nil {
+			return err
+		}
+	}
+	return checkGate(c, ids, cves, catalog)
+}
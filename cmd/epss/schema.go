@@ -0,0 +1,25 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/schema"
+	"github.com/urfave/cli/v2"
+)
+
+// handleSchema prints the JSON Schema of the tool's JSON results. It is
+// always JSON, whatever --output says.
+func handleSchema(c *cli.Context) error {
+	doc, err := schema.Document(c.String("type"))
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return nil
+}
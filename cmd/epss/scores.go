@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvefile"
+	"github.com/urfave/cli/v2"
+)
+
+// handleGetScores retrieves EPSS scores for several CVE IDs in batched requests.
+// With --file, the CVEs are read from a CSV file and its other columns are
+// passed through to the output. With --keep-going, failed batches are recorded
+// instead of stopping the run, and --retry-failed reruns just those CVEs.
+func handleGetScores(c *cli.Context) error {
+	if c.IsSet("retry-failed") {
+		return handleRetryFailed(c)
+	}
+	if c.IsSet("file") {
+		return handleGetScoresForFile(c)
+	}
+	cveIDs := splitCVEs(c.String("cves"))
+	if len(cveIDs) == 0 {
+		return fmt.Errorf("no CVE IDs given (use --cves or --file)")
+	}
+	if c.Bool("keep-going") {
+		return handleScoreBatches(c, cveIDs)
+	}
+
+	repo := newRepository(c)
+	date, err := scoresDate(c, repo)
+	if err != nil {
+		return err
+	}
+	cves, err := repo.GetCVEScores(c.Context, cveIDs, date)
+	if err != nil {
+		return fmt.Errorf("failed to get CVE scores: %w", err)
+	}
+	if missing := service.UnscoredIDs(cveIDs, cves); len(missing) > 0 {
+		log.Printf("No EPSS score found for %d of %d CVE(s): %s", len(missing), len(cveIDs), strings.Join(missing, ", "))
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	if err := p.PrintCVEs(cves); err != nil {
+		return err
+	}
+	return checkGate(c, cveIDs, cves, nil)
+}
+
+// handleGetScoresForFile enriches each row of --file with the EPSS score of its CVE.
+func handleGetScoresForFile(c *cli.Context) error {
+	if c.IsSet("cves") {
+		return fmt.Errorf("--cves and --file cannot be used together")
+	}
+	if c.Bool("keep-going") {
+		return fmt.Errorf("--keep-going is not supported with --file")
+	}
+	table, err := cvefile.ReadFile(c.String("file"), c.String("cve-column-name"))
+	if err != nil {
+		return err
+	}
+
+	var cves []models.CVE
+	if ids := table.IDs(); len(ids) > 0 {
+		repo := newRepository(c)
+		date, err := scoresDate(c, repo)
+		if err != nil {
+			return err
+		}
+		cves, err = repo.GetCVEScores(c.Context, ids, date)
+		if err != nil {
+			return fmt.Errorf("failed to get CVE scores: %w", err)
+		}
+	}
+	byID := make(map[string]*models.CVE, len(cves))
+	for i := range cves {
+		byID[cves[i].ID] = &cves[i]
+	}
+	scores := make([]*models.CVE, len(table.Rows))
+	for i, id := range table.IDs() {
+		scores[i] = byID[id]
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	if err := p.PrintRecords(table.Header, table.Rows, scores); err != nil {
+		return err
+	}
+	return checkGate(c, table.IDs(), cves, nil)
+}
+
+// scoresDate returns the date to score CVEs for. With --as-of latest, the latest
+// data date is resolved once up front so every batch request queries the same
+// snapshot, even if new data is published mid-run.
+func scoresDate(c *cli.Context, repo ports.EPSSRepository) (string, error) {
+	asOf := c.String("as-of")
+	switch {
+	case asOf == "":
+		return c.String("date"), nil
+	case c.IsSet("date"):
+		return "", fmt.Errorf("--as-of and --date cannot be used together")
+	case asOf == "latest":
+		return runLatestDate(c, repo)
+	default:
+		return "", fmt.Errorf("unsupported --as-of value: %s (expected latest)", asOf)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoresAsOf(t *testing.T) {
+	var queries []url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			date = "2024-10-17"
+		}
+		fmt.Fprintf(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":%q}]}`, date)
+	}))
+	defer mockServer.Close()
+	scores := func(args ...string) error {
+		queries = nil
+		return newApp().Run(append([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "scores", "--cves", "CVE-2023-0001,CVE-2023-0002"}, args...))
+	}
+
+	t.Run("Success - Latest Is Resolved Once And Queried", func(t *testing.T) {
+		require.NoError(t, scores("--as-of", "latest"))
+
+		require.Len(t, queries, 2)
+		assert.Empty(t, queries[0].Get("cve"), "the first request looks up the latest date")
+		assert.Equal(t, "CVE-2023-0001,CVE-2023-0002", queries[1].Get("cve"))
+		assert.Equal(t, "2024-10-17", queries[1].Get("date"))
+	})
+
+	t.Run("Fail - As Of With Date", func(t *testing.T) {
+		err := scores("--as-of", "latest", "--date", "2024-10-18")
+
+		assert.EqualError(t, err, "--as-of and --date cannot be used together")
+		assert.Empty(t, queries)
+	})
+
+	t.Run("Fail - Unsupported As Of Value", func(t *testing.T) {
+		err := scores("--as-of", "yesterday")
+
+		assert.EqualError(t, err, "unsupported --as-of value: yesterday (expected latest)")
+		assert.Empty(t, queries)
+	})
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// streamBatchSize is the number of rows printed at a time while streaming.
+const streamBatchSize = 1000
+
+// streamCVEsForDate prints every CVE scored on date as its page arrives,
+// keeping memory bounded regardless of the size of the day.
+func streamCVEsForDate(c *cli.Context, repo ports.EPSSRepository, p *printer.Printer, date string) error {
+	pager, ok := repo.(ports.CVEPager)
+	if !ok {
+		return fmt.Errorf("the configured source does not support paging")
+	}
+
+	it := service.IterateCVEs(c.Context, pager, date, nil)
+	batch := make([]models.CVE, 0, streamBatchSize)
+	flush := func() error {
+		if err := annotateRank(c, repo, batch, date); err != nil {
+			return err
+		}
+		annotateResults(c, batch)
+		err := p.PrintCVEs(batch)
+		batch = batch[:0]
+		return err
+	}
+	for it.Next() {
+		batch = append(batch, it.CVE())
+		if len(batch) == streamBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to get CVEs for date: %w", err)
+	}
+	return flush()
+}
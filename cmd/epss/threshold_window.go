@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/urfave/cli/v2"
+)
+
+// handleThresholdWindow prints every CVE whose --field exceeded --threshold
+// on any of the --days days ending at --end, at its peak score.
+func handleThresholdWindow(c *cli.Context) error {
+	repo := newRepository(c)
+	end := c.String("end")
+	if end == "" {
+		var err error
+		if end, err = runLatestDate(c, repo); err != nil {
+			return err
+		}
+	}
+	dates, err := service.WindowDates(end, c.Int("days"))
+	if err != nil {
+		return err
+	}
+
+	cves, err := service.ThresholdPeaks(c.Context, repo, dates, c.Float64("threshold"), c.String("field"), c.Int("parallel"))
+	if err != nil {
+		return fmt.Errorf("failed to get CVEs above threshold: %w", err)
+	}
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	return p.PrintCVEs(cves)
+}
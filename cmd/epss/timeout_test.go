@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	t.Run("Success - Rate Limit Pause Does Not Count Against The Timeout", func(t *testing.T) {
+		// The first response exhausts the budget for two seconds, so the
+		// second request pauses longer than the one-second --timeout.
+		var calls int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "2")
+			fmt.Fprint(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":"2024-10-17"}]}`)
+		}))
+		defer mockServer.Close()
+
+		err := newApp().Run([]string{"epss", "--base-url", mockServer.URL, "--no-cache", "--timeout", "1",
+			"scores", "--cves", "CVE-2023-0001", "--as-of", "latest"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
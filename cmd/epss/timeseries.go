@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/urfave/cli/v2"
+)
+
+// handleGetTimeSeries retrieves the time series of one or more CVEs. Series are
+// fetched concurrently and each is sorted by date; text and CSV output stream
+// each series as it arrives. A CVE that fails to fetch is reported without
+// stopping the others.
+func handleGetTimeSeries(c *cli.Context) error {
+	ids := splitCVEs(c.String("cves"))
+	if cve := c.String("cve"); cve != "" {
+		ids = append([]string{cve}, ids...)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no CVE IDs given; use --cve or --cves")
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	stream := streamsOutput(c)
+
+	repo := newRepository(c)
+	var mu sync.Mutex
+	var failures []error
+	fetch := func(id string) ([]models.CVE, error) {
+		cves, err := repo.GetTimeSeries(c.Context, id)
+		if err != nil {
+			log.Printf("Failed to get time series for %s: %v", id, err)
+			mu.Lock()
+			failures = append(failures, fmt.Errorf("%s: %w", id, err))
+			mu.Unlock()
+			return nil, nil
+		}
+		return cves, nil
+	}
+
+	var all []models.CVE
+	err = service.FetchEach(ids, c.Int("parallel"), false, fetch, func(id string, cves []models.CVE) error {
+		service.SortByDate(cves)
+		annotateResults(c, cves)
+		all = append(all, cves...)
+		if stream {
+			return p.PrintCVEs(cves)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !stream {
+		if err := p.PrintCVEs(all); err != nil {
+			return err
+		}
+	}
+
+	if path := c.String("output-charts"); path != "" && len(all) > 0 {
+		if err := writeChart(path, all); err != nil {
+			return err
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to get time series for %d of %d CVE(s): %w", len(failures), len(ids), errors.Join(failures...))
+	}
+	return nil
+}
+
+// handleTrend fits a line to the recent time series of --cve and prints whether
+// its score is rising, falling or stable.
+func handleTrend(c *cli.Context) error {
+	trend, err := service.GetScoreTrend(c.Context, newRepository(c), c.String("cve"), c.Int("days"))
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintTrend(*trend)
+}
+
+// handleStability prints how many days the score of --cve has held its
+// current value.
+func handleStability(c *cli.Context) error {
+	stability, err := service.GetScoreStability(c.Context, newRepository(c), c.String("cve"), c.Int("days"), c.Float64("epsilon"))
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintStability(*stability)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/urfave/cli/v2"
+)
+
+// handleTopPercentile retrieves every CVE in the top --pct percent of scores.
+func handleTopPercentile(c *cli.Context) error {
+	date := c.String("date")
+	repo := newRepository(c)
+	cves, err := service.TopPercentile(c.Context, repo, date, c.Float64("pct"))
+	if err != nil {
+		return fmt.Errorf("failed to get top percentile CVEs: %w", err)
+	}
+	if err := annotateRank(c, repo, cves, date); err != nil {
+		return err
+	}
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	annotateResults(c, cves)
+	return p.PrintCVEs(cves)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+)
+
+// upstreamKey stores the run's upstream query log in the app metadata, so
+// every repository of a command records into the same log.
+const upstreamKey = "upstream"
+
+// queryLog records the distinct query parameter sets sent upstream during a
+// run, in first-use order. Repositories may record from several goroutines.
+type queryLog struct {
+	mu      sync.Mutex
+	seen    map[string]bool
+	queries []map[string]string
+}
+
+// record adds params unless an identical set was already recorded.
+func (l *queryLog) record(params map[string]string) {
+	values := url.Values{}
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	key := values.Encode()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen[key] {
+		return
+	}
+	l.seen[key] = true
+	l.queries = append(l.queries, params)
+}
+
+// list returns the recorded queries.
+func (l *queryLog) list() []map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]map[string]string(nil), l.queries...)
+}
+
+// runQueryLog returns the run's upstream query log when --with-meta is set.
+func runQueryLog(c *cli.Context) *queryLog {
+	if !c.Bool("with-meta") {
+		return nil
+	}
+	if log, ok := c.App.Metadata[upstreamKey].(*queryLog); ok {
+		return log
+	}
+	if c.App.Metadata == nil {
+		c.App.Metadata = make(map[string]interface{})
+	}
+	log := &queryLog{seen: make(map[string]bool)}
+	c.App.Metadata[upstreamKey] = log
+	return log
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/urfave/cli/v2"
+)
+
+// handleVerify compares API data against the CSV dataset for a date and exits
+// non-zero when they disagree beyond the tolerance.
+func handleVerify(c *cli.Context) error {
+	date := c.String("date")
+	repo := newAPIRepository(c)
+	csvRepo := newCSVRepository(c)
+
+	var apiCVEs []models.CVE
+	var err error
+	scope := splitCVEs(c.String("cves"))
+	if len(scope) > 0 {
+		apiCVEs, err = repo.GetCVEScores(c.Context, scope, date)
+	} else {
+		apiCVEs, err = repo.GetCVEsForDate(c.Context, date)
+		for _, cve := range apiCVEs {
+			scope = append(scope, cve.ID)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get API data: %w", err)
+	}
+
+	csvCVEs, err := csvRepo.GetCVEScores(c.Context, scope, date)
+	if err != nil {
+		return fmt.Errorf("failed to get CSV data: %w", err)
+	}
+
+	discrepancies := service.Reconcile(scope, apiCVEs, csvCVEs, c.Float64("tolerance"))
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	if err := p.PrintDiscrepancies(discrepancies, service.CountByCategory(discrepancies), c.Bool("verbose")); err != nil {
+		return err
+	}
+
+	if len(discrepancies) > 0 {
+		return cli.Exit(fmt.Sprintf("found %d discrepancies between API and CSV data", len(discrepancies)), 1)
+	}
+	return nil
+}
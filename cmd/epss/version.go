@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Build metadata, set at build time with, for example:
+//
+//	go build -ldflags "-X main.version=1.4.0 -X main.commit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/epss
+//
+// Values left unset are filled from the module and VCS information Go embeds
+// in the binary, when there is any.
+var (
+	// version is the tool version, also reported in output metadata.
+	version = "dev"
+	// commit is the git commit the binary was built from.
+	commit = ""
+	// buildDate is when the binary was built, in RFC 3339 format.
+	buildDate = ""
+)
+
+func init() {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if version == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch {
+			case s.Key == "vcs.revision" && commit == "":
+				commit = s.Value
+			case s.Key == "vcs.time" && buildDate == "":
+				buildDate = s.Value
+			}
+		}
+	}
+	cli.VersionPrinter = func(c *cli.Context) {
+		printVersion(c.App.Writer)
+	}
+}
+
+// handleVersion prints the build metadata.
+func handleVersion(c *cli.Context) error {
+	printVersion(c.App.Writer)
+	return nil
+}
+
+// printVersion writes the build metadata to w as one "name: value" line per
+// field, writing unknown for values that are not known.
+func printVersion(w io.Writer) {
+	fields := []struct{ name, value string }{
+		{"version", version},
+		{"commit", commit},
+		{"built", buildDate},
+		{"go", runtime.Version()},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			f.value = "unknown"
+		}
+		fmt.Fprintf(w, "%s: %s\n", f.name, f.value)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion(t *testing.T) {
+	run := func(args ...string) []string {
+		var out bytes.Buffer
+		app := newApp()
+		app.Writer = &out
+		require.NoError(t, app.Run(append([]string{"epss"}, args...)))
+		return strings.Split(strings.TrimSpace(out.String()), "\n")
+	}
+
+	t.Run("Success - Command Prints One Field Per Line", func(t *testing.T) {
+		lines := run("version")
+
+		require.Len(t, lines, 4)
+		assert.Equal(t, "version: "+version, lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "commit: "))
+		assert.True(t, strings.HasPrefix(lines[2], "built: "))
+		assert.Equal(t, "go: "+runtime.Version(), lines[3])
+	})
+
+	t.Run("Success - Global Flag Matches The Command", func(t *testing.T) {
+		assert.Equal(t, run("version"), run("--version"))
+	})
+}
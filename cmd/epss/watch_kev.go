@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/kev"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/notifier"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/state"
+	"github.com/urfave/cli/v2"
+)
+
+// kevWatchState is the snapshot persisted between watch-kev runs.
+type kevWatchState struct {
+	Listed    []string  `json:"listed"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// newNotifier builds the alert notifier configured by the command flags.
+func newNotifier(c *cli.Context) notifier.Notifier {
+	notifiers := []notifier.Notifier{notifier.NewWriterNotifier(os.Stdout)}
+	if url := c.String("webhook"); url != "" {
+		notifiers = append(notifiers, notifier.NewWebhookNotifier(url))
+	}
+	return notifier.NewMultiNotifier(notifiers...)
+}
+
+// minWatchInterval keeps daemon mode from polling the KEV feed aggressively.
+const minWatchInterval = time.Minute
+
+// kevWatcher checks tracked CVEs against the KEV catalog, alerting when any
+// is newly listed since the snapshot in its state file.
+type kevWatcher struct {
+	tracked   []string
+	statePath string
+	client    *kev.Client
+	notifier  notifier.Notifier
+
+	// sleep waits between watch cycles. Tests replace it to run cycles
+	// without waiting.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newKEVWatcher builds the watcher configured by the command flags.
+func newKEVWatcher(c *cli.Context, tracked []string) *kevWatcher {
+	return &kevWatcher{
+		tracked:   tracked,
+		statePath: c.String("state"),
+		client:    kev.NewClient(c.String("kev-url")),
+		notifier:  newNotifier(c),
+		sleep:     sleepContext,
+	}
+}
+
+// handleWatchKEV alerts when any tracked CVE newly appears in the CISA KEV
+// catalog. With --interval it keeps checking until interrupted.
+func handleWatchKEV(c *cli.Context) error {
+	tracked := splitCVEs(c.String("cves"))
+	if len(tracked) == 0 {
+		return fmt.Errorf("no CVE IDs to track")
+	}
+	interval := c.Duration("interval")
+	if interval == 0 {
+		return newKEVWatcher(c, tracked).check()
+	}
+	if interval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s, got %s", minWatchInterval, interval)
+	}
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return newKEVWatcher(c, tracked).watch(ctx, interval)
+}
+
+// watch runs a KEV check every interval until ctx is done, such as on SIGINT
+// or SIGTERM. A failed cycle is logged and retried on the next one; the state
+// file only changes when a cycle succeeds, so alerts are never repeated or lost.
+func (w *kevWatcher) watch(ctx context.Context, interval time.Duration) error {
+	for cycle := 1; ; cycle++ {
+		log.Printf("KEV watch cycle %d starting", cycle)
+		if err := w.check(); err != nil {
+			log.Printf("KEV watch cycle %d failed: %v", cycle, err)
+		}
+		log.Printf("Next KEV check in %s", interval)
+		if err := w.sleep(ctx, interval); err != nil {
+			log.Printf("Stopping KEV watch after %d cycle(s)", cycle)
+			return nil
+		}
+	}
+}
+
+// check runs one KEV check against the snapshot in the state file.
+func (w *kevWatcher) check() error {
+	var previous kevWatchState
+	found, err := state.Load(w.statePath, &previous)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := w.client.FetchCatalog()
+	if err != nil {
+		return fmt.Errorf("KEV catalog unavailable, state left unchanged: %w", err)
+	}
+
+	listed := service.KEVListed(w.tracked, catalog)
+	if found {
+		alerts := service.NewKEVAlerts(listed, previous.Listed, catalog)
+		if err := w.notifier.Notify(alerts); err != nil {
+			return fmt.Errorf("failed to send alerts: %w", err)
+		}
+		log.Printf("KEV check complete: %d tracked, %d listed, %d newly listed", len(w.tracked), len(listed), len(alerts))
+	} else {
+		log.Printf("No previous KEV snapshot; recorded baseline of %d listed CVE(s)", len(listed))
+	}
+
+	return state.Save(w.statePath, kevWatchState{Listed: listed, CheckedAt: time.Now().UTC()})
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
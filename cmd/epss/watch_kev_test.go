@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/kev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier keeps every alert it is sent.
+type recordingNotifier struct {
+	alerts []models.Alert
+}
+
+func (n *recordingNotifier) Notify(alerts []models.Alert) error {
+	n.alerts = append(n.alerts, alerts...)
+	return nil
+}
+
+func TestWatchKEV(t *testing.T) {
+	// The catalog lists CVE-2023-0001 from the second fetch onwards.
+	var fetches int32
+	kevServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			fmt.Fprint(w, `{"vulnerabilities":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"vulnerabilities":[{"cveID":"CVE-2023-0001","vendorProject":"Acme","product":"Widget","vulnerabilityName":"Widget RCE","dateAdded":"2024-10-18"}]}`)
+	}))
+	defer kevServer.Close()
+	newWatcher := func(t *testing.T, sleep func(ctx context.Context, d time.Duration) error) (*kevWatcher, *recordingNotifier) {
+		atomic.StoreInt32(&fetches, 0)
+		n := &recordingNotifier{}
+		return &kevWatcher{
+			tracked:   []string{"CVE-2023-0001"},
+			statePath: filepath.Join(t.TempDir(), "kev-state.json"),
+			client:    kev.NewClient(kevServer.URL),
+			notifier:  n,
+			sleep:     sleep,
+		}, n
+	}
+
+	t.Run("Success - New Listing Reported On The Second Tick", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var waits []time.Duration
+		w, n := newWatcher(t, func(ctx context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			if len(waits) == 2 {
+				cancel()
+				return ctx.Err()
+			}
+			return nil
+		})
+
+		require.NoError(t, w.watch(ctx, time.Hour))
+
+		assert.Equal(t, []time.Duration{time.Hour, time.Hour}, waits)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+		require.Len(t, n.alerts, 1)
+		assert.Equal(t, "CVE-2023-0001", n.alerts[0].CVE)
+	})
+
+	t.Run("Success - Cancelled Context Ends The Loop", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		w, n := newWatcher(t, sleepContext)
+
+		done := make(chan error, 1)
+		go func() { done <- w.watch(ctx, time.Hour) }()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("watch did not stop after its context was cancelled")
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+		assert.Empty(t, n.alerts)
+	})
+
+	t.Run("Fail - Interval Below The Minimum", func(t *testing.T) {
+		atomic.StoreInt32(&fetches, 0)
+
+		err := newApp().Run([]string{"epss", "watch-kev", "--cves", "CVE-2023-0001", "--state", filepath.Join(t.TempDir(), "kev-state.json"), "--kev-url", kevServer.URL, "--interval", "30s"})
+
+		assert.EqualError(t, err, "--interval must be at least 1m0s, got 30s")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&fetches))
+	})
+}
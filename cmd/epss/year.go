@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/urfave/cli/v2"
+)
+
+// handleYear reports the scores of the CVEs disclosed in one year. The API
+// cannot filter by year, so the full day's dataset is downloaded and filtered locally.
+func handleYear(c *cli.Context) error {
+	date := c.String("date")
+	repo := newRepository(c)
+	cves, err := repo.GetAllCVEsForDate(c.Context, date)
+	if err != nil {
+		return fmt.Errorf("failed to get CVEs for date: %w", err)
+	}
+
+	cohort, stats := service.YearCohort(cves, c.Int("year"))
+	if err := annotateRank(c, repo, cohort, date); err != nil {
+		return err
+	}
+	annotateResults(c, cohort)
+
+	p, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+	return p.PrintYearCohort(stats, cohort)
+}
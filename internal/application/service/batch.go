@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// DefaultBatchSize is the number of CVEs scored per batch by ScoreBatches.
+const DefaultBatchSize = 100
+
+// ScoreBatches scores cveIDs for date in batches of size CVEs. Unlike a single
+// GetCVEScores call, a failed batch does not stop the run: its CVEs are
+// recorded in Failed with the error and the remaining batches still run.
+// CVEs without a score are in neither list.
+func ScoreBatches(ctx context.Context, repo ports.EPSSRepository, cveIDs []string, date string, size int) models.BatchResult {
+	if size < 1 {
+		size = DefaultBatchSize
+	}
+	result := models.BatchResult{Results: []models.CVE{}, Failed: []models.BatchFailure{}}
+	for start := 0; start < len(cveIDs); start += size {
+		batch := cveIDs[start:min(start+size, len(cveIDs))]
+		cves, err := repo.GetCVEScores(ctx, batch, date)
+		if err != nil {
+			for _, id := range batch {
+				result.Failed = append(result.Failed, models.BatchFailure{CVE: id, Error: err.Error()})
+			}
+			continue
+		}
+		result.Results = append(result.Results, cves...)
+	}
+	return result
+}
+
+// UnscoredIDs returns the IDs in cveIDs that have no score in scores, such as
+// CVEs the API does not know, in the order requested.
+func UnscoredIDs(cveIDs []string, scores []models.CVE) []string {
+	scored := make(map[string]bool, len(scores))
+	for _, cve := range scores {
+		scored[strings.ToUpper(cve.ID)] = true
+	}
+	var missing []string
+	for _, id := range cveIDs {
+		if !scored[strings.ToUpper(id)] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// FailedIDs returns the IDs of the CVEs that failed in r.
+func FailedIDs(r models.BatchResult) []string {
+	ids := make([]string, len(r.Failed))
+	for i, f := range r.Failed {
+		ids[i] = f.CVE
+	}
+	return ids
+}
+
+// MergeBatchResults folds retry, a rerun of the CVEs in retried, into prev.
+// Scores from retry replace any earlier score of the same CVE and are
+// otherwise appended; the earlier failures of retried CVEs are replaced by
+// their failures in retry, if any.
+func MergeBatchResults(prev models.BatchResult, retried []string, retry models.BatchResult) models.BatchResult {
+	fresh := make(map[string]models.CVE, len(retry.Results))
+	for _, cve := range retry.Results {
+		fresh[cve.ID] = cve
+	}
+	isRetried := make(map[string]bool, len(retried))
+	for _, id := range retried {
+		isRetried[id] = true
+	}
+
+	merged := models.BatchResult{Results: []models.CVE{}, Failed: []models.BatchFailure{}}
+	for _, cve := range prev.Results {
+		if replacement, ok := fresh[cve.ID]; ok {
+			cve = replacement
+			delete(fresh, cve.ID)
+		}
+		merged.Results = append(merged.Results, cve)
+	}
+	for _, cve := range retry.Results {
+		if _, ok := fresh[cve.ID]; ok {
+			merged.Results = append(merged.Results, cve)
+		}
+	}
+	for _, f := range prev.Failed {
+		if !isRetried[f.CVE] {
+			merged.Failed = append(merged.Failed, f)
+		}
+	}
+	merged.Failed = append(merged.Failed, retry.Failed...)
+	return merged
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreBatches(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("cve"), ",")
+		var rows []string
+		for _, id := range ids {
+			if id == "CVE-2023-0003" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if id != "CVE-2023-0005" {
+				rows = append(rows, fmt.Sprintf(`{"cve":%q,"epss":"0.1","percentile":"0.5","date":"2024-10-18"}`, id))
+			}
+		}
+		fmt.Fprintf(w, `{"total":%d,"data":[%s]}`, len(rows), strings.Join(rows, ","))
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - Failed Batches Are Recorded And The Run Continues", func(t *testing.T) {
+		ids := []string{"CVE-2023-0001", "CVE-2023-0002", "CVE-2023-0003", "CVE-2023-0004", "CVE-2023-0005"}
+
+		result := service.ScoreBatches(context.Background(), repository.NewAPIRepository(mockServer.URL), ids, "", 2)
+
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "CVE-2023-0001", result.Results[0].ID)
+		assert.Equal(t, "CVE-2023-0002", result.Results[1].ID)
+		assert.Equal(t, []string{"CVE-2023-0003", "CVE-2023-0004"}, service.FailedIDs(result))
+		assert.Contains(t, result.Failed[0].Error, "503")
+	})
+}
+
+func TestMergeBatchResults(t *testing.T) {
+	cve := func(id string, score float64) models.CVE {
+		return models.CVE{ID: id, EPSSScore: score, Percentile: 0.5, Date: "2024-10-18"}
+	}
+	prev := models.BatchResult{
+		Results: []models.CVE{cve("CVE-2023-0001", 0.1), cve("CVE-2023-0002", 0.2)},
+		Failed: []models.BatchFailure{
+			{CVE: "CVE-2023-0003", Error: "timeout"},
+			{CVE: "CVE-2023-0004", Error: "timeout"},
+			{CVE: "CVE-2023-0005", Error: "timeout"},
+		},
+	}
+
+	t.Run("Success - Retried Scores Are Appended And Failures Replaced", func(t *testing.T) {
+		retry := models.BatchResult{
+			Results: []models.CVE{cve("CVE-2023-0003", 0.3)},
+			Failed:  []models.BatchFailure{{CVE: "CVE-2023-0004", Error: "503"}},
+		}
+
+		merged := service.MergeBatchResults(prev, []string{"CVE-2023-0003", "CVE-2023-0004", "CVE-2023-0005"}, retry)
+
+		assert.Equal(t, []models.CVE{cve("CVE-2023-0001", 0.1), cve("CVE-2023-0002", 0.2), cve("CVE-2023-0003", 0.3)}, merged.Results)
+		assert.Equal(t, []models.BatchFailure{{CVE: "CVE-2023-0004", Error: "503"}}, merged.Failed)
+	})
+
+	t.Run("Success - Unretried Failures Are Kept", func(t *testing.T) {
+		merged := service.MergeBatchResults(prev, []string{"CVE-2023-0003"}, models.BatchResult{Results: []models.CVE{cve("CVE-2023-0003", 0.3)}})
+
+		assert.Len(t, merged.Results, 3)
+		assert.Equal(t, []string{"CVE-2023-0004", "CVE-2023-0005"}, service.FailedIDs(merged))
+	})
+
+	t.Run("Success - Fresh Score Replaces An Earlier One", func(t *testing.T) {
+		merged := service.MergeBatchResults(prev, []string{"CVE-2023-0001"}, models.BatchResult{Results: []models.CVE{cve("CVE-2023-0001", 0.9)}})
+
+		require.Len(t, merged.Results, 2)
+		assert.Equal(t, 0.9, merged.Results[0].EPSSScore)
+		assert.Len(t, merged.Failed, 3)
+	})
+
+	t.Run("Success - Input Is Not Modified", func(t *testing.T) {
+		service.MergeBatchResults(prev, []string{"CVE-2023-0003"}, models.BatchResult{})
+
+		assert.Len(t, prev.Failed, 3)
+		assert.Len(t, prev.Results, 2)
+	})
+}
+
+func TestUnscoredIDs(t *testing.T) {
+	t.Run("Success - Lists Requested CVEs Missing From The Scores", func(t *testing.T) {
+		scores := []models.CVE{{ID: "CVE-2021-44228"}}
+
+		missing := service.UnscoredIDs([]string{"CVE-2020-1472", "cve-2021-44228", "CVE-2099-0001"}, scores)
+
+		assert.Equal(t, []string{"CVE-2020-1472", "CVE-2099-0001"}, missing)
+	})
+
+	t.Run("Success - None Missing", func(t *testing.T) {
+		assert.Empty(t, service.UnscoredIDs([]string{"CVE-2021-44228"}, []models.CVE{{ID: "CVE-2021-44228"}}))
+	})
+}
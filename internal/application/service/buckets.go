@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// CountsByThresholds counts the CVEs scored on date whose field (epss or
+// percentile) exceeds each threshold. When repo is a ports.Counter, each
+// threshold costs one single-row query; otherwise the day is paginated once
+// and bucketed locally, so any number of thresholds costs a single pass.
+func CountsByThresholds(ctx context.Context, repo ports.EPSSRepository, date string, thresholds []float64, field string) (map[float64]int, error) {
+	if err := validateThresholds(thresholds, field); err != nil {
+		return nil, err
+	}
+	if counter, ok := repo.(ports.Counter); ok {
+		counts := make(map[float64]int, len(thresholds))
+		for _, t := range thresholds {
+			n, err := counter.Count(ctx, CountParams(date, field, t))
+			if err != nil {
+				return nil, fmt.Errorf("failed to count CVEs above %g: %w", t, err)
+			}
+			counts[t] = n
+		}
+		return counts, nil
+	}
+	cves, err := repo.GetAllCVEsForDate(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CVEs for date: %w", err)
+	}
+	return BucketCounts(cves, thresholds, field)
+}
+
+// CountParams returns the API query parameters selecting the CVEs scored on
+// date (the latest data when empty) whose field exceeds threshold.
+func CountParams(date string, field string, threshold float64) map[string]string {
+	params := map[string]string{field + "-gt": strconv.FormatFloat(threshold, 'f', -1, 64)}
+	if date != "" {
+		params["date"] = date
+	}
+	return params
+}
+
+// BucketCounts counts the cves whose field (epss or percentile) is strictly
+// greater than each threshold, matching the API's -gt filters.
+func BucketCounts(cves []models.CVE, thresholds []float64, field string) (map[float64]int, error) {
+	if err := validateThresholds(thresholds, field); err != nil {
+		return nil, err
+	}
+	counts := make(map[float64]int, len(thresholds))
+	for _, t := range thresholds {
+		counts[t] = 0
+	}
+	for _, cve := range cves {
+		v := cve.EPSSScore
+		if field == "percentile" {
+			v = cve.Percentile
+		}
+		for _, t := range thresholds {
+			if v > t {
+				counts[t]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// validateThresholds checks the field name and that every threshold lies
+// within [0, 1] and at least one is given.
+func validateThresholds(thresholds []float64, field string) error {
+	if field != "epss" && field != "percentile" {
+		return fmt.Errorf("unsupported field: %s (expected epss or percentile)", field)
+	}
+	if len(thresholds) == 0 {
+		return fmt.Errorf("at least one threshold is required")
+	}
+	for _, t := range thresholds {
+		if t < 0 || t > 1 {
+			return fmt.Errorf("threshold must be between 0 and 1, got %g", t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketCounts(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2024-0001", EPSSScore: 0.05, Percentile: 0.20},
+		{ID: "CVE-2024-0002", EPSSScore: 0.10, Percentile: 0.60},
+		{ID: "CVE-2024-0003", EPSSScore: 0.30, Percentile: 0.85},
+		{ID: "CVE-2024-0004", EPSSScore: 0.70, Percentile: 0.95},
+		{ID: "CVE-2024-0005", EPSSScore: 0.95, Percentile: 0.99},
+	}
+
+	t.Run("Success - EPSS", func(t *testing.T) {
+		counts, err := service.BucketCounts(cves, []float64{0.1, 0.5, 0.9}, "epss")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[float64]int{0.1: 3, 0.5: 2, 0.9: 1}, counts)
+	})
+
+	t.Run("Success - Percentile", func(t *testing.T) {
+		counts, err := service.BucketCounts(cves, []float64{0.5, 0.9}, "percentile")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[float64]int{0.5: 4, 0.9: 2}, counts)
+	})
+
+	t.Run("Success - Empty Buckets Are Reported", func(t *testing.T) {
+		counts, err := service.BucketCounts(nil, []float64{0.5}, "epss")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[float64]int{0.5: 0}, counts)
+	})
+
+	t.Run("Fail - Invalid Input", func(t *testing.T) {
+		_, err := service.BucketCounts(cves, nil, "epss")
+		assert.Error(t, err)
+		_, err = service.BucketCounts(cves, []float64{1.5}, "epss")
+		assert.Error(t, err)
+		_, err = service.BucketCounts(cves, []float64{0.5}, "cvss")
+		assert.Error(t, err)
+	})
+}
+
+func TestCountsByThresholds(t *testing.T) {
+	t.Run("Success - Counting Sources Use One Single-Row Query Per Threshold", func(t *testing.T) {
+		totals := map[string]string{"0.1": "3", "0.5": "2", "0.9": "1"}
+		var requests int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, "2024-10-18", r.URL.Query().Get("date"))
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			fmt.Fprintf(w, `{"total":%s,"data":[]}`, totals[r.URL.Query().Get("epss-gt")])
+		}))
+		defer mockServer.Close()
+
+		counts, err := service.CountsByThresholds(context.Background(), repository.NewAPIRepository(mockServer.URL), "2024-10-18", []float64{0.1, 0.5, 0.9}, "epss")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[float64]int{0.1: 3, 0.5: 2, 0.9: 1}, counts)
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("Success - Other Sources Paginate The Day Once", func(t *testing.T) {
+		var requests int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, "2024-10-18", r.URL.Query().Get("date"))
+			if r.URL.Query().Get("offset") == "0" {
+				fmt.Fprint(w, `{"data":[{"cve":"CVE-2024-0001","epss":"0.2","percentile":"0.5","date":"2024-10-18"},{"cve":"CVE-2024-0002","epss":"0.6","percentile":"0.9","date":"2024-10-18"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"cve":"CVE-2024-0003","epss":"0.95","percentile":"0.99","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		// Hiding Count leaves only the EPSSRepository methods.
+		repo := struct{ ports.EPSSRepository }{repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2))}
+		counts, err := service.CountsByThresholds(context.Background(), repo, "2024-10-18", []float64{0.1, 0.5, 0.9}, "epss")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[float64]int{0.1: 3, 0.5: 2, 0.9: 1}, counts)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("Fail - Invalid Field Skips The Download", func(t *testing.T) {
+		_, err := service.CountsByThresholds(context.Background(), nil, "2024-10-18", []float64{0.5}, "cvss")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// YearCohort returns the CVEs disclosed in year, sorted by EPSS score
+// (highest first), together with summary statistics for the cohort.
+func YearCohort(cves []models.CVE, year int) ([]models.CVE, models.CohortStats) {
+	var cohort []models.CVE
+	for _, cve := range cves {
+		if y, ok := CVEYear(cve.ID); ok && y == year {
+			cohort = append(cohort, cve)
+		}
+	}
+	sort.SliceStable(cohort, func(i, j int) bool {
+		return cohort[i].EPSSScore > cohort[j].EPSSScore
+	})
+
+	stats := models.CohortStats{Year: year, Count: len(cohort)}
+	stats.MeanEPSS, stats.MedianEPSS, stats.MaxEPSS = epssStats(cohort)
+	return cohort, stats
+}
+
+// epssStats returns the mean, median and maximum EPSS score of cves, which
+// must be sorted by EPSS score, highest first. All are 0 for no CVEs.
+func epssStats(cves []models.CVE) (mean, median, max float64) {
+	if len(cves) == 0 {
+		return 0, 0, 0
+	}
+	var sum float64
+	for _, cve := range cves {
+		sum += cve.EPSSScore
+	}
+	mid := len(cves) / 2
+	if len(cves)%2 == 1 {
+		median = cves[mid].EPSSScore
+	} else {
+		median = (cves[mid-1].EPSSScore + cves[mid].EPSSScore) / 2
+	}
+	return sum / float64(len(cves)), median, cves[0].EPSSScore
+}
+
+// CVESet is a named list of CVE IDs, such as the CVEs of one asset group.
+type CVESet struct {
+	Name string
+	IDs  []string
+}
+
+// DefaultCohortThresholds are the EPSS thresholds counted by CompareCohorts
+// when none are given.
+var DefaultCohortThresholds = []float64{0.1, 0.5}
+
+// CompareCohorts scores both sets for date (the latest data when empty) and
+// summarizes them side by side. Any batch that fails to score fails the
+// comparison, as statistics over part of a set would mislead.
+func CompareCohorts(ctx context.Context, repo ports.EPSSRepository, a, b CVESet, date string, thresholds []float64, catalog ports.KEVCatalog) (models.CohortComparison, error) {
+	cmp := models.CohortComparison{Date: date}
+	for _, set := range []struct {
+		in  CVESet
+		out *models.SetStats
+	}{{a, &cmp.A}, {b, &cmp.B}} {
+		result := ScoreBatches(ctx, repo, set.in.IDs, date, DefaultBatchSize)
+		if len(result.Failed) > 0 {
+			return models.CohortComparison{}, fmt.Errorf("failed to score %d CVE(s) of %s: %s", len(result.Failed), set.in.Name, result.Failed[0].Error)
+		}
+		stats, err := SummarizeSet(set.in, result.Results, thresholds, catalog)
+		if err != nil {
+			return models.CohortComparison{}, err
+		}
+		*set.out = stats
+	}
+	return cmp, nil
+}
+
+// SummarizeSet computes the statistics of set from the scores of its CVEs.
+// EPSS scores are counted when strictly above each threshold.
+func SummarizeSet(set CVESet, scored []models.CVE, thresholds []float64, catalog ports.KEVCatalog) (models.SetStats, error) {
+	counts, err := BucketCounts(scored, thresholds, "epss")
+	if err != nil {
+		return models.SetStats{}, err
+	}
+	sorted := make([]models.CVE, len(scored))
+	copy(sorted, scored)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EPSSScore > sorted[j].EPSSScore
+	})
+
+	stats := models.SetStats{Name: set.Name, CVEs: len(set.IDs), Scored: len(scored), Above: make([]models.ThresholdCount, len(thresholds))}
+	stats.MeanEPSS, stats.MedianEPSS, stats.MaxEPSS = epssStats(sorted)
+	for i, t := range thresholds {
+		stats.Above[i] = models.ThresholdCount{Threshold: t, Count: counts[t]}
+	}
+	if catalog != nil {
+		stats.KEV = len(KEVListed(set.IDs, catalog))
+	}
+	return stats, nil
+}
@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYearCohort(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2021-0001", EPSSScore: 0.1},
+		{ID: "CVE-2022-0002", EPSSScore: 0.9},
+		{ID: "CVE-2021-0003", EPSSScore: 0.7},
+		{ID: "CVE-2021-0004", EPSSScore: 0.4},
+		{ID: "CVE-2021-0005", EPSSScore: 0.2},
+	}
+
+	t.Run("Success - Filters And Sorts By Score", func(t *testing.T) {
+		cohort, stats := service.YearCohort(cves, 2021)
+
+		var ids []string
+		for _, cve := range cohort {
+			ids = append(ids, cve.ID)
+		}
+		assert.Equal(t, []string{"CVE-2021-0003", "CVE-2021-0004", "CVE-2021-0005", "CVE-2021-0001"}, ids)
+		assert.Equal(t, 2021, stats.Year)
+		assert.Equal(t, 4, stats.Count)
+		assert.InDelta(t, 0.35, stats.MeanEPSS, 1e-9)
+		assert.InDelta(t, 0.3, stats.MedianEPSS, 1e-9)
+		assert.Equal(t, 0.7, stats.MaxEPSS)
+	})
+
+	t.Run("Success - Empty Cohort", func(t *testing.T) {
+		cohort, stats := service.YearCohort(cves, 1999)
+
+		assert.Empty(t, cohort)
+		assert.Equal(t, models.CohortStats{Year: 1999}, stats)
+	})
+}
+
+func TestCompareCohorts(t *testing.T) {
+	scores := map[string]string{
+		"CVE-2021-44228": "0.97",
+		"CVE-2023-0001":  "0.6",
+		"CVE-2023-0002":  "0.2",
+		"CVE-2023-0003":  "0.05",
+		"CVE-2023-0004":  "0.01",
+		"CVE-2023-0005":  "0.03",
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rows []string
+		for _, id := range strings.Split(r.URL.Query().Get("cve"), ",") {
+			if score, ok := scores[id]; ok {
+				rows = append(rows, fmt.Sprintf(`{"cve":%q,"epss":%q,"percentile":"0.5","date":"2024-10-18"}`, id, score))
+			}
+		}
+		fmt.Fprintf(w, `{"total":%d,"data":[%s]}`, len(rows), strings.Join(rows, ","))
+	}))
+	defer mockServer.Close()
+	catalog := fakeCatalog{"CVE-2021-44228": {CVE: "CVE-2021-44228"}, "CVE-2023-0005": {CVE: "CVE-2023-0005"}}
+
+	t.Run("Success - Aggregates Both Sets", func(t *testing.T) {
+		a := service.CVESet{Name: "critical", IDs: []string{"CVE-2021-44228", "CVE-2023-0001", "CVE-2023-0002"}}
+		b := service.CVESet{Name: "deprioritized", IDs: []string{"CVE-2023-0003", "CVE-2023-0004", "CVE-2023-0005", "CVE-2023-9999"}}
+
+		cmp, err := service.CompareCohorts(context.Background(), repository.NewAPIRepository(mockServer.URL), a, b, "2024-10-18", []float64{0.1, 0.5}, catalog)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-10-18", cmp.Date)
+		assert.Equal(t, "critical", cmp.A.Name)
+		assert.Equal(t, 3, cmp.A.CVEs)
+		assert.Equal(t, 3, cmp.A.Scored)
+		assert.InDelta(t, 0.59, cmp.A.MeanEPSS, 1e-9)
+		assert.InDelta(t, 0.6, cmp.A.MedianEPSS, 1e-9)
+		assert.Equal(t, 0.97, cmp.A.MaxEPSS)
+		assert.Equal(t, []models.ThresholdCount{{Threshold: 0.1, Count: 3}, {Threshold: 0.5, Count: 2}}, cmp.A.Above)
+		assert.Equal(t, 1, cmp.A.KEV)
+
+		assert.Equal(t, 4, cmp.B.CVEs)
+		assert.Equal(t, 3, cmp.B.Scored)
+		assert.InDelta(t, 0.03, cmp.B.MeanEPSS, 1e-9)
+		assert.InDelta(t, 0.03, cmp.B.MedianEPSS, 1e-9)
+		assert.Equal(t, 0.05, cmp.B.MaxEPSS)
+		assert.Equal(t, []models.ThresholdCount{{Threshold: 0.1, Count: 0}, {Threshold: 0.5, Count: 0}}, cmp.B.Above)
+		assert.Equal(t, 1, cmp.B.KEV)
+	})
+
+	t.Run("Fail - Invalid Threshold", func(t *testing.T) {
+		set := service.CVESet{Name: "a", IDs: []string{"CVE-2023-0001"}}
+
+		_, err := service.CompareCohorts(context.Background(), repository.NewAPIRepository(mockServer.URL), set, set, "", []float64{2}, catalog)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// CompareScoreDates fetches the score of cveID on each of dates concurrently
+// and compares them. A date without data for the CVE is reported as such
+// rather than failing the comparison.
+func CompareScoreDates(ctx context.Context, repo ports.EPSSRepository, cveID string, dates []string) (*models.ScoreComparison, error) {
+	for _, date := range dates {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return nil, fmt.Errorf("invalid date format: %w", err)
+		}
+	}
+	scores := make(map[string]models.CVE, len(dates))
+	fetch := func(date string) ([]models.CVE, error) {
+		return repo.GetCVEScores(ctx, []string{cveID}, date)
+	}
+	err := FetchEach(dates, len(dates), false, fetch, func(date string, cves []models.CVE) error {
+		if len(cves) > 0 {
+			scores[date] = cves[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scores for %s: %w", cveID, err)
+	}
+	return CompareScores(cveID, dates, scores), nil
+}
+
+// CompareScores orders dates chronologically, dropping duplicates, and pairs
+// each with its score in scores, computing the change since the previous
+// date with data.
+func CompareScores(cveID string, dates []string, scores map[string]models.CVE) *models.ScoreComparison {
+	sorted := append([]string(nil), dates...)
+	sort.Strings(sorted)
+
+	cmp := &models.ScoreComparison{CVE: cveID, Scores: []models.DatedScore{}}
+	var prev *models.CVE
+	for i, date := range sorted {
+		if i > 0 && date == sorted[i-1] {
+			continue
+		}
+		entry := models.DatedScore{Date: date}
+		if cve, ok := scores[date]; ok {
+			epss, percentile := cve.EPSSScore, cve.Percentile
+			entry.EPSS, entry.Percentile = &epss, &percentile
+			if prev != nil {
+				epssDelta, percentileDelta := epss-prev.EPSSScore, percentile-prev.Percentile
+				entry.EPSSDelta, entry.PercentileDelta = &epssDelta, &percentileDelta
+			}
+			prev = &cve
+		}
+		cmp.Scores = append(cmp.Scores, entry)
+	}
+	return cmp
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareScores(t *testing.T) {
+	scores := map[string]models.CVE{
+		"2024-01-01": {ID: "CVE-2023-0001", EPSSScore: 0.1, Percentile: 0.5, Date: "2024-01-01"},
+		"2024-12-01": {ID: "CVE-2023-0001", EPSSScore: 0.4, Percentile: 0.9, Date: "2024-12-01"},
+	}
+
+	t.Run("Success - Deltas Skip Dates Without Data", func(t *testing.T) {
+		cmp := service.CompareScores("CVE-2023-0001", []string{"2024-12-01", "2024-06-01", "2024-01-01", "2024-06-01"}, scores)
+
+		require.Len(t, cmp.Scores, 3)
+		assert.Equal(t, "2024-01-01", cmp.Scores[0].Date)
+		assert.Nil(t, cmp.Scores[0].EPSSDelta)
+		assert.Equal(t, "2024-06-01", cmp.Scores[1].Date)
+		assert.Nil(t, cmp.Scores[1].EPSS)
+		assert.Equal(t, "2024-12-01", cmp.Scores[2].Date)
+		assert.Equal(t, 0.4, *cmp.Scores[2].EPSS)
+		assert.InDelta(t, 0.3, *cmp.Scores[2].EPSSDelta, 1e-9)
+		assert.InDelta(t, 0.4, *cmp.Scores[2].PercentileDelta, 1e-9)
+	})
+
+	t.Run("Success - No Data At All", func(t *testing.T) {
+		cmp := service.CompareScores("CVE-2023-0001", []string{"2024-06-01"}, nil)
+
+		require.Len(t, cmp.Scores, 1)
+		assert.Nil(t, cmp.Scores[0].EPSS)
+	})
+}
+
+func TestCompareScoreDates(t *testing.T) {
+	t.Run("Success - Dates Are Fetched Concurrently", func(t *testing.T) {
+		var inFlight, peak int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			date := r.URL.Query().Get("date")
+			if date == "2024-06-01" {
+				fmt.Fprintln(w, `{"total":0,"data":[]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":%q}]}`+"\n", date)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		cmp, err := service.CompareScoreDates(context.Background(), repo, "CVE-2023-0001", []string{"2024-01-01", "2024-06-01", "2024-12-01"})
+
+		require.NoError(t, err)
+		require.Len(t, cmp.Scores, 3)
+		assert.Nil(t, cmp.Scores[1].EPSS)
+		assert.NotNil(t, cmp.Scores[2].EPSSDelta)
+		assert.Greater(t, atomic.LoadInt32(&peak), int32(1))
+	})
+
+	t.Run("Fail - Invalid Date", func(t *testing.T) {
+		_, err := service.CompareScoreDates(context.Background(), nil, "CVE-2023-0001", []string{"2024-13-01"})
+
+		assert.ErrorContains(t, err, "invalid date format")
+	})
+
+	t.Run("Fail - Upstream Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer mockServer.Close()
+
+		_, err := service.CompareScoreDates(context.Background(), repository.NewAPIRepository(mockServer.URL), "CVE-2023-0001", []string{"2024-01-01"})
+
+		assert.ErrorContains(t, err, "failed to get scores for CVE-2023-0001")
+	})
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// CVSSSeverities lists the CVSS v3 qualitative ratings, most severe first.
+var CVSSSeverities = []string{"critical", "high", "medium", "low", "none"}
+
+// RiskLevels lists the RiskLevel names, highest first.
+var RiskLevels = []string{"very high", "high", "moderate", "low"}
+
+// CVSSSeverity returns the CVSS v3 qualitative rating of a base score.
+func CVSSSeverity(score float64) string {
+	switch {
+	case score >= 9:
+		return "critical"
+	case score >= 7:
+		return "high"
+	case score >= 4:
+		return "medium"
+	case score > 0:
+		return "low"
+	default:
+		return "none"
+	}
+}
+
+// Pearson returns the Pearson correlation coefficient of xs and ys.
+func Pearson(xs, ys []float64) (float64, error) {
+	if len(xs) != len(ys) {
+		return 0, fmt.Errorf("mismatched sample sizes: %d and %d", len(xs), len(ys))
+	}
+	if len(xs) < 2 {
+		return 0, fmt.Errorf("at least 2 samples are needed, got %d", len(xs))
+	}
+	n := float64(len(xs))
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, fmt.Errorf("correlation is undefined when all values of a score are equal")
+	}
+	return cov / math.Sqrt(varX*varY), nil
+}
+
+// PairScores looks up the CVSS base score of each CVE in cves. CVEs in ids with
+// no EPSS or no CVSS score are returned as missing. ctx cancels the lookups.
+func PairScores(ctx context.Context, ids []string, cves []models.CVE, source ports.CVSSSource) ([]models.ScorePair, []string, error) {
+	epss := make(map[string]float64, len(cves))
+	for _, cve := range cves {
+		epss[strings.ToUpper(cve.ID)] = cve.EPSSScore
+	}
+	var pairs []models.ScorePair
+	var missing []string
+	for _, id := range ids {
+		score, ok := epss[strings.ToUpper(id)]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		cvss, ok, err := source.BaseScore(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		pairs = append(pairs, models.ScorePair{CVE: id, EPSS: score, CVSS: cvss})
+	}
+	return pairs, missing, nil
+}
+
+// Correlate computes the Pearson correlation of the EPSS and CVSS scores in
+// pairs and counts the pairs per CVSS severity and EPSS risk level. Empty cells
+// are omitted from the scatter summary.
+func Correlate(pairs []models.ScorePair) (models.Correlation, error) {
+	xs := make([]float64, len(pairs))
+	ys := make([]float64, len(pairs))
+	counts := make(map[[2]string]int)
+	for i, pair := range pairs {
+		xs[i], ys[i] = pair.EPSS, pair.CVSS
+		counts[[2]string{CVSSSeverity(pair.CVSS), RiskLevel(pair.EPSS)}]++
+	}
+	r, err := Pearson(xs, ys)
+	if err != nil {
+		return models.Correlation{}, err
+	}
+
+	result := models.Correlation{Count: len(pairs), Pearson: r, Scatter: []models.ScatterCell{}}
+	for _, severity := range CVSSSeverities {
+		for _, level := range RiskLevels {
+			if n := counts[[2]string{severity, level}]; n > 0 {
+				result.Scatter = append(result.Scatter, models.ScatterCell{Severity: severity, RiskLevel: level, Count: n})
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPearson(t *testing.T) {
+	t.Run("Success - Perfect Positive", func(t *testing.T) {
+		r, err := service.Pearson([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 1, r, 1e-12)
+	})
+
+	t.Run("Success - Perfect Negative", func(t *testing.T) {
+		r, err := service.Pearson([]float64{1, 2, 3}, []float64{9, 6, 3})
+
+		require.NoError(t, err)
+		assert.InDelta(t, -1, r, 1e-12)
+	})
+
+	t.Run("Success - Known Value", func(t *testing.T) {
+		r, err := service.Pearson([]float64{1, 2, 3, 4, 5}, []float64{2, 1, 4, 3, 5})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 0.8, r, 1e-12)
+	})
+
+	t.Run("Fail - Too Few Samples", func(t *testing.T) {
+		_, err := service.Pearson([]float64{1}, []float64{1})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail - Constant Values", func(t *testing.T) {
+		_, err := service.Pearson([]float64{1, 2, 3}, []float64{5, 5, 5})
+
+		assert.Error(t, err)
+	})
+}
+
+// cvssScores is an in-memory ports.CVSSSource.
+type cvssScores map[string]float64
+
+func (s cvssScores) BaseScore(ctx context.Context, cveID string) (float64, bool, error) {
+	score, ok := s[cveID]
+	return score, ok, nil
+}
+
+func TestPairScores(t *testing.T) {
+	ids := []string{"CVE-2023-0001", "CVE-2023-0002", "CVE-2023-0003"}
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.2}, {ID: "CVE-2023-0002", EPSSScore: 0.01}}
+	source := cvssScores{"CVE-2023-0001": 9.8, "CVE-2023-0003": 5.0}
+
+	pairs, missing, err := service.PairScores(context.Background(), ids, cves, source)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.ScorePair{{CVE: "CVE-2023-0001", EPSS: 0.2, CVSS: 9.8}}, pairs)
+	assert.Equal(t, []string{"CVE-2023-0002", "CVE-2023-0003"}, missing)
+}
+
+func TestCorrelate(t *testing.T) {
+	t.Run("Success - Scatter Summary", func(t *testing.T) {
+		pairs := []models.ScorePair{
+			{CVE: "CVE-2023-0001", EPSS: 0.9, CVSS: 9.8},
+			{CVE: "CVE-2023-0002", EPSS: 0.6, CVSS: 9.1},
+			{CVE: "CVE-2023-0003", EPSS: 0.05, CVSS: 7.5},
+			{CVE: "CVE-2023-0004", EPSS: 0.001, CVSS: 5.3},
+			{CVE: "CVE-2023-0005", EPSS: 0.002, CVSS: 9.0},
+		}
+
+		result, err := service.Correlate(pairs)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Count)
+		assert.Greater(t, result.Pearson, 0.5)
+		assert.Equal(t, []models.ScatterCell{
+			{Severity: "critical", RiskLevel: "very high", Count: 2},
+			{Severity: "critical", RiskLevel: "low", Count: 1},
+			{Severity: "high", RiskLevel: "moderate", Count: 1},
+			{Severity: "medium", RiskLevel: "low", Count: 1},
+		}, result.Scatter)
+	})
+
+	t.Run("Fail - Not Enough Pairs", func(t *testing.T) {
+		_, err := service.Correlate([]models.ScorePair{{CVE: "CVE-2023-0001", EPSS: 0.9, CVSS: 9.8}})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// cwePattern matches a CWE ID with or without its "CWE-" prefix.
+var cwePattern = regexp.MustCompile(`^(?i:CWE-)?(\d+)$`)
+
+// NormalizeCWE returns cwe in the canonical "CWE-<n>" form, accepting a bare
+// number or any letter case.
+func NormalizeCWE(cwe string) (string, error) {
+	m := cwePattern.FindStringSubmatch(strings.TrimSpace(cwe))
+	if m == nil {
+		return "", fmt.Errorf("invalid CWE %q, want CWE-<number>", cwe)
+	}
+	return "CWE-" + m[1], nil
+}
+
+// ScoreCWE looks up the CVEs mapped to cwe and scores them for date in batches
+// of size, highest score first. Failed batches are recorded rather than
+// aborting the run, as a popular CWE can map to thousands of CVEs.
+func ScoreCWE(ctx context.Context, mapper ports.CWEMapper, repo ports.EPSSRepository, cwe string, date string, size int) (models.CWEResult, error) {
+	cwe, err := NormalizeCWE(cwe)
+	if err != nil {
+		return models.CWEResult{}, err
+	}
+	ids, err := mapper.CVEsForCWE(ctx, cwe)
+	if err != nil {
+		return models.CWEResult{}, fmt.Errorf("failed to map %s to CVEs: %w", cwe, err)
+	}
+	if len(ids) == 0 {
+		return models.CWEResult{}, fmt.Errorf("no CVEs mapped to %s", cwe)
+	}
+	batch := ScoreBatches(ctx, repo, ids, date, size)
+	sort.SliceStable(batch.Results, func(i, j int) bool {
+		return batch.Results[i].EPSSScore > batch.Results[j].EPSSScore
+	})
+	return models.CWEResult{CWE: cwe, CVEs: batch.Results, Failed: batch.Failed}, nil
+}
@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMapper maps CWEs to CVEs from a fixed table.
+type fakeMapper struct {
+	cves map[string][]string
+	err  error
+}
+
+func (m fakeMapper) CVEsForCWE(ctx context.Context, cwe string) ([]string, error) {
+	return m.cves[cwe], m.err
+}
+
+func TestNormalizeCWE(t *testing.T) {
+	t.Run("Success - Accepts Bare Numbers And Any Case", func(t *testing.T) {
+		for _, in := range []string{"CWE-79", "cwe-79", " 79 "} {
+			cwe, err := service.NormalizeCWE(in)
+			require.NoError(t, err)
+			assert.Equal(t, "CWE-79", cwe)
+		}
+	})
+
+	t.Run("Fail - Invalid CWE", func(t *testing.T) {
+		_, err := service.NormalizeCWE("XSS")
+		assert.Error(t, err)
+	})
+}
+
+func TestScoreCWE(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rows []string
+		for i, id := range strings.Split(r.URL.Query().Get("cve"), ",") {
+			rows = append(rows, fmt.Sprintf(`{"cve":%q,"epss":"0.%d","percentile":"0.5","date":"2024-10-18"}`, id, i+1))
+		}
+		fmt.Fprintf(w, `{"total":%d,"data":[%s]}`, len(rows), strings.Join(rows, ","))
+	}))
+	defer mockServer.Close()
+	repo := repository.NewAPIRepository(mockServer.URL)
+
+	t.Run("Success - Scores Mapped CVEs Highest First", func(t *testing.T) {
+		mapper := fakeMapper{cves: map[string][]string{"CWE-79": {"CVE-2023-0001", "CVE-2023-0002", "CVE-2023-0003"}}}
+
+		result, err := service.ScoreCWE(context.Background(), mapper, repo, "79", "", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "CWE-79", result.CWE)
+		require.Len(t, result.CVEs, 3)
+		assert.Equal(t, "CVE-2023-0002", result.CVEs[0].ID)
+		assert.Empty(t, result.Failed)
+	})
+
+	t.Run("Fail - No CVEs Mapped", func(t *testing.T) {
+		_, err := service.ScoreCWE(context.Background(), fakeMapper{}, repo, "CWE-79", "", 2)
+		assert.ErrorContains(t, err, "no CVEs mapped to CWE-79")
+	})
+
+	t.Run("Fail - Mapper Error", func(t *testing.T) {
+		_, err := service.ScoreCWE(context.Background(), fakeMapper{err: errors.New("boom")}, repo, "CWE-79", "", 2)
+		assert.ErrorContains(t, err, "boom")
+	})
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// FindEarliestDate binary-searches [lo, hi] for the first date on which
+// available reports data, assuming data is available on every date from the
+// earliest onwards. It makes about log2(days) calls to available.
+func FindEarliestDate(lo, hi string, available func(date string) (bool, error)) (string, error) {
+	start, err := time.Parse(dateLayout, lo)
+	if err != nil {
+		return "", fmt.Errorf("invalid start date format: %w", err)
+	}
+	end, err := time.Parse(dateLayout, hi)
+	if err != nil {
+		return "", fmt.Errorf("invalid end date format: %w", err)
+	}
+	if end.Before(start) {
+		return "", fmt.Errorf("end date %s is before start date %s", hi, lo)
+	}
+
+	ok, err := available(hi)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("no data available on %s", hi)
+	}
+
+	// Invariant: the earliest date lies in [start, end] and end has data.
+	for start.Before(end) {
+		days := int(end.Sub(start).Hours() / 24)
+		mid := start.AddDate(0, 0, days/2)
+		ok, err := available(mid.Format(dateLayout))
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			end = mid
+		} else {
+			start = mid.AddDate(0, 0, 1)
+		}
+	}
+	return end.Format(dateLayout), nil
+}
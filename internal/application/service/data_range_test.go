@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// availableFrom reports data for every date on or after earliest, counting calls.
+func availableFrom(earliest string, calls *int) func(string) (bool, error) {
+	return func(date string) (bool, error) {
+		*calls++
+		return date >= earliest, nil
+	}
+}
+
+func TestFindEarliestDate(t *testing.T) {
+	t.Run("Success - Finds First Available Date In Few Probes", func(t *testing.T) {
+		var calls int
+		earliest, err := service.FindEarliestDate("2020-01-01", "2024-10-18", availableFrom("2021-04-14", &calls))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2021-04-14", earliest)
+		assert.LessOrEqual(t, calls, 13)
+	})
+
+	t.Run("Success - Lower Bound Available", func(t *testing.T) {
+		var calls int
+		earliest, err := service.FindEarliestDate("2024-10-01", "2024-10-18", availableFrom("2021-04-14", &calls))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-10-01", earliest)
+	})
+
+	t.Run("Fail - Upper Bound Unavailable", func(t *testing.T) {
+		var calls int
+		_, err := service.FindEarliestDate("2020-01-01", "2024-10-18", availableFrom("2025-01-01", &calls))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail - Probe Error", func(t *testing.T) {
+		_, err := service.FindEarliestDate("2020-01-01", "2024-10-18", func(string) (bool, error) {
+			return false, errors.New("boom")
+		})
+
+		assert.Error(t, err)
+	})
+}
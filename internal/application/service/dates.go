@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the YYYY-MM-DD format used for EPSS data dates.
+const dateLayout = "2006-01-02"
+
+// DatesBetween returns every date from start to end inclusive in YYYY-MM-DD format.
+func DatesBetween(startStr, endStr string) ([]string, error) {
+	start, err := time.Parse(dateLayout, startStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+	end, err := time.Parse(dateLayout, endStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", endStr, startStr)
+	}
+
+	var dates []string
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format(dateLayout))
+	}
+	return dates, nil
+}
+
+// daysBetween returns the number of dates from start to end inclusive, without
+// listing them as DatesBetween does.
+func daysBetween(startStr, endStr string) (int, error) {
+	start, err := time.Parse(dateLayout, startStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start date format: %w", err)
+	}
+	end, err := time.Parse(dateLayout, endStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid end date format: %w", err)
+	}
+	return int(end.Sub(start).Hours()/24) + 1, nil
+}
+
+// WindowDates returns the days dates ending at end inclusive, oldest first.
+func WindowDates(endStr string, days int) ([]string, error) {
+	if days < 1 {
+		return nil, fmt.Errorf("window must be at least one day, got %d", days)
+	}
+	end, err := time.Parse(dateLayout, endStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	return DatesBetween(end.AddDate(0, 0, -(days-1)).Format(dateLayout), endStr)
+}
+
+// StepDates returns the dates from start to end inclusive, step days apart,
+// beginning at start.
+func StepDates(startStr, endStr string, step int) ([]string, error) {
+	if step < 1 {
+		return nil, fmt.Errorf("step must be at least one day, got %d", step)
+	}
+	all, err := DatesBetween(startStr, endStr)
+	if err != nil {
+		return nil, err
+	}
+	var dates []string
+	for i := 0; i < len(all); i += step {
+		dates = append(dates, all[i])
+	}
+	return dates, nil
+}
+
+// ParseStepDays parses a step in whole days, written as "7d" or as a
+// duration such as "168h".
+func ParseStepDays(s string) (int, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid step %q, want a positive number of days such as 7d", s)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 || d%(24*time.Hour) != 0 {
+		return 0, fmt.Errorf("invalid step %q, want a whole number of days such as 7d", s)
+	}
+	return int(d / (24 * time.Hour)), nil
+}
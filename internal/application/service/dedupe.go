@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// DedupePolicy selects which row is kept when a CVE appears more than once.
+type DedupePolicy string
+
+const (
+	// KeepHighest keeps the row with the highest EPSS score.
+	KeepHighest DedupePolicy = "highest"
+	// KeepLatest keeps the row with the most recent date.
+	KeepLatest DedupePolicy = "latest"
+)
+
+// ParseDedupePolicy validates a dedupe policy name.
+func ParseDedupePolicy(s string) (DedupePolicy, error) {
+	switch DedupePolicy(s) {
+	case KeepHighest, KeepLatest:
+		return DedupePolicy(s), nil
+	default:
+		return "", fmt.Errorf("unsupported dedupe policy: %s (expected highest or latest)", s)
+	}
+}
+
+// Dedupe collapses cves to one row per CVE ID according to policy. Rows keep
+// the order in which each CVE was first seen.
+func Dedupe(cves []models.CVE, policy DedupePolicy) []models.CVE {
+	index := make(map[string]int, len(cves))
+	unique := make([]models.CVE, 0, len(cves))
+	for _, cve := range cves {
+		i, seen := index[cve.ID]
+		if !seen {
+			index[cve.ID] = len(unique)
+			unique = append(unique, cve)
+			continue
+		}
+		if replaces(cve, unique[i], policy) {
+			unique[i] = cve
+		}
+	}
+	return unique
+}
+
+// replaces reports whether candidate should replace current under policy.
+func replaces(candidate, current models.CVE, policy DedupePolicy) bool {
+	switch policy {
+	case KeepLatest:
+		return candidate.Date > current.Date
+	default:
+		return candidate.EPSSScore > current.EPSSScore
+	}
+}
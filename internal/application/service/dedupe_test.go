@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupe(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.10, Date: "2024-10-16"},
+		{ID: "CVE-2023-0002", EPSSScore: 0.50, Date: "2024-10-16"},
+		{ID: "CVE-2023-0001", EPSSScore: 0.30, Date: "2024-10-17"},
+		{ID: "CVE-2023-0001", EPSSScore: 0.20, Date: "2024-10-18"},
+	}
+
+	t.Run("Success - Keeps Highest Score", func(t *testing.T) {
+		unique := service.Dedupe(cves, service.KeepHighest)
+
+		assert.Len(t, unique, 2)
+		assert.Equal(t, "CVE-2023-0001", unique[0].ID)
+		assert.Equal(t, 0.30, unique[0].EPSSScore)
+		assert.Equal(t, "2024-10-17", unique[0].Date)
+		assert.Equal(t, "CVE-2023-0002", unique[1].ID)
+	})
+
+	t.Run("Success - Keeps Latest Date", func(t *testing.T) {
+		unique := service.Dedupe(cves, service.KeepLatest)
+
+		assert.Len(t, unique, 2)
+		assert.Equal(t, 0.20, unique[0].EPSSScore)
+		assert.Equal(t, "2024-10-18", unique[0].Date)
+	})
+
+	t.Run("Fail - Unknown Policy", func(t *testing.T) {
+		_, err := service.ParseDedupePolicy("first")
+		assert.Error(t, err)
+	})
+}
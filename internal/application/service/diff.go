@@ -0,0 +1,78 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// DiffReports compares two saved result sets by CVE ID. Added and removed CVEs
+// keep the order of their report; changed CVEs, those whose EPSS score or
+// percentile differs, are sorted by the size of the EPSS change, largest
+// first. When a report holds several rows for a CVE, its last row is used.
+func DiffReports(old, new []models.CVE) models.ReportDiff {
+	oldByID, oldOrder := indexReport(old)
+	newByID, newOrder := indexReport(new)
+
+	diff := models.ReportDiff{Added: []models.CVE{}, Removed: []models.CVE{}, Changed: []models.CVEDelta{}}
+	for _, id := range newOrder {
+		n := newByID[id]
+		o, ok := oldByID[id]
+		if !ok {
+			diff.Added = append(diff.Added, n)
+			continue
+		}
+		if o.EPSSScore != n.EPSSScore || o.Percentile != n.Percentile {
+			diff.Changed = append(diff.Changed, models.CVEDelta{
+				CVE:             n.ID,
+				OldEPSS:         o.EPSSScore,
+				NewEPSS:         n.EPSSScore,
+				EPSSDelta:       n.EPSSScore - o.EPSSScore,
+				OldPercentile:   o.Percentile,
+				NewPercentile:   n.Percentile,
+				PercentileDelta: n.Percentile - o.Percentile,
+			})
+		}
+	}
+	for _, id := range oldOrder {
+		if _, ok := newByID[id]; !ok {
+			diff.Removed = append(diff.Removed, oldByID[id])
+		}
+	}
+
+	sort.SliceStable(diff.Changed, func(i, j int) bool {
+		return math.Abs(diff.Changed[i].EPSSDelta) > math.Abs(diff.Changed[j].EPSSDelta)
+	})
+	return diff
+}
+
+// OnlyChanged trims diff to the CVEs present in both reports whose EPSS score
+// moved by at least minChange. The bound is inclusive: a change of exactly
+// minChange, as computed from the two float64 scores, is kept, so a
+// minChange of 0 keeps every changed row. Added and removed CVEs are dropped.
+func OnlyChanged(diff models.ReportDiff, minChange float64) models.ReportDiff {
+	changed := []models.CVEDelta{}
+	for _, d := range diff.Changed {
+		if math.Abs(d.EPSSDelta) >= minChange {
+			changed = append(changed, d)
+		}
+	}
+	return models.ReportDiff{Added: []models.CVE{}, Removed: []models.CVE{}, Changed: changed}
+}
+
+// indexReport maps each CVE ID (upper-cased) to its last row and returns the IDs
+// in order of first appearance.
+func indexReport(cves []models.CVE) (map[string]models.CVE, []string) {
+	byID := make(map[string]models.CVE, len(cves))
+	var order []string
+	for _, cve := range cves {
+		id := strings.ToUpper(cve.ID)
+		if _, seen := byID[id]; !seen {
+			order = append(order, id)
+		}
+		byID[id] = cve
+	}
+	return byID, order
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/pkg/epss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readReport(t *testing.T, path string) []models.CVE {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	cves, err := epss.DecodeCVEs(data)
+	require.NoError(t, err)
+	return cves
+}
+
+func TestDiffReports(t *testing.T) {
+	t.Run("Success - Fixture Reports", func(t *testing.T) {
+		old := readReport(t, "testdata/report_a.json")
+		new := readReport(t, "testdata/report_b.json")
+
+		diff := service.DiffReports(old, new)
+
+		require.Len(t, diff.Added, 1)
+		assert.Equal(t, "CVE-2024-0003", diff.Added[0].ID)
+		require.Len(t, diff.Removed, 1)
+		assert.Equal(t, "CVE-2023-0002", diff.Removed[0].ID)
+		require.Len(t, diff.Changed, 1)
+		assert.Equal(t, "CVE-2023-0001", diff.Changed[0].CVE)
+		assert.InDelta(t, 0.25, diff.Changed[0].EPSSDelta, 1e-9)
+		assert.InDelta(t, 0.15, diff.Changed[0].PercentileDelta, 1e-9)
+	})
+
+	t.Run("Success - Changes Sorted By EPSS Delta", func(t *testing.T) {
+		old := []models.CVE{{ID: "CVE-1", EPSSScore: 0.5}, {ID: "CVE-2", EPSSScore: 0.5}}
+		new := []models.CVE{{ID: "cve-1", EPSSScore: 0.45}, {ID: "CVE-2", EPSSScore: 0.1}}
+
+		diff := service.DiffReports(old, new)
+
+		require.Len(t, diff.Changed, 2)
+		assert.Equal(t, "CVE-2", diff.Changed[0].CVE)
+		assert.Equal(t, "cve-1", diff.Changed[1].CVE)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+	})
+}
+
+func TestOnlyChanged(t *testing.T) {
+	old := []models.CVE{{ID: "CVE-1", EPSSScore: 0.5}, {ID: "CVE-2", EPSSScore: 0.5}, {ID: "CVE-3", EPSSScore: 0.5, Percentile: 0.5}, {ID: "CVE-4"}}
+	new := []models.CVE{{ID: "CVE-1", EPSSScore: 0.75}, {ID: "CVE-2", EPSSScore: 0.625}, {ID: "CVE-3", EPSSScore: 0.5, Percentile: 0.75}, {ID: "CVE-5"}}
+	diff := service.DiffReports(old, new)
+
+	t.Run("Success - Drops Added And Removed", func(t *testing.T) {
+		trimmed := service.OnlyChanged(diff, 0)
+
+		assert.Empty(t, trimmed.Added)
+		assert.Empty(t, trimmed.Removed)
+		require.Len(t, trimmed.Changed, 3)
+	})
+
+	t.Run("Success - Boundary Is Inclusive", func(t *testing.T) {
+		trimmed := service.OnlyChanged(diff, 0.125)
+
+		require.Len(t, trimmed.Changed, 2)
+		assert.Equal(t, "CVE-1", trimmed.Changed[0].CVE)
+		assert.Equal(t, "CVE-2", trimmed.Changed[1].CVE)
+	})
+
+	t.Run("Success - Below Bound Dropped", func(t *testing.T) {
+		trimmed := service.OnlyChanged(diff, 0.25+1e-9)
+
+		assert.Empty(t, trimmed.Changed)
+		assert.NotNil(t, trimmed.Changed)
+	})
+}
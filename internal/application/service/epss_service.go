@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// DefaultRangeWorkers is how many days GetScoresInRange looks up at once by
+// default.
+const DefaultRangeWorkers = 4
+
+// ErrInvalidInput is returned by the EPSSService methods for arguments they
+// refuse before querying anything.
+var ErrInvalidInput = errors.New("invalid input")
+
+// cveIDPattern matches a CVE ID, such as CVE-2023-0001, in any case.
+var cveIDPattern = regexp.MustCompile(`^(?i)CVE-\d{4}-\d{4,}$`)
+
+// epssService validates queries before passing them to a repository.
+type epssService struct {
+	repo    ports.EPSSRepository
+	source  ports.ScoreSource
+	maxDays int
+	force   bool
+	workers int
+}
+
+// Option configures the service created by NewEPSSService.
+type Option func(*epssService)
+
+// WithScoreSource answers GetCVEScore from source, such as a fallback chain,
+// instead of the repository.
+func WithScoreSource(source ports.ScoreSource) Option {
+	return func(s *epssService) {
+		s.source = source
+	}
+}
+
+// WithMaxIncreaseDays refuses GetHighestIncreases windows longer than maxDays
+// unless force is set; see HighestIncreases. GetScoresInRange applies the same
+// cap to the days it looks up one by one. The default cap is
+// DefaultMaxIncreaseDays.
+func WithMaxIncreaseDays(maxDays int, force bool) Option {
+	return func(s *epssService) {
+		s.maxDays = maxDays
+		s.force = force
+	}
+}
+
+// WithParallel fetches up to n days at once when GetScoresInRange falls back
+// to daily lookups. The default is DefaultRangeWorkers.
+func WithParallel(n int) Option {
+	return func(s *epssService) {
+		s.workers = n
+	}
+}
+
+// NewEPSSService creates an EPSSService that queries repo.
+func NewEPSSService(repo ports.EPSSRepository, opts ...Option) ports.EPSSService {
+	s := &epssService{repo: repo, source: repo, maxDays: DefaultMaxIncreaseDays, workers: DefaultRangeWorkers}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetCVEScore returns the score of cveID for date, or for the latest data
+// when date is empty. The ID is matched in any case.
+func (s *epssService) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	id, err := ValidateCVEID(cveID)
+	if err != nil {
+		return nil, err
+	}
+	if date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("%w: invalid date format: %w", ErrInvalidInput, err)
+		}
+	}
+	return s.source.GetCVEScore(ctx, id, date)
+}
+
+// GetTopNCVEs returns the n CVEs with the highest current scores.
+func (s *epssService) GetTopNCVEs(ctx context.Context, n int) ([]models.CVE, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: n must be at least 1, got %d", ErrInvalidInput, n)
+	}
+	return s.repo.GetTopNCVEs(ctx, n)
+}
+
+// GetHighestIncreases returns the limit CVEs whose score rose the most over
+// the last days days.
+func (s *epssService) GetHighestIncreases(ctx context.Context, days int, limit int) ([]models.ScoreChange, error) {
+	if days < 1 {
+		return nil, fmt.Errorf("%w: days must be at least 1, got %d", ErrInvalidInput, days)
+	}
+	if limit < 1 {
+		return nil, fmt.Errorf("%w: limit must be at least 1, got %d", ErrInvalidInput, limit)
+	}
+	return HighestIncreases(ctx, s.repo, days, limit, s.maxDays, s.force)
+}
+
+// GetScoresInRange returns the daily scores of cveID from start to end
+// inclusive, oldest first. Days covered by the time series are taken from it;
+// earlier days in the range, which it does not reach, are looked up one by
+// one, and fail with ErrWindowTooLarge when there are more than the
+// WithMaxIncreaseDays cap unless forced. Days without data for the CVE are
+// left out.
+func (s *epssService) GetScoresInRange(ctx context.Context, cveID string, start, end time.Time) ([]models.CVE, error) {
+	id, err := ValidateCVEID(cveID)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end date %s is before start date %s", ErrInvalidInput, end.Format(dateLayout), start.Format(dateLayout))
+	}
+	first, last := start.Format(dateLayout), end.Format(dateLayout)
+
+	series, err := s.repo.GetTimeSeries(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time series for %s: %w", id, err)
+	}
+	var scores []models.CVE
+	covered := ""
+	for _, cve := range series {
+		if covered == "" || cve.Date < covered {
+			covered = cve.Date
+		}
+		if cve.Date >= first && cve.Date <= last {
+			scores = append(scores, cve)
+		}
+	}
+
+	// Look up the days before the series begins, or the whole range when
+	// the series is empty.
+	missingEnd := last
+	if covered != "" {
+		d, err := time.Parse(dateLayout, covered)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time series date %q: %w", covered, err)
+		}
+		missingEnd = min(last, d.AddDate(0, 0, -1).Format(dateLayout))
+	}
+	if missingEnd >= first {
+		days, err := daysBetween(first, missingEnd)
+		if err != nil {
+			return nil, err
+		}
+		if days > s.maxDays && !s.force {
+			return nil, fmt.Errorf("%w: %d days before the time series of %s would take %d requests, more than the maximum of %d days", ErrWindowTooLarge, days, id, days, s.maxDays)
+		}
+		dates, err := DatesBetween(first, missingEnd)
+		if err != nil {
+			return nil, err
+		}
+		fetch := func(date string) ([]models.CVE, error) {
+			return s.repo.GetCVEScores(ctx, []string{id}, date)
+		}
+		err = FetchDates(dates, s.workers, false, fetch, func(date string, cves []models.CVE) error {
+			scores = append(scores, cves...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scores for %s: %w", id, err)
+		}
+	}
+
+	SortByDate(scores)
+	return scores, nil
+}
+
+// ValidateCVEID returns id trimmed and upper-cased, or an ErrInvalidInput
+// error when it is not a CVE ID.
+func ValidateCVEID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if !cveIDPattern.MatchString(id) {
+		return "", fmt.Errorf("%w: %q is not a CVE ID (expected CVE-YYYY-NNNN)", ErrInvalidInput, id)
+	}
+	return strings.ToUpper(id), nil
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedSource answers every score lookup with the same score, recording the ID asked for.
+type fixedSource struct {
+	asked string
+}
+
+func (s *fixedSource) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	s.asked = cveID
+	return &models.CVE{ID: cveID, EPSSScore: 0.7, Date: date}, nil
+}
+
+func TestEPSSService(t *testing.T) {
+	var requests int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			date = "2024-10-18"
+		}
+		fmt.Fprintf(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":%q}]}`, date)
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - Score Normalizes The CVE ID", func(t *testing.T) {
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL))
+
+		cve, err := svc.GetCVEScore(context.Background(), " cve-2023-0001 ", "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Equal(t, "CVE-2023-0001", cve.ID)
+	})
+
+	t.Run("Success - Score Uses The Configured Source", func(t *testing.T) {
+		source := &fixedSource{}
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL), service.WithScoreSource(source))
+
+		cve, err := svc.GetCVEScore(context.Background(), "CVE-2023-12345", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "CVE-2023-12345", source.asked)
+		assert.Equal(t, 0.7, cve.EPSSScore)
+	})
+
+	t.Run("Success - Top N", func(t *testing.T) {
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL))
+
+		cves, err := svc.GetTopNCVEs(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 1)
+	})
+
+	t.Run("Success - Increases Within The Cap", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL), service.WithMaxIncreaseDays(2, false))
+
+		_, err := svc.GetHighestIncreases(context.Background(), 2, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Fail - Increases Beyond The Cap", func(t *testing.T) {
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL), service.WithMaxIncreaseDays(2, false))
+
+		_, err := svc.GetHighestIncreases(context.Background(), 3, 10)
+
+		assert.ErrorIs(t, err, service.ErrWindowTooLarge)
+	})
+
+	t.Run("Fail - Invalid Input Is Refused Before Fetching", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL))
+
+		for name, call := range map[string]func() error{
+			"malformed ID": func() error { _, err := svc.GetCVEScore(context.Background(), "CVE-23-1", ""); return err },
+			"not an ID":    func() error { _, err := svc.GetCVEScore(context.Background(), "GHSA-xxxx", ""); return err },
+			"bad date": func() error {
+				_, err := svc.GetCVEScore(context.Background(), "CVE-2023-0001", "18/10/2024")
+				return err
+			},
+			"zero n":     func() error { _, err := svc.GetTopNCVEs(context.Background(), 0); return err },
+			"zero days":  func() error { _, err := svc.GetHighestIncreases(context.Background(), 0, 10); return err },
+			"zero limit": func() error { _, err := svc.GetHighestIncreases(context.Background(), 7, 0); return err },
+		} {
+			assert.ErrorIs(t, call(), service.ErrInvalidInput, name)
+		}
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	})
+}
+
+func TestGetScoresInRange(t *testing.T) {
+	var daily int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("scope") == "time-series" {
+			fmt.Fprint(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.3","percentile":"0.9","date":"2024-10-18",
+				"time-series":[{"epss":"0.2","percentile":"0.8","date":"2024-10-17"},{"epss":"0.1","percentile":"0.7","date":"2024-10-16"}]}]}`)
+			return
+		}
+		atomic.AddInt32(&daily, 1)
+		if q.Get("date") == "2024-10-13" {
+			fmt.Fprint(w, `{"data":[]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.05","percentile":"0.5","date":%q}]}`, q.Get("date"))
+	}))
+	defer mockServer.Close()
+	day := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		require.NoError(t, err)
+		return d
+	}
+	dates := func(cves []models.CVE) []string {
+		var out []string
+		for _, cve := range cves {
+			out = append(out, cve.Date)
+		}
+		return out
+	}
+
+	t.Run("Success - Range Within The Time Series", func(t *testing.T) {
+		atomic.StoreInt32(&daily, 0)
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL))
+
+		cves, err := svc.GetScoresInRange(context.Background(), "CVE-2023-0001", day("2024-10-17"), day("2024-10-18"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2024-10-17", "2024-10-18"}, dates(cves))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&daily))
+	})
+
+	t.Run("Success - Days Before The Time Series Are Looked Up Daily", func(t *testing.T) {
+		atomic.StoreInt32(&daily, 0)
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL), service.WithParallel(2))
+
+		cves, err := svc.GetScoresInRange(context.Background(), "cve-2023-0001", day("2024-10-12"), day("2024-10-17"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2024-10-12", "2024-10-14", "2024-10-15", "2024-10-16", "2024-10-17"}, dates(cves))
+		assert.Equal(t, 0.05, cves[0].EPSSScore)
+		assert.Equal(t, 0.2, cves[4].EPSSScore)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&daily))
+	})
+
+	t.Run("Success - Forced Past The Cap", func(t *testing.T) {
+		atomic.StoreInt32(&daily, 0)
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL), service.WithMaxIncreaseDays(2, true))
+
+		_, err := svc.GetScoresInRange(context.Background(), "CVE-2023-0001", day("2024-10-12"), day("2024-10-17"))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&daily))
+	})
+
+	t.Run("Fail - Daily Lookups Beyond The Cap", func(t *testing.T) {
+		atomic.StoreInt32(&daily, 0)
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL), service.WithMaxIncreaseDays(3, false))
+
+		_, err := svc.GetScoresInRange(context.Background(), "CVE-2023-0001", day("2024-10-12"), day("2024-10-17"))
+
+		assert.ErrorIs(t, err, service.ErrWindowTooLarge)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&daily))
+	})
+
+	t.Run("Fail - End Before Start", func(t *testing.T) {
+		svc := service.NewEPSSService(repository.NewAPIRepository(mockServer.URL))
+
+		_, err := svc.GetScoresInRange(context.Background(), "CVE-2023-0001", day("2024-10-18"), day("2024-10-17"))
+
+		assert.ErrorIs(t, err, service.ErrInvalidInput)
+	})
+}
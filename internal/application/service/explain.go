@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// EPSS score thresholds used to phrase risk explanations. A score at or above
+// a threshold gets that level's wording.
+const (
+	VeryHighRiskThreshold = 0.5
+	HighRiskThreshold     = 0.1
+	ModerateRiskThreshold = 0.01
+)
+
+// RiskLevel describes an EPSS score in plain words.
+func RiskLevel(epss float64) string {
+	switch {
+	case epss >= VeryHighRiskThreshold:
+		return "very high"
+	case epss >= HighRiskThreshold:
+		return "high"
+	case epss >= ModerateRiskThreshold:
+		return "moderate"
+	default:
+		return "low"
+	}
+}
+
+// ExplainRisk returns a plain-language interpretation of a CVE's score, e.g.
+// "0.92 EPSS (97th percentile): very high probability of exploitation in the next 30 days."
+func ExplainRisk(cve models.CVE) string {
+	return fmt.Sprintf("%.2f EPSS (%s percentile): %s probability of exploitation in the next 30 days.",
+		cve.EPSSScore, ordinal(int(math.Floor(cve.Percentile*100))), RiskLevel(cve.EPSSScore))
+}
+
+// ordinal formats n with its English ordinal suffix.
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
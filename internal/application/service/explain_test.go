@@ -0,0 +1,31 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainRisk(t *testing.T) {
+	t.Run("Success - Very High Risk", func(t *testing.T) {
+		got := service.ExplainRisk(models.CVE{EPSSScore: 0.92, Percentile: 0.975})
+
+		assert.Equal(t, "0.92 EPSS (97th percentile): very high probability of exploitation in the next 30 days.", got)
+	})
+
+	t.Run("Success - Levels Follow Thresholds", func(t *testing.T) {
+		assert.Equal(t, "very high", service.RiskLevel(service.VeryHighRiskThreshold))
+		assert.Equal(t, "high", service.RiskLevel(0.2))
+		assert.Equal(t, "moderate", service.RiskLevel(0.05))
+		assert.Equal(t, "low", service.RiskLevel(0.0004))
+	})
+
+	t.Run("Success - Ordinal Suffixes", func(t *testing.T) {
+		assert.Contains(t, service.ExplainRisk(models.CVE{Percentile: 0.01}), "(1st percentile)")
+		assert.Contains(t, service.ExplainRisk(models.CVE{Percentile: 0.12}), "(12th percentile)")
+		assert.Contains(t, service.ExplainRisk(models.CVE{Percentile: 0.22}), "(22nd percentile)")
+		assert.Contains(t, service.ExplainRisk(models.CVE{Percentile: 0.43}), "(43rd percentile)")
+	})
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// DefaultMaxStalenessDays is the data age, in days, beyond which results are
+// flagged stale. EPSS publishes daily, so older data usually means a missed
+// publication or a query for a past date.
+const DefaultMaxStalenessDays = 2
+
+// DataAge returns the number of whole days between date and now's UTC day.
+// Dates after today count as age 0.
+func DataAge(date string, now time.Time) (int, error) {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, err
+	}
+	today := now.UTC().Truncate(24 * time.Hour)
+	return max(int(today.Sub(d).Hours()/24), 0), nil
+}
+
+// AnnotateFreshness sets AgeDays and Stale on each CVE relative to now, a
+// result being stale when older than maxStaleness days, and returns the
+// number of stale results. CVEs without a valid date are left unannotated.
+func AnnotateFreshness(cves []models.CVE, now time.Time, maxStaleness int) int {
+	stale := 0
+	for i := range cves {
+		age, err := DataAge(cves[i].Date, now)
+		if err != nil {
+			continue
+		}
+		isStale := age > maxStaleness
+		if isStale {
+			stale++
+		}
+		cves[i].AgeDays, cves[i].Stale = &age, &isStale
+	}
+	return stale
+}
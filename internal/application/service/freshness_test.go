@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateFreshness(t *testing.T) {
+	now := time.Date(2024, 10, 21, 15, 30, 0, 0, time.UTC)
+
+	t.Run("Success - Flags Results Older Than The Threshold", func(t *testing.T) {
+		cves := []models.CVE{
+			{ID: "CVE-2023-0001", Date: "2024-10-21"},
+			{ID: "CVE-2023-0002", Date: "2024-10-19"},
+			{ID: "CVE-2023-0003", Date: "2024-10-18"},
+		}
+
+		stale := service.AnnotateFreshness(cves, now, 2)
+
+		assert.Equal(t, 1, stale)
+		for i, want := range []struct {
+			age   int
+			stale bool
+		}{{0, false}, {2, false}, {3, true}} {
+			require.NotNil(t, cves[i].AgeDays)
+			assert.Equal(t, want.age, *cves[i].AgeDays)
+			assert.Equal(t, want.stale, *cves[i].Stale)
+		}
+	})
+
+	t.Run("Success - Future Dates Have Age Zero", func(t *testing.T) {
+		age, err := service.DataAge("2024-10-22", now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, age)
+	})
+
+	t.Run("Fail - Invalid Date Is Left Unannotated", func(t *testing.T) {
+		cves := []models.CVE{{ID: "CVE-2023-0001"}}
+
+		assert.Zero(t, service.AnnotateFreshness(cves, now, 2))
+		assert.Nil(t, cves[0].AgeDays)
+		assert.Nil(t, cves[0].Stale)
+	})
+}
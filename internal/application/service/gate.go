@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// CheckGate returns the CVEs among ids that break a release gate: those listed
+// in catalog (when it is non-nil), regardless of EPSS, and those whose EPSS
+// score in scores exceeds failOver (when it is positive). Each CVE is reported
+// once, in the order of ids.
+func CheckGate(ids []string, scores []models.CVE, catalog ports.KEVCatalog, failOver float64) []models.GateFailure {
+	epss := make(map[string]float64, len(scores))
+	for _, cve := range scores {
+		epss[strings.ToUpper(cve.ID)] = cve.EPSSScore
+	}
+
+	seen := make(map[string]bool, len(ids))
+	var failures []models.GateFailure
+	for _, id := range ids {
+		id = strings.ToUpper(id)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		var reasons []string
+		if catalog != nil {
+			if _, ok := catalog.Lookup(id); ok {
+				reasons = append(reasons, "listed in CISA KEV")
+			}
+		}
+		if score, ok := epss[id]; ok && failOver > 0 && score > failOver {
+			reasons = append(reasons, fmt.Sprintf("EPSS %s exceeds %s",
+				strconv.FormatFloat(score, 'f', -1, 64), strconv.FormatFloat(failOver, 'f', -1, 64)))
+		}
+		if len(reasons) > 0 {
+			failures = append(failures, models.GateFailure{CVE: id, Reasons: reasons})
+		}
+	}
+	return failures
+}
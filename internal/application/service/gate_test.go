@@ -0,0 +1,38 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGate(t *testing.T) {
+	catalog := fakeCatalog{"CVE-2021-44228": {CVE: "CVE-2021-44228"}}
+	ids := []string{"CVE-2021-44228", "cve-2023-0001", "CVE-2023-0002", "CVE-2023-0001"}
+	scores := []models.CVE{
+		{ID: "CVE-2021-44228", EPSSScore: 0.97},
+		{ID: "CVE-2023-0001", EPSSScore: 0.6},
+		{ID: "CVE-2023-0002", EPSSScore: 0.1},
+	}
+
+	t.Run("Success - KEV Fails Regardless Of EPSS", func(t *testing.T) {
+		failures := service.CheckGate(ids, []models.CVE{{ID: "CVE-2021-44228", EPSSScore: 0.01}}, catalog, 0)
+
+		assert.Equal(t, []models.GateFailure{{CVE: "CVE-2021-44228", Reasons: []string{"listed in CISA KEV"}}}, failures)
+	})
+
+	t.Run("Success - Either Condition Gates", func(t *testing.T) {
+		failures := service.CheckGate(ids, scores, catalog, 0.5)
+
+		assert.Equal(t, []models.GateFailure{
+			{CVE: "CVE-2021-44228", Reasons: []string{"listed in CISA KEV", "EPSS 0.97 exceeds 0.5"}},
+			{CVE: "CVE-2023-0001", Reasons: []string{"EPSS 0.6 exceeds 0.5"}},
+		}, failures)
+	})
+
+	t.Run("Success - No Gates Set", func(t *testing.T) {
+		assert.Empty(t, service.CheckGate(ids, scores, nil, 0))
+	})
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// CVEYear parses the disclosure year from a CVE ID such as CVE-2021-44228.
+func CVEYear(cveID string) (int, bool) {
+	parts := strings.SplitN(cveID, "-", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[0], "CVE") || len(parts[1]) != 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, false
+	}
+	return year, true
+}
+
+// GroupByYear returns per-year counts and mean EPSS scores, sorted by year.
+// CVEs whose ID has no parseable year are skipped.
+func GroupByYear(cves []models.CVE) []models.YearSummary {
+	sums := make(map[int]float64)
+	counts := make(map[int]int)
+	for _, cve := range cves {
+		year, ok := CVEYear(cve.ID)
+		if !ok {
+			continue
+		}
+		sums[year] += cve.EPSSScore
+		counts[year]++
+	}
+
+	summaries := make([]models.YearSummary, 0, len(counts))
+	for year, count := range counts {
+		summaries = append(summaries, models.YearSummary{
+			Year:     year,
+			Count:    count,
+			MeanEPSS: sums[year] / float64(count),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Year < summaries[j].Year
+	})
+	return summaries
+}
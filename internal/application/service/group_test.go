@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCVEYear(t *testing.T) {
+	t.Run("Success - Parses Year", func(t *testing.T) {
+		year, ok := service.CVEYear("CVE-2021-44228")
+		assert.True(t, ok)
+		assert.Equal(t, 2021, year)
+	})
+
+	t.Run("Fail - Malformed IDs", func(t *testing.T) {
+		for _, id := range []string{"", "CVE-21-1", "GHSA-2021-xxxx", "CVE-abcd-1234", "CVE2021"} {
+			_, ok := service.CVEYear(id)
+			assert.False(t, ok, id)
+		}
+	})
+}
+
+func TestGroupByYear(t *testing.T) {
+	t.Run("Success - Counts And Means Per Year", func(t *testing.T) {
+		cves := []models.CVE{
+			{ID: "CVE-2021-0001", EPSSScore: 0.2},
+			{ID: "CVE-2019-0001", EPSSScore: 0.9},
+			{ID: "CVE-2021-0002", EPSSScore: 0.4},
+			{ID: "not-a-cve", EPSSScore: 1.0},
+		}
+
+		groups := service.GroupByYear(cves)
+
+		assert.Len(t, groups, 2)
+		assert.Equal(t, 2019, groups[0].Year)
+		assert.Equal(t, 1, groups[0].Count)
+		assert.InDelta(t, 0.9, groups[0].MeanEPSS, 1e-9)
+		assert.Equal(t, 2021, groups[1].Year)
+		assert.Equal(t, 2, groups[1].Count)
+		assert.InDelta(t, 0.3, groups[1].MeanEPSS, 1e-9)
+	})
+}
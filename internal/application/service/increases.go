@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// DefaultMaxIncreaseDays is the longest look-back HighestIncreases accepts
+// without force. The API is queried once per day of the window.
+const DefaultMaxIncreaseDays = 180
+
+// ErrWindowTooLarge is returned by HighestIncreases and GetScoresInRange when
+// the days to fetch one by one exceed the cap and force is not set.
+var ErrWindowTooLarge = errors.New("look-back window too large")
+
+// HighestIncreases returns the limit CVEs whose score rose the most over the
+// last days days. A window longer than maxDays is refused before anything is
+// fetched, unless force is set, as each day costs a request.
+func HighestIncreases(ctx context.Context, repo ports.EPSSRepository, days int, limit int, maxDays int, force bool) ([]models.ScoreChange, error) {
+	if days > maxDays && !force {
+		return nil, fmt.Errorf("%w: %d days would take about %d requests, more than the maximum of %d days", ErrWindowTooLarge, days, days+1, maxDays)
+	}
+	return repo.GetHighestIncreases(ctx, days, limit)
+}
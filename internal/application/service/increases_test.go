@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighestIncreases(t *testing.T) {
+	var requests int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.5","date":%q}]}`, r.URL.Query().Get("date"))
+	}))
+	defer mockServer.Close()
+
+	t.Run("Fail - Cap Enforced Before Fetching", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		_, err := service.HighestIncreases(context.Background(), repository.NewAPIRepository(mockServer.URL), 3, 10, 2, false)
+
+		assert.ErrorIs(t, err, service.ErrWindowTooLarge)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Success - Within Cap", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		_, err := service.HighestIncreases(context.Background(), repository.NewAPIRepository(mockServer.URL), 2, 10, 2, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Success - Force Bypasses Cap", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		_, err := service.HighestIncreases(context.Background(), repository.NewAPIRepository(mockServer.URL), 3, 10, 2, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+	})
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// IncrementalState is the progress persisted between runs of an incremental job.
+type IncrementalState struct {
+	LastDate  string    `json:"last_date"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PendingDates returns the dates an incremental run still has to process: every
+// date after lastDate up to latest. On the first run (empty lastDate) it starts
+// at firstDate, or at latest when no first date is configured.
+func PendingDates(lastDate, firstDate, latest string) ([]string, error) {
+	start := firstDate
+	if lastDate != "" {
+		last, err := time.Parse(dateLayout, lastDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid last processed date: %w", err)
+		}
+		start = last.AddDate(0, 0, 1).Format(dateLayout)
+	}
+	if start == "" {
+		start = latest
+	}
+
+	startDate, err := time.Parse(dateLayout, start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+	latestDate, err := time.Parse(dateLayout, latest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latest date format: %w", err)
+	}
+	if startDate.After(latestDate) {
+		return nil, nil
+	}
+	return DatesBetween(start, latest)
+}
+
+// RunIncremental processes dates in order, advancing st and saving it after
+// each date so an interrupted run resumes after the last completed date.
+func RunIncremental(st *IncrementalState, dates []string, process func(date string) error, save func(IncrementalState) error) error {
+	for _, date := range dates {
+		if err := process(date); err != nil {
+			return fmt.Errorf("failed to process %s: %w", date, err)
+		}
+		st.LastDate = date
+		st.UpdatedAt = time.Now().UTC()
+		if err := save(*st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
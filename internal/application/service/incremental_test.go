@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// incrementalRun simulates one scheduled run against the latest available date.
+func incrementalRun(t *testing.T, st *service.IncrementalState, firstDate, latest string) []string {
+	dates, err := service.PendingDates(st.LastDate, firstDate, latest)
+	require.NoError(t, err)
+
+	var processed []string
+	err = service.RunIncremental(st, dates, func(date string) error {
+		processed = append(processed, date)
+		return nil
+	}, func(service.IncrementalState) error { return nil })
+	require.NoError(t, err)
+	return processed
+}
+
+func TestIncremental(t *testing.T) {
+	t.Run("Success - State Advances Across Runs", func(t *testing.T) {
+		var st service.IncrementalState
+
+		first := incrementalRun(t, &st, "2024-10-01", "2024-10-03")
+		assert.Equal(t, []string{"2024-10-01", "2024-10-02", "2024-10-03"}, first)
+		assert.Equal(t, "2024-10-03", st.LastDate)
+
+		second := incrementalRun(t, &st, "2024-10-01", "2024-10-05")
+		assert.Equal(t, []string{"2024-10-04", "2024-10-05"}, second)
+		assert.Equal(t, "2024-10-05", st.LastDate)
+
+		third := incrementalRun(t, &st, "2024-10-01", "2024-10-05")
+		assert.Empty(t, third)
+		assert.Equal(t, "2024-10-05", st.LastDate)
+	})
+
+	t.Run("Success - First Run Without Start Date Takes Latest Only", func(t *testing.T) {
+		dates, err := service.PendingDates("", "", "2024-10-05")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2024-10-05"}, dates)
+	})
+
+	t.Run("Fail - Interrupted Run Keeps Last Completed Date", func(t *testing.T) {
+		st := service.IncrementalState{LastDate: "2024-10-01"}
+		var saved []string
+		err := service.RunIncremental(&st, []string{"2024-10-02", "2024-10-03"}, func(date string) error {
+			if date == "2024-10-03" {
+				return errors.New("boom")
+			}
+			return nil
+		}, func(s service.IncrementalState) error {
+			saved = append(saved, s.LastDate)
+			return nil
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, "2024-10-02", st.LastDate)
+		assert.Equal(t, []string{"2024-10-02"}, saved)
+	})
+
+	t.Run("Fail - Invalid Last Date", func(t *testing.T) {
+		_, err := service.PendingDates("yesterday", "", "2024-10-05")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// CVEIterator yields a day's CVEs page by page, holding only the current page
+// in memory. Call Next until it returns false, then check Err:
+//
+//	for it.Next() {
+//		cve := it.CVE()
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// Stopping early needs no cleanup; no further pages are requested.
+type CVEIterator struct {
+	ctx       context.Context
+	pager     ports.CVEPager
+	date      string
+	transform func([]models.CVE) ([]models.CVE, error)
+
+	page   []models.CVE
+	next   int
+	offset int
+	more   bool
+	cur    models.CVE
+	err    error
+}
+
+// IterateCVEs returns an iterator over the CVEs scored on date (the latest data
+// when empty). Cancelling ctx aborts the page request in flight and makes the
+// iterator stop with ctx's error. transform, when non-nil, is applied to each
+// page as it is fetched.
+func IterateCVEs(ctx context.Context, pager ports.CVEPager, date string, transform func([]models.CVE) ([]models.CVE, error)) *CVEIterator {
+	return &CVEIterator{ctx: ctx, pager: pager, date: date, transform: transform, more: true}
+}
+
+// Next advances to the next CVE, fetching the following page when the current
+// one is exhausted. It returns false at the end of the day or on error.
+func (it *CVEIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	for it.next >= len(it.page) {
+		if !it.more {
+			return false
+		}
+		page, more, err := it.pager.GetCVEPage(it.ctx, it.date, it.offset)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.offset += len(page)
+		it.more = more && len(page) > 0
+		if it.transform != nil && len(page) > 0 {
+			if page, err = it.transform(page); err != nil {
+				it.err = err
+				return false
+			}
+		}
+		it.page, it.next = page, 0
+	}
+	it.cur = it.page[it.next]
+	it.next++
+	return true
+}
+
+// CVE returns the CVE Next advanced to.
+func (it *CVEIterator) CVE() models.CVE {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *CVEIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPagedServer serves total CVEs for 2024-10-18, counting requests.
+func newPagedServer(t *testing.T, total int, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{"data":[`)
+		for i := offset; i < total && i < offset+limit; i++ {
+			if i > offset {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"cve":"CVE-2024-%04d","epss":"0.1","percentile":"0.5","date":"2024-10-18"}`, i)
+		}
+		fmt.Fprint(w, `]}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestIterateCVEs(t *testing.T) {
+	t.Run("Success - Yields Every Page", func(t *testing.T) {
+		var requests int
+		server := newPagedServer(t, 5, &requests)
+		pager := repository.NewAPIRepository(server.URL, repository.WithPageSize(2)).(ports.CVEPager)
+
+		it := service.IterateCVEs(context.Background(), pager, "2024-10-18", nil)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.CVE().ID)
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"CVE-2024-0000", "CVE-2024-0001", "CVE-2024-0002", "CVE-2024-0003", "CVE-2024-0004"}, ids)
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("Success - Early Break Fetches No Further Pages", func(t *testing.T) {
+		var requests int
+		server := newPagedServer(t, 10, &requests)
+		pager := repository.NewAPIRepository(server.URL, repository.WithPageSize(2)).(ports.CVEPager)
+
+		it := service.IterateCVEs(context.Background(), pager, "2024-10-18", nil)
+		for i := 0; i < 3 && it.Next(); i++ {
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, "CVE-2024-0002", it.CVE().ID)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("Success - Transform Filters Pages", func(t *testing.T) {
+		var requests int
+		server := newPagedServer(t, 4, &requests)
+		pager := repository.NewAPIRepository(server.URL, repository.WithPageSize(2)).(ports.CVEPager)
+		dropFirstPage := func(page []models.CVE) ([]models.CVE, error) {
+			if page[0].ID == "CVE-2024-0000" {
+				return nil, nil
+			}
+			return page, nil
+		}
+
+		it := service.IterateCVEs(context.Background(), pager, "2024-10-18", dropFirstPage)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.CVE().ID)
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"CVE-2024-0002", "CVE-2024-0003"}, ids)
+	})
+
+	t.Run("Fail - Cancelled Context", func(t *testing.T) {
+		var requests int
+		server := newPagedServer(t, 10, &requests)
+		pager := repository.NewAPIRepository(server.URL, repository.WithPageSize(2)).(ports.CVEPager)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		it := service.IterateCVEs(ctx, pager, "2024-10-18", nil)
+		require.True(t, it.Next())
+		cancel()
+
+		assert.False(t, it.Next())
+		assert.ErrorIs(t, it.Err(), context.Canceled)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Fail - Page Error Stops Iteration", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		pager := repository.NewAPIRepository(server.URL).(ports.CVEPager)
+
+		it := service.IterateCVEs(context.Background(), pager, "2024-10-18", nil)
+
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+		assert.False(t, it.Next())
+	})
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// KEVListed returns the tracked CVE IDs currently listed in catalog, sorted.
+func KEVListed(tracked []string, catalog ports.KEVCatalog) []string {
+	listed := make([]string, 0)
+	for _, id := range tracked {
+		if entry, ok := catalog.Lookup(id); ok {
+			listed = append(listed, entry.CVE)
+		}
+	}
+	sort.Strings(listed)
+	return listed
+}
+
+// NewKEVAlerts returns an alert for each CVE in listed that was not in previous.
+func NewKEVAlerts(listed []string, previous []string, catalog ports.KEVCatalog) []models.Alert {
+	seen := make(map[string]bool, len(previous))
+	for _, id := range previous {
+		seen[strings.ToUpper(id)] = true
+	}
+
+	var alerts []models.Alert
+	for _, id := range listed {
+		if seen[strings.ToUpper(id)] {
+			continue
+		}
+		entry, _ := catalog.Lookup(id)
+		alerts = append(alerts, models.Alert{
+			CVE:     entry.CVE,
+			Kind:    "kev-added",
+			Message: fmt.Sprintf("added to the CISA KEV catalog on %s (%s)", entry.DateAdded, entry.VulnerabilityName),
+		})
+	}
+	return alerts
+}
@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCatalog is an in-memory KEV catalog.
+type fakeCatalog map[string]models.KEVEntry
+
+func (f fakeCatalog) Lookup(cveID string) (models.KEVEntry, bool) {
+	entry, ok := f[cveID]
+	return entry, ok
+}
+
+func TestNewKEVAlerts(t *testing.T) {
+	catalog := fakeCatalog{
+		"CVE-2021-44228": {CVE: "CVE-2021-44228", DateAdded: "2021-12-10", VulnerabilityName: "Log4Shell"},
+		"CVE-2020-1472":  {CVE: "CVE-2020-1472", DateAdded: "2021-11-03", VulnerabilityName: "Zerologon"},
+	}
+	tracked := []string{"CVE-2021-44228", "CVE-2020-1472", "CVE-2023-0001"}
+
+	t.Run("Success - Lists Tracked CVEs In Catalog", func(t *testing.T) {
+		assert.Equal(t, []string{"CVE-2020-1472", "CVE-2021-44228"}, service.KEVListed(tracked, catalog))
+	})
+
+	t.Run("Success - Alerts Only On Newly Listed CVEs", func(t *testing.T) {
+		listed := service.KEVListed(tracked, catalog)
+		alerts := service.NewKEVAlerts(listed, []string{"CVE-2020-1472"}, catalog)
+
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, "CVE-2021-44228", alerts[0].CVE)
+		assert.Equal(t, "kev-added", alerts[0].Kind)
+	})
+
+	t.Run("Success - No Alerts When Nothing Changed", func(t *testing.T) {
+		listed := service.KEVListed(tracked, catalog)
+		assert.Empty(t, service.NewKEVAlerts(listed, listed, catalog))
+	})
+}
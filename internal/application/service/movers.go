@@ -0,0 +1,44 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// PercentileMovers compares two snapshots and returns the limit CVEs whose
+// percentile moved the most in either direction, largest movement first.
+// Both the percentile and EPSS deltas are reported; CVEs missing from either
+// snapshot are skipped.
+func PercentileMovers(start, end []models.CVE, date time.Time, limit int) []models.ScoreChange {
+	before := make(map[string]models.CVE, len(start))
+	for _, cve := range start {
+		before[cve.ID] = cve
+	}
+	var changes []models.ScoreChange
+	for _, cve := range end {
+		prev, ok := before[cve.ID]
+		if !ok {
+			continue
+		}
+		changes = append(changes, models.ScoreChange{
+			CVE:              cve.ID,
+			Date:             date,
+			ScoreChange:      cve.EPSSScore - prev.EPSSScore,
+			PercentileChange: cve.Percentile - prev.Percentile,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := math.Abs(changes[i].PercentileChange), math.Abs(changes[j].PercentileChange)
+		if a != b {
+			return a > b
+		}
+		return changes[i].CVE < changes[j].CVE
+	})
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes
+}
@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentileMovers(t *testing.T) {
+	date := time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC)
+	start := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.010, Percentile: 0.50},
+		{ID: "CVE-2023-0002", EPSSScore: 0.020, Percentile: 0.80},
+		{ID: "CVE-2023-0003", EPSSScore: 0.030, Percentile: 0.90},
+	}
+	end := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.011, Percentile: 0.70},
+		{ID: "CVE-2023-0002", EPSSScore: 0.500, Percentile: 0.85},
+		{ID: "CVE-2023-0003", EPSSScore: 0.029, Percentile: 0.60},
+		{ID: "CVE-2024-0001", EPSSScore: 0.900, Percentile: 0.99},
+	}
+
+	t.Run("Success - Sorted By Absolute Percentile Change", func(t *testing.T) {
+		changes := service.PercentileMovers(start, end, date, 10)
+
+		assert.Len(t, changes, 3)
+		assert.Equal(t, "CVE-2023-0003", changes[0].CVE)
+		assert.InDelta(t, -0.30, changes[0].PercentileChange, 1e-9)
+		assert.InDelta(t, -0.001, changes[0].ScoreChange, 1e-9)
+		assert.Equal(t, "CVE-2023-0001", changes[1].CVE)
+		assert.InDelta(t, 0.20, changes[1].PercentileChange, 1e-9)
+		assert.Equal(t, "CVE-2023-0002", changes[2].CVE)
+		assert.Equal(t, date, changes[2].Date)
+	})
+
+	t.Run("Success - Limit", func(t *testing.T) {
+		changes := service.PercentileMovers(start, end, date, 1)
+
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "CVE-2023-0003", changes[0].CVE)
+	})
+
+	t.Run("Success - No Overlap", func(t *testing.T) {
+		assert.Empty(t, service.PercentileMovers(nil, end, date, 10))
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"math"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// maxNormalizedPercentile keeps the transform finite for the extreme
+// percentiles of 0 and 1, which map to ±3.72 standard deviations.
+const maxNormalizedPercentile = 0.9999
+
+// NormalizedPosition maps a percentile to a z-like position: the number of
+// standard deviations from the median a value with that percentile would sit
+// at under a standard normal distribution.
+//
+// EPSS scores are not comparable across model versions, but percentiles are
+// ranks within a day's population, so their positions remain comparable. The
+// result is approximate: it assumes nothing about the real score distribution
+// and inherits any change in the population of scored CVEs.
+func NormalizedPosition(percentile float64) float64 {
+	p := math.Min(math.Max(percentile, 1-maxNormalizedPercentile), maxNormalizedPercentile)
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// NormalizeScores sets Normalized on each CVE from its percentile.
+func NormalizeScores(cves []models.CVE) {
+	for i := range cves {
+		position := NormalizedPosition(cves[i].Percentile)
+		cves[i].Normalized = &position
+	}
+}
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizedPosition(t *testing.T) {
+	t.Run("Success - Median Maps To Zero", func(t *testing.T) {
+		assert.InDelta(t, 0, service.NormalizedPosition(0.5), 1e-12)
+	})
+
+	t.Run("Success - Known Quantiles", func(t *testing.T) {
+		assert.InDelta(t, 1.2816, service.NormalizedPosition(0.9), 1e-4)
+		assert.InDelta(t, -1.2816, service.NormalizedPosition(0.1), 1e-4)
+	})
+
+	t.Run("Success - Extremes Stay Finite", func(t *testing.T) {
+		high := service.NormalizedPosition(1)
+		low := service.NormalizedPosition(0)
+
+		assert.False(t, math.IsInf(high, 0))
+		assert.InDelta(t, 3.719, high, 1e-3)
+		assert.InDelta(t, -high, low, 1e-9)
+	})
+
+	t.Run("Success - Order Is Preserved", func(t *testing.T) {
+		assert.Less(t, service.NormalizedPosition(0.7), service.NormalizedPosition(0.95))
+	})
+}
+
+func TestNormalizeScores(t *testing.T) {
+	t.Run("Success - Sets Normalized From Percentile", func(t *testing.T) {
+		cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.5}}
+
+		service.NormalizeScores(cves)
+
+		require.NotNil(t, cves[0].Normalized)
+		assert.InDelta(t, 0, *cves[0].Normalized, 1e-12)
+		assert.Equal(t, 0.9, cves[0].EPSSScore)
+	})
+}
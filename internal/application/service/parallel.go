@@ -0,0 +1,102 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// fetchResult is the outcome of fetching one key.
+type fetchResult struct {
+	index int
+	cves  []models.CVE
+	err   error
+}
+
+// FetchDates fetches each date with up to workers concurrent calls to fetch and
+// passes every result to emit from the calling goroutine.
+//
+// When ordered is true, results are emitted in the order of dates: results that
+// arrive early wait in a reordering buffer until every earlier date has been
+// emitted. Otherwise results are emitted as soon as they arrive. The first fetch
+// or emit error stops further work and is returned.
+func FetchDates(dates []string, workers int, ordered bool, fetch func(date string) ([]models.CVE, error), emit func(date string, cves []models.CVE) error) error {
+	return FetchEach(dates, workers, ordered, fetch, emit)
+}
+
+// FetchEach is FetchDates for arbitrary keys, such as CVE IDs.
+func FetchEach(keys []string, workers int, ordered bool, fetch func(key string) ([]models.CVE, error), emit func(key string, cves []models.CVE) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	results := make(chan fetchResult)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				cves, err := fetch(keys[i])
+				select {
+				case results <- fetchResult{index: i, cves: cves, err: err}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := range keys {
+			select {
+			case jobs <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	pending := make(map[int]fetchResult)
+	next := 0
+	for res := range results {
+		if firstErr != nil {
+			continue
+		}
+		if res.err != nil {
+			firstErr = res.err
+			close(done)
+			continue
+		}
+		if !ordered {
+			if err := emit(keys[res.index], res.cves); err != nil {
+				firstErr = err
+				close(done)
+			}
+			continue
+		}
+		pending[res.index] = res
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if err := emit(keys[ready.index], ready.cves); err != nil {
+				firstErr = err
+				close(done)
+				break
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// jitteryFetch returns one CVE per date after a random delay.
+func jitteryFetch(date string) ([]models.CVE, error) {
+	time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+	return []models.CVE{{ID: "CVE-2023-0001", Date: date}}, nil
+}
+
+func TestFetchDates(t *testing.T) {
+	dates := []string{"2024-10-01", "2024-10-02", "2024-10-03", "2024-10-04", "2024-10-05", "2024-10-06", "2024-10-07", "2024-10-08"}
+
+	t.Run("Success - Ordered Output Dates Are Monotonic", func(t *testing.T) {
+		var emitted []string
+		err := service.FetchDates(dates, 4, true, jitteryFetch, func(date string, cves []models.CVE) error {
+			emitted = append(emitted, cves[0].Date)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dates, emitted)
+		assert.True(t, sort.StringsAreSorted(emitted))
+	})
+
+	t.Run("Success - Unordered Output Contains Every Date", func(t *testing.T) {
+		var emitted []string
+		err := service.FetchDates(dates, 4, false, jitteryFetch, func(date string, cves []models.CVE) error {
+			emitted = append(emitted, date)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, dates, emitted)
+	})
+
+	t.Run("Fail - Fetch Error Stops Emission", func(t *testing.T) {
+		fetch := func(date string) ([]models.CVE, error) {
+			if date == "2024-10-03" {
+				return nil, errors.New("upstream unavailable")
+			}
+			return jitteryFetch(date)
+		}
+		var emitted []string
+		err := service.FetchDates(dates, 2, true, fetch, func(date string, cves []models.CVE) error {
+			emitted = append(emitted, date)
+			return nil
+		})
+
+		assert.EqualError(t, err, "upstream unavailable")
+		assert.NotContains(t, emitted, "2024-10-03")
+		assert.NotContains(t, emitted, "2024-10-04")
+	})
+}
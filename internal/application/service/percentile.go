@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// TopPercentileCutoff returns the percentile a CVE must exceed to be in the
+// top pct percent, e.g. 0.99 for a pct of 1. pct must lie strictly between
+// 0 and 100.
+func TopPercentileCutoff(pct float64) (float64, error) {
+	if pct <= 0 || pct >= 100 {
+		return 0, fmt.Errorf("pct must be between 0 and 100 exclusive, got %g", pct)
+	}
+	// Rounding drops the binary noise of 1 - pct/100, so a pct of 0.1 is
+	// sent as 0.999 rather than 0.9990000000000001.
+	return math.Round((1-pct/100)*1e12) / 1e12, nil
+}
+
+// TopPercentile returns the CVEs in the top pct percent of date's scores,
+// highest percentile first.
+func TopPercentile(ctx context.Context, repo ports.EPSSRepository, date string, pct float64) ([]models.CVE, error) {
+	cutoff, err := TopPercentileCutoff(pct)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetCVEsAbovePercentile(ctx, date, cutoff)
+}
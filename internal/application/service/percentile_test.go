@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopPercentileCutoff(t *testing.T) {
+	t.Run("Success - Percent Maps To Percentile Fraction", func(t *testing.T) {
+		for pct, want := range map[float64]float64{1: 0.99, 0.1: 0.999, 10: 0.9, 50: 0.5} {
+			cutoff, err := service.TopPercentileCutoff(pct)
+			require.NoError(t, err)
+			assert.Equal(t, want, cutoff, "pct %g", pct)
+		}
+	})
+
+	t.Run("Fail - Pct Outside (0, 100)", func(t *testing.T) {
+		for _, pct := range []float64{0, 100, -1, 101} {
+			_, err := service.TopPercentileCutoff(pct)
+			assert.Error(t, err, "pct %g", pct)
+		}
+	})
+}
+
+func TestTopPercentile(t *testing.T) {
+	t.Run("Success - Pct 1 Queries Percentile Above 0.99 Sorted Descending", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "0.99", r.URL.Query().Get("percentile-gt"))
+			assert.Equal(t, "!percentile", r.URL.Query().Get("order"))
+			assert.Equal(t, "2024-10-18", r.URL.Query().Get("date"))
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.9","percentile":"0.999","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		cves, err := service.TopPercentile(context.Background(), repository.NewAPIRepository(mockServer.URL), "2024-10-18", 1)
+
+		require.NoError(t, err)
+		require.Len(t, cves, 1)
+		assert.Equal(t, "CVE-2023-0001", cves[0].ID)
+	})
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// Portfolio maps the CVEs of a portfolio to their risk weights, such as the
+// number or criticality of the assets exposed to each.
+type Portfolio map[string]float64
+
+// IDs returns the CVE IDs of the portfolio in sorted order.
+func (p Portfolio) IDs() []string {
+	ids := make([]string, 0, len(p))
+	for id := range p {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// WeightedRisk returns the weighted mean EPSS score of the scored CVEs of the
+// portfolio, leaving the point's date unset. CVEs without a score, such as
+// ones not yet published on the queried date, are left out rather than
+// counted as zero.
+func WeightedRisk(portfolio Portfolio, scored []models.CVE) models.PortfolioPoint {
+	point := models.PortfolioPoint{CVEs: len(portfolio)}
+	var sum, weights float64
+	for _, cve := range scored {
+		w, ok := portfolio[cve.ID]
+		if !ok {
+			continue
+		}
+		point.Scored++
+		sum += w * cve.EPSSScore
+		weights += w
+	}
+	if weights > 0 {
+		point.Risk = sum / weights
+	}
+	return point
+}
+
+// PortfolioTrend returns the weighted risk of portfolio on each of dates,
+// fetching up to workers dates concurrently. Any failed request fails the
+// trend, as a partial point would misstate the portfolio's risk.
+func PortfolioTrend(ctx context.Context, repo ports.EPSSRepository, portfolio Portfolio, dates []string, workers int) ([]models.PortfolioPoint, error) {
+	ids := portfolio.IDs()
+	fetch := func(date string) ([]models.CVE, error) {
+		result := ScoreBatches(ctx, repo, ids, date, DefaultBatchSize)
+		if len(result.Failed) > 0 {
+			return nil, fmt.Errorf("failed to score %d CVE(s) for %s: %s", len(result.Failed), date, result.Failed[0].Error)
+		}
+		return result.Results, nil
+	}
+	points := make([]models.PortfolioPoint, 0, len(dates))
+	err := FetchDates(dates, workers, true, fetch, func(date string, cves []models.CVE) error {
+		point := WeightedRisk(portfolio, cves)
+		point.Date = date
+		points = append(points, point)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
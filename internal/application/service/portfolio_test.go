@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepDates(t *testing.T) {
+	t.Run("Success - Weekly Steps From Start", func(t *testing.T) {
+		dates, err := service.StepDates("2024-10-01", "2024-10-20", 7)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2024-10-01", "2024-10-08", "2024-10-15"}, dates)
+	})
+
+	t.Run("Fail - Step Below One Day", func(t *testing.T) {
+		_, err := service.StepDates("2024-10-01", "2024-10-20", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseStepDays(t *testing.T) {
+	t.Run("Success - Days And Durations", func(t *testing.T) {
+		for in, want := range map[string]int{"7d": 7, "1d": 1, "48h": 2} {
+			days, err := service.ParseStepDays(in)
+			require.NoError(t, err)
+			assert.Equal(t, want, days, in)
+		}
+	})
+
+	t.Run("Fail - Partial Or Invalid Steps", func(t *testing.T) {
+		for _, in := range []string{"0d", "1.5d", "36h", "week"} {
+			_, err := service.ParseStepDays(in)
+			assert.Error(t, err, in)
+		}
+	})
+}
+
+func TestWeightedRisk(t *testing.T) {
+	t.Run("Success - Weighted Mean Of Scored CVEs", func(t *testing.T) {
+		portfolio := service.Portfolio{"CVE-2023-0001": 3, "CVE-2023-0002": 1, "CVE-2023-0003": 5}
+		point := service.WeightedRisk(portfolio, []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.2},
+			{ID: "CVE-2023-0002", EPSSScore: 0.6},
+		})
+
+		assert.InDelta(t, 0.3, point.Risk, 1e-9)
+		assert.Equal(t, 2, point.Scored)
+		assert.Equal(t, 3, point.CVEs)
+	})
+}
+
+func TestPortfolioTrend(t *testing.T) {
+	// Each CVE's score on a date is its number times the day of the month / 100.
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		var day int
+		fmt.Sscanf(date[8:], "%d", &day)
+		var rows []string
+		for _, id := range strings.Split(r.URL.Query().Get("cve"), ",") {
+			var n int
+			fmt.Sscanf(id[len("CVE-2023-"):], "%d", &n)
+			rows = append(rows, fmt.Sprintf(`{"cve":%q,"epss":"%g","percentile":"0.5","date":%q}`, id, float64(n*day)/100, date))
+		}
+		fmt.Fprintf(w, `{"total":%d,"data":[%s]}`, len(rows), strings.Join(rows, ","))
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - One Point Per Step In Date Order", func(t *testing.T) {
+		portfolio := service.Portfolio{"CVE-2023-0001": 1, "CVE-2023-0002": 1}
+		dates, err := service.StepDates("2024-10-01", "2024-10-15", 7)
+		require.NoError(t, err)
+
+		points, err := service.PortfolioTrend(context.Background(), repository.NewAPIRepository(mockServer.URL), portfolio, dates, 3)
+
+		require.NoError(t, err)
+		require.Len(t, points, 3)
+		for i, want := range []struct {
+			date string
+			risk float64
+		}{{"2024-10-01", 0.015}, {"2024-10-08", 0.12}, {"2024-10-15", 0.225}} {
+			assert.Equal(t, want.date, points[i].Date)
+			assert.InDelta(t, want.risk, points[i].Risk, 1e-9)
+			assert.Equal(t, 2, points[i].Scored)
+		}
+	})
+
+	t.Run("Fail - Request Error", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer failing.Close()
+
+		_, err := service.PortfolioTrend(context.Background(), repository.NewAPIRepository(failing.URL), service.Portfolio{"CVE-2023-0001": 1}, []string{"2024-10-01"}, 1)
+		assert.Error(t, err)
+	})
+}
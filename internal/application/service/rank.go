@@ -0,0 +1,34 @@
+package service
+
+import (
+	"math"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// ApproximateRank returns the approximate position of a CVE with the given
+// percentile among total scored CVEs, where 1 is the highest score.
+//
+// The percentile is the fraction of CVEs scoring at or below the CVE, so
+// roughly (1 - percentile) * total CVEs score above it.
+func ApproximateRank(percentile float64, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	rank := int(math.Round((1-percentile)*float64(total))) + 1
+	if rank > total {
+		rank = total
+	}
+	return rank
+}
+
+// AnnotateRank sets Rank and Total on each CVE. It is a no-op when total is unknown.
+func AnnotateRank(cves []models.CVE, total int) {
+	if total <= 0 {
+		return
+	}
+	for i := range cves {
+		cves[i].Rank = ApproximateRank(cves[i].Percentile, total)
+		cves[i].Total = total
+	}
+}
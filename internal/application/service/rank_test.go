@@ -0,0 +1,39 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproximateRank(t *testing.T) {
+	t.Run("Success - Maps Percentile To Rank From The Top", func(t *testing.T) {
+		assert.Equal(t, 1, service.ApproximateRank(1.0, 250000))
+		assert.Equal(t, 1201, service.ApproximateRank(0.9952, 250000))
+		assert.Equal(t, 250000, service.ApproximateRank(0.0, 250000))
+	})
+
+	t.Run("Unknown Total - Returns Zero", func(t *testing.T) {
+		assert.Equal(t, 0, service.ApproximateRank(0.5, 0))
+	})
+}
+
+func TestAnnotateRank(t *testing.T) {
+	t.Run("Success - Sets Rank And Total", func(t *testing.T) {
+		cves := []models.CVE{{ID: "CVE-2023-0001", Percentile: 0.5}}
+		service.AnnotateRank(cves, 1000)
+
+		assert.Equal(t, 501, cves[0].Rank)
+		assert.Equal(t, 1000, cves[0].Total)
+	})
+
+	t.Run("Unknown Total - Leaves CVEs Untouched", func(t *testing.T) {
+		cves := []models.CVE{{ID: "CVE-2023-0001", Percentile: 0.5}}
+		service.AnnotateRank(cves, 0)
+
+		assert.Zero(t, cves[0].Rank)
+		assert.Zero(t, cves[0].Total)
+	})
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// percentileDecimals is the precision EPSS percentiles are published with.
+const percentileDecimals = 5
+
+// Band is an open percentile range (Min, Max), as GetCVEsInBand queries it;
+// a Min of 0 or a Max of 1 leaves that side unbounded.
+type Band struct {
+	Min float64
+	Max float64
+}
+
+// PercentileBands splits [0, 1] into n roughly equal-width bands. The API
+// only takes exclusive bounds, so adjacent bands share a cut placed half a unit
+// of the published precision below the first percentile at or above each
+// edge: every published percentile, including one exactly on an edge such as
+// 0.1, falls in exactly one band.
+func PercentileBands(n int) []Band {
+	bands := make([]Band, n)
+	for i := range bands {
+		bands[i].Max = 1
+		if i > 0 {
+			bands[i].Min = percentileCut(float64(i) / float64(n))
+			bands[i-1].Max = bands[i].Min
+		}
+	}
+	return bands
+}
+
+// percentileCut returns the point half a unit of the published precision below
+// the smallest published percentile at or above edge.
+func percentileCut(edge float64) float64 {
+	scale := math.Pow10(percentileDecimals)
+	above := math.Ceil(edge*scale - 1e-6)
+	return math.Round(above*10-5) / (scale * 10)
+}
+
+// SampleByPercentileBand returns up to perBand CVEs from each of n percentile
+// bands for date, lowest band first.
+//
+// The sample is approximate: band membership follows the percentiles the API
+// reports for the day, and each band yields whichever rows the API returns
+// first rather than a random draw.
+func SampleByPercentileBand(ctx context.Context, repo ports.EPSSRepository, date string, bands int, perBand int) ([]models.CVE, error) {
+	if bands <= 0 {
+		return nil, fmt.Errorf("number of bands must be positive, got %d", bands)
+	}
+	if perBand <= 0 {
+		return nil, fmt.Errorf("per-band sample size must be positive, got %d", perBand)
+	}
+
+	var sample []models.CVE
+	for _, band := range PercentileBands(bands) {
+		cves, err := repo.GetCVEsInBand(ctx, date, "percentile", band.Min, band.Max, perBand)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample percentile band %.2f-%.2f: %w", band.Min, band.Max, err)
+		}
+		if len(cves) > perBand {
+			cves = cves[:perBand]
+		}
+		sample = append(sample, cves...)
+	}
+	return sample, nil
+}
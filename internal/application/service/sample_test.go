@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentileBands(t *testing.T) {
+	t.Run("Success - Equal Width", func(t *testing.T) {
+		bands := service.PercentileBands(4)
+
+		assert.Equal(t, []service.Band{
+			{Min: 0, Max: 0.249995},
+			{Min: 0.249995, Max: 0.499995},
+			{Min: 0.499995, Max: 0.749995},
+			{Min: 0.749995, Max: 1},
+		}, bands)
+	})
+
+	t.Run("Success - Every Percentile In One Band", func(t *testing.T) {
+		for _, n := range []int{3, 10} {
+			bands := service.PercentileBands(n)
+			for i := 0; i <= 100000; i++ {
+				p := float64(i) / 100000
+				var in []int
+				for j, band := range bands {
+					if (band.Min <= 0 || p > band.Min) && (band.Max >= 1 || p < band.Max) {
+						in = append(in, j)
+					}
+				}
+				require.Len(t, in, 1, "percentile %v with %d bands", p, n)
+				if n == 10 && p == 0.1 {
+					assert.Equal(t, []int{1}, in)
+				}
+			}
+		}
+	})
+}
+
+func TestSampleByPercentileBand(t *testing.T) {
+	t.Run("Success - Queries Each Band", func(t *testing.T) {
+		var queries []string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			queries = append(queries, q.Get("percentile-gt")+".."+q.Get("percentile-lt"))
+			assert.Equal(t, "2024-10-18", q.Get("date"))
+			assert.Equal(t, "1", q.Get("limit"))
+			fmt.Fprintf(w, `{"data":[{"cve":"CVE-2023-%04d","epss":"0.1","percentile":"0.5","date":"2024-10-18"}]}`, len(queries))
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		sample, err := service.SampleByPercentileBand(context.Background(), repo, "2024-10-18", 4, 1)
+
+		require.NoError(t, err)
+		assert.Len(t, sample, 4)
+		assert.Equal(t, []string{"..0.249995", "0.249995..0.499995", "0.499995..0.749995", "0.749995.."}, queries)
+	})
+
+	t.Run("Fail - Invalid Sizes", func(t *testing.T) {
+		_, err := service.SampleByPercentileBand(context.Background(), nil, "2024-10-18", 0, 1)
+		assert.Error(t, err)
+		_, err = service.SampleByPercentileBand(context.Background(), nil, "2024-10-18", 10, 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// FindingCVEs returns the distinct CVE IDs among findings, in first-seen order.
+func FindingCVEs(findings []models.Finding) []string {
+	seen := make(map[string]bool, len(findings))
+	var ids []string
+	for _, f := range findings {
+		id := strings.ToUpper(f.CVE)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// EnrichFindings copies the EPSS score of each finding's CVE from scores and sorts
+// findings by score, highest first. Findings without a score sort last.
+func EnrichFindings(findings []models.Finding, scores []models.CVE) {
+	byID := make(map[string]models.CVE, len(scores))
+	for _, cve := range scores {
+		byID[strings.ToUpper(cve.ID)] = cve
+	}
+	for i := range findings {
+		cve, ok := byID[strings.ToUpper(findings[i].CVE)]
+		if !ok {
+			continue
+		}
+		epss, percentile := cve.EPSSScore, cve.Percentile
+		findings[i].EPSSScore = &epss
+		findings[i].Percentile = &percentile
+		findings[i].Date = cve.Date
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].EPSSScore, findings[j].EPSSScore
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a > *b
+	})
+}
+
+// AggregateByComponent groups enriched findings by package and version, counting
+// each CVE once per component. Components are ranked by their highest EPSS score,
+// then by CVE count; components without any score come last. A component is in
+// KEV when any of its CVEs is listed in catalog.
+func AggregateByComponent(findings []models.Finding, catalog ports.KEVCatalog) []models.ComponentSummary {
+	type key struct{ pkg, version string }
+	var order []key
+	summaries := make(map[key]*models.ComponentSummary)
+	seen := make(map[key]map[string]bool)
+	for _, f := range findings {
+		k := key{f.Package, f.Version}
+		summary, ok := summaries[k]
+		if !ok {
+			summary = &models.ComponentSummary{Package: f.Package, Version: f.Version}
+			summaries[k] = summary
+			seen[k] = make(map[string]bool)
+			order = append(order, k)
+		}
+		id := strings.ToUpper(f.CVE)
+		if seen[k][id] {
+			continue
+		}
+		seen[k][id] = true
+		summary.CVECount++
+		if f.EPSSScore != nil && (summary.MaxEPSS == nil || *f.EPSSScore > *summary.MaxEPSS) {
+			score := *f.EPSSScore
+			summary.MaxEPSS = &score
+			summary.TopCVE = id
+		}
+		if _, listed := catalog.Lookup(id); listed {
+			summary.InKEV = true
+		}
+	}
+
+	components := make([]models.ComponentSummary, len(order))
+	for i, k := range order {
+		components[i] = *summaries[k]
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		if (a.MaxEPSS == nil) != (b.MaxEPSS == nil) {
+			return a.MaxEPSS != nil
+		}
+		if a.MaxEPSS != nil && *a.MaxEPSS != *b.MaxEPSS {
+			return *a.MaxEPSS > *b.MaxEPSS
+		}
+		return a.CVECount > b.CVECount
+	})
+	return components
+}
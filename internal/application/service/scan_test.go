@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindingCVEs(t *testing.T) {
+	findings := []models.Finding{
+		{CVE: "CVE-2019-1549", Package: "libcrypto1.1"},
+		{CVE: "CVE-2021-23337", Package: "lodash"},
+		{CVE: "cve-2019-1549", Package: "libssl1.1"},
+	}
+
+	assert.Equal(t, []string{"CVE-2019-1549", "CVE-2021-23337"}, service.FindingCVEs(findings))
+}
+
+func TestEnrichFindings(t *testing.T) {
+	t.Run("Success - Scores And Sorts Findings", func(t *testing.T) {
+		findings := []models.Finding{
+			{CVE: "CVE-2023-9999", Package: "unscored"},
+			{CVE: "CVE-2019-1549", Package: "libcrypto1.1"},
+			{CVE: "CVE-2021-23337", Package: "lodash"},
+			{CVE: "CVE-2019-1549", Package: "libssl1.1"},
+		}
+		scores := []models.CVE{
+			{ID: "CVE-2019-1549", EPSSScore: 0.002, Percentile: 0.55, Date: "2024-10-18"},
+			{ID: "CVE-2021-23337", EPSSScore: 0.03, Percentile: 0.9, Date: "2024-10-18"},
+		}
+
+		service.EnrichFindings(findings, scores)
+
+		packages := make([]string, len(findings))
+		for i, f := range findings {
+			packages[i] = f.Package
+		}
+		assert.Equal(t, []string{"lodash", "libcrypto1.1", "libssl1.1", "unscored"}, packages)
+		require.NotNil(t, findings[0].EPSSScore)
+		assert.Equal(t, 0.03, *findings[0].EPSSScore)
+		assert.Equal(t, 0.9, *findings[0].Percentile)
+		assert.Equal(t, "2024-10-18", findings[1].Date)
+		assert.Nil(t, findings[3].EPSSScore)
+	})
+}
+
+func TestAggregateByComponent(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	findings := []models.Finding{
+		{CVE: "CVE-2021-23337", Package: "lodash", Version: "4.17.20", Artifact: "a/package-lock.json", EPSSScore: score(0.03)},
+		{CVE: "CVE-2021-23337", Package: "lodash", Version: "4.17.20", Artifact: "b/package-lock.json", EPSSScore: score(0.03)},
+		{CVE: "CVE-2020-8203", Package: "lodash", Version: "4.17.20", EPSSScore: score(0.01)},
+		{CVE: "CVE-2019-1549", Package: "libssl1.1", Version: "1.1.1c-r0", EPSSScore: score(0.002)},
+		{CVE: "CVE-2021-44228", Package: "log4j-core", Version: "2.14.1", EPSSScore: score(0.97)},
+		{CVE: "CVE-2099-0001", Package: "unscored", Version: "1.0"},
+		{CVE: "CVE-2019-1551", Package: "libssl1.1", Version: "1.1.1c-r0", EPSSScore: score(0.002)},
+	}
+	catalog := fakeCatalog{"CVE-2021-44228": {CVE: "CVE-2021-44228"}}
+
+	components := service.AggregateByComponent(findings, catalog)
+
+	require.Len(t, components, 4)
+	assert.Equal(t, models.ComponentSummary{Package: "log4j-core", Version: "2.14.1", CVECount: 1, MaxEPSS: score(0.97), TopCVE: "CVE-2021-44228", InKEV: true}, components[0])
+	assert.Equal(t, models.ComponentSummary{Package: "lodash", Version: "4.17.20", CVECount: 2, MaxEPSS: score(0.03), TopCVE: "CVE-2021-23337"}, components[1])
+	assert.Equal(t, "libssl1.1", components[2].Package)
+	assert.Equal(t, 2, components[2].CVECount)
+	assert.Equal(t, models.ComponentSummary{Package: "unscored", Version: "1.0", CVECount: 1}, components[3])
+}
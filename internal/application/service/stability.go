@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// DefaultStabilityEpsilon is the largest difference from the current EPSS
+// score that still counts as unchanged.
+const DefaultStabilityEpsilon = 0.0001
+
+// GetScoreStability fetches the time series of cveID and reports how long its
+// score has been stable within the last days days of data.
+func GetScoreStability(ctx context.Context, repo ports.EPSSRepository, cveID string, days int, epsilon float64) (*models.Stability, error) {
+	series, err := repo.GetTimeSeries(ctx, cveID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time series for %s: %w", cveID, err)
+	}
+	return ScoreStability(cveID, series, days, epsilon)
+}
+
+// ScoreStability walks series back from its latest point and reports the
+// days since the EPSS score was last more than epsilon away from its latest
+// value, looking at most days days back.
+func ScoreStability(cveID string, series []models.CVE, days int, epsilon float64) (*models.Stability, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("number of days must be positive, got %d", days)
+	}
+	if epsilon < 0 {
+		return nil, fmt.Errorf("epsilon must not be negative, got %g", epsilon)
+	}
+	points := make([]models.CVE, len(series))
+	copy(points, series)
+	SortByDate(points)
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no time series data for %s", cveID)
+	}
+
+	latest := points[len(points)-1]
+	end, err := time.Parse(dateLayout, latest.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date in time series: %w", err)
+	}
+	start := end.AddDate(0, 0, -(days - 1))
+
+	stability := &models.Stability{CVE: cveID, Current: latest.EPSSScore, Date: latest.Date, Since: latest.Date}
+	for i := len(points) - 2; i >= 0; i-- {
+		d, err := time.Parse(dateLayout, points[i].Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date in time series: %w", err)
+		}
+		if d.Before(start) {
+			break
+		}
+		if math.Abs(points[i].EPSSScore-latest.EPSSScore) > epsilon {
+			stability.Changed = true
+			break
+		}
+		stability.Since = points[i].Date
+	}
+
+	since, err := time.Parse(dateLayout, stability.Since)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date in time series: %w", err)
+	}
+	stability.StableDays = int(end.Sub(since).Hours() / 24)
+	return stability, nil
+}
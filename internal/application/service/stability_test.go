@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreStability(t *testing.T) {
+	// Flat at 0.1, then a jump to 0.3 on 2024-10-05 that holds, with noise
+	// below epsilon.
+	series := []models.CVE{
+		{Date: "2024-10-10", EPSSScore: 0.3},
+		{Date: "2024-10-01", EPSSScore: 0.1},
+		{Date: "2024-10-02", EPSSScore: 0.1},
+		{Date: "2024-10-03", EPSSScore: 0.1},
+		{Date: "2024-10-04", EPSSScore: 0.1},
+		{Date: "2024-10-05", EPSSScore: 0.3},
+		{Date: "2024-10-06", EPSSScore: 0.30004},
+		{Date: "2024-10-07", EPSSScore: 0.3},
+		{Date: "2024-10-08", EPSSScore: 0.29996},
+		{Date: "2024-10-09", EPSSScore: 0.3},
+	}
+
+	t.Run("Success - Stable Since Jump", func(t *testing.T) {
+		s, err := service.ScoreStability("CVE-2024-0001", series, 30, service.DefaultStabilityEpsilon)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.3, s.Current)
+		assert.Equal(t, "2024-10-10", s.Date)
+		assert.Equal(t, "2024-10-05", s.Since)
+		assert.Equal(t, 5, s.StableDays)
+		assert.True(t, s.Changed)
+	})
+
+	t.Run("Success - Smaller Epsilon Sees Noise", func(t *testing.T) {
+		s, err := service.ScoreStability("CVE-2024-0001", series, 30, 0.00001)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-10-09", s.Since)
+		assert.Equal(t, 1, s.StableDays)
+		assert.True(t, s.Changed)
+	})
+
+	t.Run("Success - Unchanged Within Window", func(t *testing.T) {
+		s, err := service.ScoreStability("CVE-2024-0001", series, 4, service.DefaultStabilityEpsilon)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-10-07", s.Since)
+		assert.Equal(t, 3, s.StableDays)
+		assert.False(t, s.Changed)
+	})
+
+	t.Run("Success - Flat Series", func(t *testing.T) {
+		flat := []models.CVE{
+			{Date: "2024-10-01", EPSSScore: 0.5},
+			{Date: "2024-10-02", EPSSScore: 0.5},
+			{Date: "2024-10-03", EPSSScore: 0.5},
+		}
+
+		s, err := service.ScoreStability("CVE-2024-0001", flat, 30, service.DefaultStabilityEpsilon)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-10-01", s.Since)
+		assert.Equal(t, 2, s.StableDays)
+		assert.False(t, s.Changed)
+	})
+
+	t.Run("Fail - Invalid Input", func(t *testing.T) {
+		_, err := service.ScoreStability("CVE-2024-0001", nil, 30, service.DefaultStabilityEpsilon)
+		assert.Error(t, err)
+		_, err = service.ScoreStability("CVE-2024-0001", series, 0, service.DefaultStabilityEpsilon)
+		assert.Error(t, err)
+		_, err = service.ScoreStability("CVE-2024-0001", series, 30, -1)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetScoreStability(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "time-series", r.URL.Query().Get("scope"))
+		fmt.Fprint(w, `{"data":[{"cve":"CVE-2024-0001","epss":"0.3","percentile":"0.9","date":"2024-10-03","time-series":[`+
+			`{"epss":"0.1","percentile":"0.5","date":"2024-10-01"},{"epss":"0.3","percentile":"0.9","date":"2024-10-02"}]}]}`)
+	}))
+	defer mockServer.Close()
+
+	s, err := service.GetScoreStability(context.Background(), repository.NewAPIRepository(mockServer.URL), "CVE-2024-0001", 30, service.DefaultStabilityEpsilon)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2024-10-02", s.Since)
+	assert.Equal(t, 1, s.StableDays)
+}
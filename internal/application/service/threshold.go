@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// CVEsAboveThreshold returns the CVEs whose field exceeds threshold, sorted by
+// order, then keeps those whose EPSS score is at least minScore.
+//
+// The API's -gt filters are exclusive, so a threshold of 0 drops CVEs scored
+// exactly 0. With includeZero, which requires a threshold of 0, the filter is
+// skipped and every CVE of the latest day is fetched, then sorted, instead.
+func CVEsAboveThreshold(ctx context.Context, repo ports.EPSSRepository, threshold float64, field string, order models.Ordering, includeZero bool, minScore float64) ([]models.CVE, error) {
+	var cves []models.CVE
+	var err error
+	if includeZero {
+		if threshold != 0 {
+			return nil, fmt.Errorf("including zero scores needs a threshold of 0, got %g", threshold)
+		}
+		if cves, err = repo.GetAllCVEsForDate(ctx, ""); err == nil {
+			sort.SliceStable(cves, func(i, j int) bool {
+				return order.Less(cves[i], cves[j])
+			})
+		}
+	} else {
+		cves, err = repo.GetCVEsAboveThresholdOrdered(ctx, threshold, field, order)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return FilterMinScore(cves, minScore), nil
+}
+
+// FilterMinScore keeps the CVEs whose EPSS score is at least min. Unlike the
+// API's filters, the bound is inclusive: a score of exactly min is kept.
+func FilterMinScore(cves []models.CVE, min float64) []models.CVE {
+	if min <= 0 {
+		return cves
+	}
+	kept := cves[:0:0]
+	for _, cve := range cves {
+		if cve.EPSSScore >= min {
+			kept = append(kept, cve)
+		}
+	}
+	return kept
+}
+
+// ThresholdPeaks returns every CVE whose field exceeded threshold on any of
+// dates, fetching up to workers dates concurrently. Each CVE appears once, as
+// the row of the day its EPSS score peaked, so its Date is the peak date.
+// Rows are sorted by peak score, highest first.
+func ThresholdPeaks(ctx context.Context, repo ports.EPSSRepository, dates []string, threshold float64, field string, workers int) ([]models.CVE, error) {
+	fetch := func(date string) ([]models.CVE, error) {
+		return repo.GetCVEsAboveThresholdForDate(ctx, date, threshold, field)
+	}
+	var all []models.CVE
+	// Days are merged in date order so that a score tied across several days
+	// peaks on the earliest of them.
+	err := FetchDates(dates, workers, true, fetch, func(date string, cves []models.CVE) error {
+		all = append(all, cves...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return PeakCVEs(all), nil
+}
+
+// PeakCVEs collapses rows from several days to the highest-scored row of each
+// CVE, keeping the earliest on ties, and sorts them by score, highest first,
+// then by ID.
+func PeakCVEs(rows []models.CVE) []models.CVE {
+	peaks := Dedupe(rows, KeepHighest)
+	sort.SliceStable(peaks, func(i, j int) bool {
+		if peaks[i].EPSSScore != peaks[j].EPSSScore {
+			return peaks[i].EPSSScore > peaks[j].EPSSScore
+		}
+		return peaks[i].ID < peaks[j].ID
+	})
+	return peaks
+}
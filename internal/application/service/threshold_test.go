@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMinScore(t *testing.T) {
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.0999}, {ID: "CVE-2023-0002", EPSSScore: 0.1}, {ID: "CVE-2023-0003", EPSSScore: 0.5}}
+
+	t.Run("Success - Score Exactly At The Floor Is Kept", func(t *testing.T) {
+		kept := service.FilterMinScore(cves, 0.1)
+
+		require.Len(t, kept, 2)
+		assert.Equal(t, "CVE-2023-0002", kept[0].ID)
+		assert.Len(t, cves, 3)
+	})
+
+	t.Run("Success - No Floor Keeps Everything", func(t *testing.T) {
+		assert.Len(t, service.FilterMinScore(cves, 0), 3)
+	})
+}
+
+func TestCVEsAboveThreshold(t *testing.T) {
+	var queries []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		rows := `{"cve":"CVE-2023-0002","epss":"0.1","percentile":"0.5","date":"2024-10-18"},{"cve":"CVE-2023-0003","epss":"0.5","percentile":"0.9","date":"2024-10-18"}`
+		if r.URL.Query().Get("epss-gt") == "" {
+			rows = `{"cve":"CVE-2023-0001","epss":"0","percentile":"0","date":"2024-10-18"},` + rows
+		}
+		fmt.Fprintf(w, `{"total":3,"data":[%s]}`, rows)
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - Min Score Floor Is Inclusive", func(t *testing.T) {
+		cves, err := service.CVEsAboveThreshold(context.Background(), repository.NewAPIRepository(mockServer.URL), 0.05, "epss", models.Unordered, false, 0.1)
+
+		require.NoError(t, err)
+		require.Len(t, cves, 2)
+		assert.Equal(t, "CVE-2023-0002", cves[0].ID)
+	})
+
+	t.Run("Success - Include Zero Skips The Exclusive API Filter", func(t *testing.T) {
+		queries = nil
+		cves, err := service.CVEsAboveThreshold(context.Background(), repository.NewAPIRepository(mockServer.URL), 0, "epss", models.Unordered, true, 0)
+
+		require.NoError(t, err)
+		require.Len(t, cves, 3)
+		assert.Equal(t, 0.0, cves[0].EPSSScore)
+		assert.NotContains(t, queries[0], "epss-gt")
+	})
+
+	t.Run("Success - Order Is Passed To The API", func(t *testing.T) {
+		queries = nil
+		_, err := service.CVEsAboveThreshold(context.Background(), repository.NewAPIRepository(mockServer.URL), 0.05, "epss", models.PercentileDesc, false, 0)
+
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], "order=%21percentile")
+	})
+
+	t.Run("Success - Include Zero Sorts The Whole Day", func(t *testing.T) {
+		cves, err := service.CVEsAboveThreshold(context.Background(), repository.NewAPIRepository(mockServer.URL), 0, "epss", models.EPSSDesc, true, 0)
+
+		require.NoError(t, err)
+		require.Len(t, cves, 3)
+		assert.Equal(t, []string{"CVE-2023-0003", "CVE-2023-0002", "CVE-2023-0001"}, []string{cves[0].ID, cves[1].ID, cves[2].ID})
+	})
+
+	t.Run("Fail - Include Zero With A Positive Threshold", func(t *testing.T) {
+		_, err := service.CVEsAboveThreshold(context.Background(), repository.NewAPIRepository(mockServer.URL), 0.1, "epss", models.Unordered, true, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestPeakCVEs(t *testing.T) {
+	t.Run("Success - Union Keeps Each CVE At Its Peak", func(t *testing.T) {
+		rows := []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.6, Date: "2024-10-01"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.55, Date: "2024-10-01"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.8, Date: "2024-10-02"},
+			{ID: "CVE-2023-0003", EPSSScore: 0.7, Date: "2024-10-02"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.7, Date: "2024-10-03"},
+		}
+
+		peaks := service.PeakCVEs(rows)
+
+		require.Len(t, peaks, 3)
+		assert.Equal(t, models.CVE{ID: "CVE-2023-0001", EPSSScore: 0.8, Date: "2024-10-02"}, peaks[0])
+		assert.Equal(t, "CVE-2023-0003", peaks[1].ID)
+		assert.Equal(t, "CVE-2023-0002", peaks[2].ID)
+	})
+
+	t.Run("Success - Tied Peak Keeps The Earliest Day", func(t *testing.T) {
+		peaks := service.PeakCVEs([]models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.6, Date: "2024-10-01"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.6, Date: "2024-10-02"},
+		})
+
+		require.Len(t, peaks, 1)
+		assert.Equal(t, "2024-10-01", peaks[0].Date)
+	})
+}
+
+func TestThresholdPeaks(t *testing.T) {
+	t.Run("Success - Queries Every Day And Merges Peaks", func(t *testing.T) {
+		days := map[string]string{
+			"2024-10-01": `{"cve":"CVE-2023-0001","epss":"0.6","percentile":"0.9","date":"2024-10-01"}`,
+			"2024-10-02": `{"cve":"CVE-2023-0001","epss":"0.9","percentile":"0.99","date":"2024-10-02"},{"cve":"CVE-2023-0002","epss":"0.51","percentile":"0.8","date":"2024-10-02"}`,
+			"2024-10-03": ``,
+		}
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "0.5", r.URL.Query().Get("epss-gt"))
+			rows, ok := days[r.URL.Query().Get("date")]
+			assert.True(t, ok, "unexpected date %s", r.URL.Query().Get("date"))
+			fmt.Fprintf(w, `{"data":[%s]}`, rows)
+		}))
+		defer mockServer.Close()
+
+		dates, err := service.WindowDates("2024-10-03", 3)
+		require.NoError(t, err)
+		peaks, err := service.ThresholdPeaks(context.Background(), repository.NewAPIRepository(mockServer.URL), dates, 0.5, "epss", 3)
+
+		require.NoError(t, err)
+		require.Len(t, peaks, 2)
+		assert.Equal(t, "CVE-2023-0001", peaks[0].ID)
+		assert.Equal(t, 0.9, peaks[0].EPSSScore)
+		assert.Equal(t, "2024-10-02", peaks[0].Date)
+		assert.Equal(t, "CVE-2023-0002", peaks[1].ID)
+	})
+
+	t.Run("Fail - Failed Day Fails The Query", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer mockServer.Close()
+
+		_, err := service.ThresholdPeaks(context.Background(), repository.NewAPIRepository(mockServer.URL), []string{"2024-10-01"}, 0.5, "epss", 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWindowDates(t *testing.T) {
+	t.Run("Success - Window Ends At End Date", func(t *testing.T) {
+		dates, err := service.WindowDates("2024-03-01", 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2024-02-28", "2024-02-29", "2024-03-01"}, dates)
+	})
+
+	t.Run("Fail - Empty Window", func(t *testing.T) {
+		_, err := service.WindowDates("2024-03-01", 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// SortByDate sorts cves by date, oldest first, keeping the order of rows with equal dates.
+func SortByDate(cves []models.CVE) {
+	sort.SliceStable(cves, func(i, j int) bool {
+		return cves[i].Date < cves[j].Date
+	})
+}
@@ -0,0 +1,19 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByDate(t *testing.T) {
+	t.Run("Success - Oldest First", func(t *testing.T) {
+		cves := []models.CVE{{Date: "2024-10-18"}, {Date: "2024-10-16"}, {Date: "2024-10-17"}}
+
+		service.SortByDate(cves)
+
+		assert.Equal(t, []models.CVE{{Date: "2024-10-16"}, {Date: "2024-10-17"}, {Date: "2024-10-18"}}, cves)
+	})
+}
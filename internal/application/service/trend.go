@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// StableSlope is the largest change in EPSS per day, in either direction,
+// that is still classified as stable.
+const StableSlope = 0.0001
+
+// GetScoreTrend fetches the time series of cveID and classifies its trend over
+// the last days days of data.
+func GetScoreTrend(ctx context.Context, repo ports.EPSSRepository, cveID string, days int) (*models.Trend, error) {
+	series, err := repo.GetTimeSeries(ctx, cveID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time series for %s: %w", cveID, err)
+	}
+	return ScoreTrend(cveID, series, days)
+}
+
+// ScoreTrend fits a least-squares line to the EPSS scores of series within
+// days days of its latest point. The slope is the change in EPSS per day.
+func ScoreTrend(cveID string, series []models.CVE, days int) (*models.Trend, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("number of days must be positive, got %d", days)
+	}
+	points := make([]models.CVE, len(series))
+	copy(points, series)
+	SortByDate(points)
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no time series data for %s", cveID)
+	}
+
+	end, err := time.Parse(dateLayout, points[len(points)-1].Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date in time series: %w", err)
+	}
+	start := end.AddDate(0, 0, -(days - 1))
+
+	var xs, ys []float64
+	first := ""
+	for _, p := range points {
+		d, err := time.Parse(dateLayout, p.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date in time series: %w", err)
+		}
+		if d.Before(start) {
+			continue
+		}
+		if first == "" {
+			first = p.Date
+		}
+		xs = append(xs, d.Sub(start).Hours()/24)
+		ys = append(ys, p.EPSSScore)
+	}
+	if len(xs) < 2 {
+		return nil, fmt.Errorf("need at least 2 data points within %d days for %s, got %d", days, cveID, len(xs))
+	}
+
+	slope := linearSlope(xs, ys)
+	direction := models.TrendStable
+	switch {
+	case slope > StableSlope:
+		direction = models.TrendRising
+	case slope < -StableSlope:
+		direction = models.TrendFalling
+	}
+	return &models.Trend{
+		CVE:       cveID,
+		Start:     first,
+		End:       points[len(points)-1].Date,
+		Points:    len(xs),
+		Slope:     slope,
+		Direction: direction,
+	}, nil
+}
+
+// linearSlope returns the least-squares slope of ys against xs.
+func linearSlope(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		cov += dx * (ys[i] - meanY)
+		varX += dx * dx
+	}
+	if varX == 0 {
+		return 0
+	}
+	return cov / varX
+}
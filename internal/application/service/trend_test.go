@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreTrend(t *testing.T) {
+	series := []models.CVE{
+		{Date: "2024-10-04", EPSSScore: 0.16},
+		{Date: "2024-10-01", EPSSScore: 0.10},
+		{Date: "2024-10-02", EPSSScore: 0.12},
+		{Date: "2024-10-03", EPSSScore: 0.14},
+	}
+
+	t.Run("Success - Rising", func(t *testing.T) {
+		trend, err := service.ScoreTrend("CVE-2024-0001", series, 30)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 0.02, trend.Slope, 1e-9)
+		assert.Equal(t, models.TrendRising, trend.Direction)
+		assert.Equal(t, "2024-10-01", trend.Start)
+		assert.Equal(t, "2024-10-04", trend.End)
+		assert.Equal(t, 4, trend.Points)
+	})
+
+	t.Run("Success - Falling Within Window", func(t *testing.T) {
+		falling := append([]models.CVE{}, series...)
+		falling = append(falling, models.CVE{Date: "2024-10-06", EPSSScore: 0.04})
+
+		trend, err := service.ScoreTrend("CVE-2024-0001", falling, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-10-04", trend.Start)
+		assert.Equal(t, 2, trend.Points)
+		assert.InDelta(t, -0.06, trend.Slope, 1e-9)
+		assert.Equal(t, models.TrendFalling, trend.Direction)
+	})
+
+	t.Run("Success - Stable", func(t *testing.T) {
+		flat := []models.CVE{
+			{Date: "2024-10-01", EPSSScore: 0.5},
+			{Date: "2024-10-02", EPSSScore: 0.50001},
+			{Date: "2024-10-03", EPSSScore: 0.5},
+		}
+
+		trend, err := service.ScoreTrend("CVE-2024-0001", flat, 30)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TrendStable, trend.Direction)
+	})
+
+	t.Run("Fail - Too Few Points", func(t *testing.T) {
+		_, err := service.ScoreTrend("CVE-2024-0001", series, 1)
+		assert.Error(t, err)
+		_, err = service.ScoreTrend("CVE-2024-0001", nil, 30)
+		assert.Error(t, err)
+		_, err = service.ScoreTrend("CVE-2024-0001", series, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetScoreTrend(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "time-series", r.URL.Query().Get("scope"))
+		fmt.Fprint(w, `{"data":[{"cve":"CVE-2024-0001","epss":"0.3","percentile":"0.9","date":"2024-10-03","time-series":[`+
+			`{"epss":"0.1","percentile":"0.5","date":"2024-10-01"},{"epss":"0.2","percentile":"0.7","date":"2024-10-02"}]}]}`)
+	}))
+	defer mockServer.Close()
+
+	trend, err := service.GetScoreTrend(context.Background(), repository.NewAPIRepository(mockServer.URL), "CVE-2024-0001", 30)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, trend.Points)
+	assert.InDelta(t, 0.1, trend.Slope, 1e-9)
+	assert.Equal(t, models.TrendRising, trend.Direction)
+}
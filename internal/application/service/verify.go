@@ -0,0 +1,78 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// Reconcile compares API and CSV rows for the CVEs in scope and returns every
+// discrepancy: CVEs missing from either source, and CVEs whose EPSS score or
+// percentile differs by more than tolerance. The report is sorted by CVE ID so
+// repeated runs produce identical, diffable output.
+func Reconcile(scope []string, api, csv []models.CVE, tolerance float64) []models.Discrepancy {
+	apiByID := indexCVEs(api)
+	csvByID := indexCVEs(csv)
+
+	seen := make(map[string]bool, len(scope))
+	var discrepancies []models.Discrepancy
+	for _, id := range scope {
+		id = strings.ToUpper(id)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		a, inAPI := apiByID[id]
+		c, inCSV := csvByID[id]
+		d := models.Discrepancy{
+			CVE:           id,
+			APIEPSS:       a.EPSSScore,
+			CSVEPSS:       c.EPSSScore,
+			APIPercentile: a.Percentile,
+			CSVPercentile: c.Percentile,
+		}
+		switch {
+		case !inAPI && !inCSV:
+			continue
+		case !inAPI:
+			d.Category = models.MissingInAPI
+		case !inCSV:
+			d.Category = models.MissingInCSV
+		case math.Abs(a.EPSSScore-c.EPSSScore) > tolerance || math.Abs(a.Percentile-c.Percentile) > tolerance:
+			d.Category = models.ScoreDiff
+		default:
+			continue
+		}
+		discrepancies = append(discrepancies, d)
+	}
+
+	sort.Slice(discrepancies, func(i, j int) bool {
+		return discrepancies[i].CVE < discrepancies[j].CVE
+	})
+	return discrepancies
+}
+
+// CountByCategory tallies discrepancies per category.
+func CountByCategory(discrepancies []models.Discrepancy) map[models.DiscrepancyCategory]int {
+	counts := map[models.DiscrepancyCategory]int{
+		models.MissingInAPI: 0,
+		models.MissingInCSV: 0,
+		models.ScoreDiff:    0,
+	}
+	for _, d := range discrepancies {
+		counts[d.Category]++
+	}
+	return counts
+}
+
+// indexCVEs maps upper-cased CVE IDs to their rows.
+func indexCVEs(cves []models.CVE) map[string]models.CVE {
+	byID := make(map[string]models.CVE, len(cves))
+	for _, cve := range cves {
+		byID[strings.ToUpper(cve.ID)] = cve
+	}
+	return byID
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	api := []models.CVE{
+		{ID: "CVE-2023-0005", EPSSScore: 0.50, Percentile: 0.90},
+		{ID: "CVE-2023-0001", EPSSScore: 0.10, Percentile: 0.40},
+		{ID: "CVE-2023-0003", EPSSScore: 0.30, Percentile: 0.70},
+		{ID: "CVE-2023-0004", EPSSScore: 0.40, Percentile: 0.80},
+	}
+	csv := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.10, Percentile: 0.40},
+		{ID: "CVE-2023-0002", EPSSScore: 0.20, Percentile: 0.60},
+		{ID: "CVE-2023-0003", EPSSScore: 0.30005, Percentile: 0.70},
+		{ID: "CVE-2023-0004", EPSSScore: 0.45, Percentile: 0.80},
+	}
+	scope := []string{"CVE-2023-0005", "CVE-2023-0004", "cve-2023-0003", "CVE-2023-0002", "CVE-2023-0001", "CVE-2023-0001"}
+
+	t.Run("Success - Sorted Report With Typed Categories", func(t *testing.T) {
+		report := service.Reconcile(scope, api, csv, 0.001)
+
+		assert.Equal(t, []models.Discrepancy{
+			{CVE: "CVE-2023-0002", Category: models.MissingInAPI, CSVEPSS: 0.20, CSVPercentile: 0.60},
+			{CVE: "CVE-2023-0004", Category: models.ScoreDiff, APIEPSS: 0.40, CSVEPSS: 0.45, APIPercentile: 0.80, CSVPercentile: 0.80},
+			{CVE: "CVE-2023-0005", Category: models.MissingInCSV, APIEPSS: 0.50, APIPercentile: 0.90},
+		}, report)
+	})
+
+	t.Run("Success - Zero Tolerance Flags Small Differences", func(t *testing.T) {
+		report := service.Reconcile(scope, api, csv, 0)
+
+		assert.Len(t, report, 4)
+		assert.Equal(t, "CVE-2023-0003", report[1].CVE)
+	})
+
+	t.Run("Success - Counts Every Category", func(t *testing.T) {
+		counts := service.CountByCategory(service.Reconcile(scope, api, csv, 0.001))
+
+		assert.Equal(t, map[models.DiscrepancyCategory]int{
+			models.MissingInAPI: 1,
+			models.MissingInCSV: 1,
+			models.ScoreDiff:    1,
+		}, counts)
+	})
+}
@@ -0,0 +1,71 @@
+// Package apierr defines the typed errors returned when talking to an EPSS data source.
+package apierr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// StatusError reports an unexpected HTTP status code from the EPSS API.
+type StatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d from %s", e.StatusCode, e.URL)
+}
+
+// EnvelopeError reports a failure the EPSS API signalled in the status fields
+// of a response body sent with HTTP 200, such as an invalid parameter.
+type EnvelopeError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *EnvelopeError) Error() string {
+	msg := fmt.Sprintf("API reported status %d", e.StatusCode)
+	if e.Status != "" {
+		msg += " (" + e.Status + ")"
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// ErrRequestTimeout is returned when a single request attempt exceeds its
+// timeout, as opposed to the caller's context being cancelled or expiring.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// IsRetryable reports whether err is a transient failure worth retrying:
+// a 5xx or 429 response, a timeout, or a connection reset. It unwraps err,
+// so errors returned through several layers are classified correctly.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrRequestTimeout) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET)
+}
+
+// ErrCacheMiss is returned by cache-only sources when no fresh cached response exists.
+var ErrCacheMiss = errors.New("no fresh cached response")
@@ -0,0 +1,51 @@
+package apierr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/stretchr/testify/assert"
+)
+
+// timeoutError is a net.Error that reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Nil Error", nil, false},
+		{"Server Error", &apierr.StatusError{StatusCode: 500}, true},
+		{"Bad Gateway", &apierr.StatusError{StatusCode: 502}, true},
+		{"Service Unavailable", &apierr.StatusError{StatusCode: 503}, true},
+		{"Too Many Requests", &apierr.StatusError{StatusCode: 429}, true},
+		{"Bad Request", &apierr.StatusError{StatusCode: 400}, false},
+		{"Not Found", &apierr.StatusError{StatusCode: 404}, false},
+		{"Wrapped Server Error", fmt.Errorf("failed to get CVE score: %w", &apierr.StatusError{StatusCode: 503}), true},
+		{"Network Timeout", &net.OpError{Op: "read", Err: timeoutError{}}, true},
+		{"Context Deadline", fmt.Errorf("fetch: %w", context.DeadlineExceeded), true},
+		{"Request Timeout", fmt.Errorf("fetch: %w after 2m0s", apierr.ErrRequestTimeout), true},
+		{"Context Canceled", fmt.Errorf("fetch: %w", context.Canceled), false},
+		{"Connection Reset", &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"Connection Refused", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, false},
+		{"Plain Error", errors.New("failed to unmarshal JSON response"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apierr.IsRetryable(tt.err))
+		})
+	}
+}
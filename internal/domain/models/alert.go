@@ -0,0 +1,8 @@
+package models
+
+// Alert is a notification raised by a monitoring command.
+type Alert struct {
+	CVE     string `json:"cve"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
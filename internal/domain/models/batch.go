@@ -0,0 +1,23 @@
+package models
+
+// BatchFailure records CVEs whose batch request failed, so a later run can
+// retry just those.
+type BatchFailure struct {
+	CVE   string `json:"cve"`
+	Error string `json:"error"`
+}
+
+// BatchResult holds the scores of a batch run that continued past failed
+// batches, together with the CVEs that could not be scored.
+type BatchResult struct {
+	Results []CVE          `json:"results"`
+	Failed  []BatchFailure `json:"failed"`
+}
+
+// CWEResult holds the scores of the CVEs mapped to a CWE, together with the
+// CVEs whose batch request failed.
+type CWEResult struct {
+	CWE    string         `json:"cwe"`
+	CVEs   []CVE          `json:"cves"`
+	Failed []BatchFailure `json:"failed"`
+}
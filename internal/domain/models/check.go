@@ -0,0 +1,8 @@
+package models
+
+// Check is the outcome of one environment diagnostic.
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
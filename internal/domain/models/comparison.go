@@ -0,0 +1,18 @@
+package models
+
+// DatedScore is the score of a CVE on one of several compared dates. EPSS and
+// Percentile are nil when there is no data for the date. The deltas are
+// relative to the previous date with data, and nil when there is none.
+type DatedScore struct {
+	Date            string   `json:"date"`
+	EPSS            *float64 `json:"epss"`
+	Percentile      *float64 `json:"percentile"`
+	EPSSDelta       *float64 `json:"epss_delta,omitempty"`
+	PercentileDelta *float64 `json:"percentile_delta,omitempty"`
+}
+
+// ScoreComparison lists the scores of a CVE on chosen dates, oldest first.
+type ScoreComparison struct {
+	CVE    string       `json:"cve"`
+	Scores []DatedScore `json:"scores"`
+}
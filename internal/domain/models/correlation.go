@@ -0,0 +1,24 @@
+package models
+
+// ScorePair holds the EPSS and CVSS base scores of one CVE.
+type ScorePair struct {
+	CVE  string  `json:"cve"`
+	EPSS float64 `json:"epss"`
+	CVSS float64 `json:"cvss"`
+}
+
+// ScatterCell counts the CVEs falling in one CVSS severity and EPSS risk level.
+type ScatterCell struct {
+	Severity  string `json:"cvss_severity"`
+	RiskLevel string `json:"epss_risk_level"`
+	Count     int    `json:"count"`
+}
+
+// Correlation summarizes how well EPSS and CVSS scores agree for a set of CVEs.
+type Correlation struct {
+	Count   int           `json:"count"`
+	Pearson float64       `json:"pearson"`
+	Scatter []ScatterCell `json:"scatter"`
+	// Missing lists the CVEs left out because they lack an EPSS or CVSS score.
+	Missing []string `json:"missing"`
+}
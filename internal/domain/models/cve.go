@@ -3,14 +3,58 @@ package models
 import "time"
 
 type CVE struct {
-	ID         string
-	EPSSScore  float64
-	Percentile float64
-	Date       string
+	ID         string  `json:"cve"`
+	EPSSScore  float64 `json:"epss"`
+	Percentile float64 `json:"percentile"`
+	Date       string  `json:"date"`
+
+	// RawEPSS and RawPercentile are the scores exactly as the API sent them,
+	// for byte-for-byte reconciliation. They are only set when requested.
+	RawEPSS       string `json:"raw_epss,omitempty"`
+	RawPercentile string `json:"raw_percentile,omitempty"`
+
+	// Rank is the approximate position of the CVE among all scored CVEs for
+	// the day (1 is the highest score). It is only set when Total is known.
+	Rank  int `json:"rank,omitempty"`
+	Total int `json:"total,omitempty"`
+
+	// Normalized is the experimental model-agnostic position derived from the
+	// percentile. It is only set when normalization was requested.
+	Normalized *float64 `json:"normalized,omitempty"`
+
+	// Source names the source of a fallback chain (cache, api or csv) that
+	// answered the query. It is only set when the score came from a chain.
+	Source string `json:"source,omitempty"`
+
+	// AgeDays is the number of days between Date and the day of the query,
+	// and Stale reports whether it exceeds the staleness threshold. Both are
+	// only set when freshness was requested.
+	AgeDays *int  `json:"age_days,omitempty"`
+	Stale   *bool `json:"stale,omitempty"`
 }
 
 type ScoreChange struct {
-	CVE         string
-	Date        time.Time
-	ScoreChange float64
+	CVE              string    `json:"cve"`
+	Date             time.Time `json:"date"`
+	ScoreChange      float64   `json:"score_change"`
+	PercentileChange float64   `json:"percentile_change,omitempty"`
+}
+
+// CVEDelta is a CVE whose scores differ between two saved reports.
+type CVEDelta struct {
+	CVE             string  `json:"cve"`
+	OldEPSS         float64 `json:"old_epss"`
+	NewEPSS         float64 `json:"new_epss"`
+	EPSSDelta       float64 `json:"epss_delta"`
+	OldPercentile   float64 `json:"old_percentile"`
+	NewPercentile   float64 `json:"new_percentile"`
+	PercentileDelta float64 `json:"percentile_delta"`
+}
+
+// ReportDiff lists the CVEs added to, removed from and changed between two
+// saved reports.
+type ReportDiff struct {
+	Added   []CVE      `json:"added"`
+	Removed []CVE      `json:"removed"`
+	Changed []CVEDelta `json:"changed"`
 }
@@ -0,0 +1,7 @@
+package models
+
+// DataRange is the span of dates for which EPSS data is available.
+type DataRange struct {
+	Earliest string `json:"earliest"`
+	Latest   string `json:"latest"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// DiscrepancyCategory classifies a difference between two data sources.
+type DiscrepancyCategory string
+
+const (
+	MissingInAPI DiscrepancyCategory = "missing-in-api"
+	MissingInCSV DiscrepancyCategory = "missing-in-csv"
+	ScoreDiff    DiscrepancyCategory = "score-diff"
+)
+
+// Discrepancy is a CVE whose data differs between the API and the CSV dataset.
+// Scores from a source missing the CVE are left at zero.
+type Discrepancy struct {
+	CVE           string              `json:"cve"`
+	Category      DiscrepancyCategory `json:"category"`
+	APIEPSS       float64             `json:"api_epss"`
+	CSVEPSS       float64             `json:"csv_epss"`
+	APIPercentile float64             `json:"api_percentile"`
+	CSVPercentile float64             `json:"csv_percentile"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Finding is a CVE reported by a vulnerability scanner against an installed package.
+// EPSSScore and Percentile are nil until the finding is enriched, and stay nil
+// when the CVE has no EPSS score.
+type Finding struct {
+	CVE        string   `json:"cve"`
+	Package    string   `json:"package"`
+	Version    string   `json:"version,omitempty"`
+	Artifact   string   `json:"artifact,omitempty"`
+	EPSSScore  *float64 `json:"epss"`
+	Percentile *float64 `json:"percentile"`
+	Date       string   `json:"date,omitempty"`
+}
+
+// ComponentSummary aggregates the findings reported against one package version.
+type ComponentSummary struct {
+	Package  string `json:"package"`
+	Version  string `json:"version,omitempty"`
+	CVECount int    `json:"cve_count"`
+	// MaxEPSS is the highest EPSS score among the component's CVEs, and TopCVE
+	// the CVE holding it. MaxEPSS is nil when none of the CVEs has a score.
+	MaxEPSS *float64 `json:"max_epss"`
+	TopCVE  string   `json:"top_cve,omitempty"`
+	InKEV   bool     `json:"in_kev"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// GateFailure is a CVE that breaks a release gate, with the reasons it fails.
+type GateFailure struct {
+	CVE     string   `json:"cve"`
+	Reasons []string `json:"reasons"`
+}
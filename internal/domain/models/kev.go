@@ -0,0 +1,10 @@
+package models
+
+// KEVEntry is a vulnerability listed in the CISA Known Exploited Vulnerabilities catalog.
+type KEVEntry struct {
+	CVE               string `json:"cve"`
+	VendorProject     string `json:"vendor_project"`
+	Product           string `json:"product"`
+	VulnerabilityName string `json:"vulnerability_name"`
+	DateAdded         string `json:"date_added"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// Ordering is the order in which a query returns CVEs.
+type Ordering int
+
+const (
+	// Unordered leaves CVEs in the order the source returns them.
+	Unordered Ordering = iota
+	EPSSAsc
+	EPSSDesc
+	PercentileAsc
+	PercentileDesc
+)
+
+// Less reports whether a sorts before b under o. Under Unordered no CVE sorts
+// before another, so a stable sort keeps the source's order.
+func (o Ordering) Less(a, b CVE) bool {
+	switch o {
+	case EPSSAsc:
+		return a.EPSSScore < b.EPSSScore
+	case EPSSDesc:
+		return a.EPSSScore > b.EPSSScore
+	case PercentileAsc:
+		return a.Percentile < b.Percentile
+	case PercentileDesc:
+		return a.Percentile > b.Percentile
+	default:
+		return false
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+// YearSummary aggregates the CVEs disclosed in a single year.
+type YearSummary struct {
+	Year     int     `json:"year"`
+	Count    int     `json:"count"`
+	MeanEPSS float64 `json:"mean_epss"`
+}
+
+// CohortStats summarizes the EPSS scores of the CVEs disclosed in one year.
+type CohortStats struct {
+	Year       int     `json:"year"`
+	Count      int     `json:"count"`
+	MeanEPSS   float64 `json:"mean_epss"`
+	MedianEPSS float64 `json:"median_epss"`
+	MaxEPSS    float64 `json:"max_epss"`
+}
+
+// ThresholdCount is the number of CVEs whose score exceeds a threshold.
+type ThresholdCount struct {
+	Threshold float64 `json:"threshold"`
+	Count     int     `json:"count"`
+}
+
+// SetStats summarizes the EPSS scores and KEV listings of a named CVE set.
+// Score statistics cover the Scored CVEs; KEV counts every CVE in the set.
+type SetStats struct {
+	Name       string           `json:"name"`
+	CVEs       int              `json:"cves"`
+	Scored     int              `json:"scored"`
+	MeanEPSS   float64          `json:"mean_epss"`
+	MedianEPSS float64          `json:"median_epss"`
+	MaxEPSS    float64          `json:"max_epss"`
+	Above      []ThresholdCount `json:"above"`
+	KEV        int              `json:"kev"`
+}
+
+// CohortComparison puts the statistics of two CVE sets side by side.
+type CohortComparison struct {
+	Date string   `json:"date,omitempty"`
+	A    SetStats `json:"set_a"`
+	B    SetStats `json:"set_b"`
+}
+
+// PortfolioPoint is the weighted risk of a CVE portfolio on one date: the
+// weighted mean EPSS score of the Scored CVEs, out of CVEs in the portfolio.
+type PortfolioPoint struct {
+	Date   string  `json:"date"`
+	Risk   float64 `json:"weighted_risk"`
+	Scored int     `json:"scored"`
+	CVEs   int     `json:"cves"`
+}
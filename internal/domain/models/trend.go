@@ -0,0 +1,32 @@
+package models
+
+// Trend direction classifications.
+const (
+	TrendRising  = "rising"
+	TrendFalling = "falling"
+	TrendStable  = "stable"
+)
+
+// Trend summarizes the direction of a CVE's EPSS score over a window.
+type Trend struct {
+	CVE       string  `json:"cve"`
+	Start     string  `json:"start"`
+	End       string  `json:"end"`
+	Points    int     `json:"points"`
+	Slope     float64 `json:"slope"`
+	Direction string  `json:"direction"`
+}
+
+// Stability reports how long a CVE's EPSS score has held its current value.
+type Stability struct {
+	CVE     string  `json:"cve"`
+	Current float64 `json:"current"`
+	Date    string  `json:"date"`
+	// Since is the first date from which the score stayed within epsilon of
+	// Current, and StableDays the number of days from Since to Date.
+	Since      string `json:"since"`
+	StableDays int    `json:"stable_days"`
+	// Changed is false when the score never left epsilon of Current within the
+	// window, so it may have been stable for longer than StableDays.
+	Changed bool `json:"changed"`
+}
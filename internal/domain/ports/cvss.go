@@ -0,0 +1,8 @@
+package ports
+
+import "context"
+
+// CVSSSource looks up CVSS base scores. ok is false when the CVE has no score.
+type CVSSSource interface {
+	BaseScore(ctx context.Context, cveID string) (score float64, ok bool, err error)
+}
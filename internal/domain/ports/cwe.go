@@ -0,0 +1,8 @@
+package ports
+
+import "context"
+
+// CWEMapper lists the CVEs classified under a CWE, such as "CWE-79".
+type CWEMapper interface {
+	CVEsForCWE(ctx context.Context, cwe string) ([]string, error)
+}
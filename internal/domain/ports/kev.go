@@ -0,0 +1,9 @@
+package ports
+
+import (
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+type KEVCatalog interface {
+	Lookup(cveID string) (models.KEVEntry, bool)
+}
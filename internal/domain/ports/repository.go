@@ -1,14 +1,42 @@
 package ports
 
 import (
+	"context"
+
 	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
 )
 
+type ScoreSource interface {
+	GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error)
+}
+
 type EPSSRepository interface {
-	GetCVEScore(cveID string, date string) (*models.CVE, error)
-	GetTopNCVEs(n int) ([]models.CVE, error)
-	GetHighestIncreases(days int, limit int) ([]models.ScoreChange, error)
-	GetCVEsForDate(date string) ([]models.CVE, error)
-	GetTimeSeries(cveID string) ([]models.CVE, error)
-	GetCVEsAboveThreshold(threshold float64, field string) ([]models.CVE, error)
+	GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error)
+	GetCVEScores(ctx context.Context, cveIDs []string, date string) ([]models.CVE, error)
+	GetTopNCVEs(ctx context.Context, n int) ([]models.CVE, error)
+	GetHighestIncreases(ctx context.Context, days int, limit int) ([]models.ScoreChange, error)
+	GetCVEsForDate(ctx context.Context, date string) ([]models.CVE, error)
+	GetCVEsForDateOrdered(ctx context.Context, date string, order models.Ordering) ([]models.CVE, error)
+	GetCVEsForDatePaged(ctx context.Context, date string, maxResults int) ([]models.CVE, error)
+	GetAllCVEsForDate(ctx context.Context, date string) ([]models.CVE, error)
+	GetTimeSeries(ctx context.Context, cveID string) ([]models.CVE, error)
+	GetCVEsAboveThreshold(ctx context.Context, threshold float64, field string) ([]models.CVE, error)
+	GetCVEsAboveThresholdOrdered(ctx context.Context, threshold float64, field string, order models.Ordering) ([]models.CVE, error)
+	GetCVEsAboveThresholdForDate(ctx context.Context, date string, threshold float64, field string) ([]models.CVE, error)
+	GetCVEsInRange(ctx context.Context, date string, field string, min, max float64) ([]models.CVE, error)
+	GetCVEsInBand(ctx context.Context, date string, field string, min, max float64, limit int) ([]models.CVE, error)
+	GetCVEsAbovePercentile(ctx context.Context, date string, min float64) ([]models.CVE, error)
+	GetTotalCVEs(ctx context.Context, date string) (int, error)
+}
+
+// CVEPager fetches a day's CVEs one page at a time. more is false once the
+// page returned is the last one.
+type CVEPager interface {
+	GetCVEPage(ctx context.Context, date string, offset int) (page []models.CVE, more bool, err error)
+}
+
+// Counter reports how many records a query matches without downloading them.
+// params are API query parameters, such as date, epss-gt or q.
+type Counter interface {
+	Count(ctx context.Context, params map[string]string) (int, error)
 }
@@ -1,11 +1,15 @@
 package ports
 
 import (
+	"context"
+	"time"
+
 	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
 )
 
 type EPSSService interface {
-    GetCVEScore(cveID string, date string) (*models.CVE, error)
-    GetTopNCVEs(n int) ([]models.CVE, error)
-    GetHighestIncreases(days int, limit int) ([]models.ScoreChange, error)
+    GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error)
+    GetTopNCVEs(ctx context.Context, n int) ([]models.CVE, error)
+    GetHighestIncreases(ctx context.Context, days int, limit int) ([]models.ScoreChange, error)
+    GetScoresInRange(ctx context.Context, cveID string, start, end time.Time) ([]models.CVE, error)
 }
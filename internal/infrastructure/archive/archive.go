@@ -0,0 +1,220 @@
+// Package archive downloads First.org's daily EPSS CSV datasets into a local
+// directory, building the archive read by the offline CSV source.
+package archive
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+)
+
+// DefaultInterval is the pause between downloads, keeping bulk fetches polite.
+const DefaultInterval = time.Second
+
+// Status is the outcome of fetching one day's dataset.
+type Status string
+
+const (
+	// Downloaded means the dataset was fetched and verified.
+	Downloaded Status = "downloaded"
+	// Skipped means a valid copy was already in the archive.
+	Skipped Status = "skipped"
+	// Missing means the mirror has no dataset for the date.
+	Missing Status = "missing"
+	// Failed means the download or its verification failed.
+	Failed Status = "failed"
+)
+
+// Result describes the outcome of fetching one day's dataset.
+type Result struct {
+	Date   string
+	Status Status
+	Bytes  int64
+	Err    error
+}
+
+// Summary counts the outcomes of a bulk fetch.
+type Summary struct {
+	Downloaded int
+	Skipped    int
+	Missing    int
+	Failed     int
+	Bytes      int64
+}
+
+// Downloader fetches daily datasets from a mirror into a directory. Files are
+// written to a .part file and only renamed into place once verified, so an
+// interrupted run leaves no file that looks complete and can simply be rerun.
+type Downloader struct {
+	mirrorURL string
+	dir       string
+	client    *http.Client
+	interval  time.Duration
+	sleep     func(time.Duration)
+}
+
+// Option configures a Downloader.
+type Option func(*Downloader)
+
+// WithHTTPClient sets the HTTP client used for downloads.
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Downloader) {
+		d.client = client
+	}
+}
+
+// WithInterval sets the pause between downloads. Skipped files do not wait.
+func WithInterval(interval time.Duration) Option {
+	return func(d *Downloader) {
+		d.interval = interval
+	}
+}
+
+// WithSleep replaces time.Sleep, letting tests observe the rate limit.
+func WithSleep(sleep func(time.Duration)) Option {
+	return func(d *Downloader) {
+		d.sleep = sleep
+	}
+}
+
+// NewDownloader creates a Downloader reading from mirrorURL and writing to dir.
+func NewDownloader(mirrorURL, dir string, opts ...Option) *Downloader {
+	d := &Downloader{
+		mirrorURL: strings.TrimRight(mirrorURL, "/"),
+		dir:       dir,
+		client:    http.DefaultClient,
+		interval:  DefaultInterval,
+		sleep:     time.Sleep,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// FetchAll fetches the dataset for each date in turn, calling progress after
+// each one, and returns the totals. A failed date does not stop the run.
+func (d *Downloader) FetchAll(dates []string, progress func(done, total int, r Result)) (Summary, error) {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return Summary{}, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	var summary Summary
+	downloaded := false
+	for i, date := range dates {
+		path := filepath.Join(d.dir, repository.CSVFileName(date))
+		var r Result
+		if size, err := verifyFile(path); err == nil {
+			r = Result{Date: date, Status: Skipped, Bytes: size}
+		} else {
+			if downloaded && d.interval > 0 {
+				d.sleep(d.interval)
+			}
+			downloaded = true
+			r = d.fetch(date, path)
+		}
+
+		switch r.Status {
+		case Downloaded:
+			summary.Downloaded++
+			summary.Bytes += r.Bytes
+		case Skipped:
+			summary.Skipped++
+		case Missing:
+			summary.Missing++
+		case Failed:
+			summary.Failed++
+		}
+		if progress != nil {
+			progress(i+1, len(dates), r)
+		}
+	}
+	return summary, nil
+}
+
+// fetch downloads the dataset for date to path, verifying it before it is
+// renamed into place.
+func (d *Downloader) fetch(date, path string) Result {
+	url := fmt.Sprintf("%s/%s", d.mirrorURL, repository.CSVFileName(date))
+	size, err := d.download(url, path)
+	var statusErr *apierr.StatusError
+	switch {
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		return Result{Date: date, Status: Missing, Err: err}
+	case err != nil:
+		return Result{Date: date, Status: Failed, Err: err}
+	}
+	return Result{Date: date, Status: Downloaded, Bytes: size}
+}
+
+// download writes url to path via a temporary .part file and returns its size.
+func (d *Downloader) download(url, path string) (int64, error) {
+	resp, err := d.client.Get(url)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, &apierr.StatusError{StatusCode: resp.StatusCode, URL: url}
+	}
+
+	part := path + ".part"
+	f, err := os.Create(part)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", part, err)
+	}
+	size, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && resp.ContentLength >= 0 && size != resp.ContentLength {
+		err = fmt.Errorf("size mismatch: got %d bytes, expected %d", size, resp.ContentLength)
+	}
+	if err == nil {
+		_, err = verifyFile(part)
+	}
+	if err != nil {
+		os.Remove(part)
+		return 0, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if err := os.Rename(part, path); err != nil {
+		os.Remove(part)
+		return 0, fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return size, nil
+}
+
+// verifyFile checks that path is a complete, non-empty gzip stream and returns its size.
+func verifyFile(path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gzip file %s: %w", path, err)
+	}
+	n, err := io.Copy(io.Discard, zr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gzip file %s: %w", path, err)
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("empty dataset %s", path)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
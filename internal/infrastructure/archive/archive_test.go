@@ -0,0 +1,91 @@
+package archive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/archive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDownloaderFetchAll(t *testing.T) {
+	dataset := gzipped(t, "cve,epss,percentile\nCVE-2024-0001,0.1,0.5\n")
+
+	newServer := func(requests *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.URL.Path)
+			switch {
+			case strings.Contains(r.URL.Path, "2024-10-02"):
+				http.NotFound(w, r)
+			case strings.Contains(r.URL.Path, "2024-10-03"):
+				w.Write(dataset[:len(dataset)/2])
+			default:
+				w.Write(dataset)
+			}
+		}))
+	}
+
+	t.Run("Success - Downloads, Verifies And Rate Limits", func(t *testing.T) {
+		var requests []string
+		server := newServer(&requests)
+		defer server.Close()
+		dir := t.TempDir()
+		var sleeps []time.Duration
+
+		d := archive.NewDownloader(server.URL, dir, archive.WithInterval(2*time.Second),
+			archive.WithSleep(func(d time.Duration) { sleeps = append(sleeps, d) }))
+		var statuses []archive.Status
+		summary, err := d.FetchAll([]string{"2024-10-01", "2024-10-02", "2024-10-03"}, func(done, total int, r archive.Result) {
+			assert.Equal(t, 3, total)
+			statuses = append(statuses, r.Status)
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []archive.Status{archive.Downloaded, archive.Missing, archive.Failed}, statuses)
+		assert.Equal(t, archive.Summary{Downloaded: 1, Missing: 1, Failed: 1, Bytes: int64(len(dataset))}, summary)
+		assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, sleeps)
+
+		assert.FileExists(t, filepath.Join(dir, "epss_scores-2024-10-01.csv.gz"))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "failed downloads must not leave files behind")
+	})
+
+	t.Run("Success - Resumes Skipping Valid Files", func(t *testing.T) {
+		var requests []string
+		server := newServer(&requests)
+		defer server.Close()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "epss_scores-2024-10-01.csv.gz"), dataset, 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "epss_scores-2024-10-04.csv.gz"), []byte("not gzip"), 0o644))
+
+		d := archive.NewDownloader(server.URL, dir, archive.WithSleep(func(time.Duration) {}))
+		summary, err := d.FetchAll([]string{"2024-10-01", "2024-10-04"}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Skipped)
+		assert.Equal(t, 1, summary.Downloaded)
+		assert.Equal(t, []string{"/epss_scores-2024-10-04.csv.gz"}, requests)
+
+		data, err := os.ReadFile(filepath.Join(dir, "epss_scores-2024-10-04.csv.gz"))
+		require.NoError(t, err)
+		assert.Equal(t, dataset, data, "corrupt files are downloaded again")
+	})
+}
@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// dateLayout is the date format used by the EPSS API.
+const dateLayout = "2006-01-02"
+
+// FileCache stores API responses on disk, one file per request key. Raw
+// response bodies are stored as .json files; decoded CVE lists are stored as
+// .gob files, which reload much faster for large days.
+//
+// Entries for past dates never expire because published EPSS data for a
+// given day does not change. Entries for the current day (or with no date,
+// which the API resolves to the latest data) expire after currentDayTTL.
+type FileCache struct {
+	dir           string
+	currentDayTTL time.Duration
+}
+
+// NewFileCache creates a FileCache rooted at dir. The directory is created
+// on first write.
+func NewFileCache(dir string, currentDayTTL time.Duration) *FileCache {
+	return &FileCache{dir: dir, currentDayTTL: currentDayTTL}
+}
+
+// Get returns the cached body for key if present and still fresh for the
+// given data date.
+func (c *FileCache) Get(key string, date string) ([]byte, bool) {
+	return c.read(c.path(key, ".json"), date)
+}
+
+// Set stores body under key.
+func (c *FileCache) Set(key string, date string, body []byte) error {
+	return c.write(c.path(key, ".json"), body)
+}
+
+// validatorEntry is the stored form of a response's validators.
+type validatorEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// GetStale returns the cached body for key regardless of its age, together
+// with the ETag and Last-Modified validators stored for it, if any.
+func (c *FileCache) GetStale(key string) ([]byte, string, string, bool) {
+	data, err := os.ReadFile(c.path(key, ".json"))
+	if err != nil {
+		return nil, "", "", false
+	}
+	var v validatorEntry
+	if meta, err := os.ReadFile(c.path(key, ".meta")); err == nil {
+		// A corrupt sidecar only disables revalidation.
+		_ = json.Unmarshal(meta, &v)
+	}
+	return data, v.ETag, v.LastModified, true
+}
+
+// SetValidators stores the validators of the body cached under key in a
+// sidecar file, removing it when both are empty.
+func (c *FileCache) SetValidators(key string, etag string, lastModified string) error {
+	path := c.path(key, ".meta")
+	if etag == "" && lastModified == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove cache validators: %w", err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(validatorEntry{ETag: etag, LastModified: lastModified})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache validators: %w", err)
+	}
+	return c.write(path, data)
+}
+
+// Touch restarts the freshness window of the body cached under key.
+func (c *FileCache) Touch(key string) error {
+	now := time.Now()
+	if err := os.Chtimes(c.path(key, ".json"), now, now); err != nil {
+		return fmt.Errorf("failed to refresh cache entry: %w", err)
+	}
+	return nil
+}
+
+// GetCVEs returns the cached CVE list for key if present and still fresh for
+// the given data date.
+func (c *FileCache) GetCVEs(key string, date string) ([]models.CVE, bool) {
+	data, ok := c.read(c.path(key, ".gob"), date)
+	if !ok {
+		return nil, false
+	}
+	var cves []models.CVE
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cves); err != nil {
+		return nil, false
+	}
+	return cves, true
+}
+
+// SetCVEs stores cves under key in gob format.
+func (c *FileCache) SetCVEs(key string, date string, cves []models.CVE) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cves); err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	return c.write(c.path(key, ".gob"), buf.Bytes())
+}
+
+// Clear removes every entry from the cache directory, along with temporary
+// files left by interrupted writes, and reports how many files it removed.
+// Other files in the directory are left alone. A missing directory is empty.
+func (c *FileCache) Clear() (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list cache directory: %w", err)
+	}
+	var removed int
+	for _, e := range entries {
+		if !e.Type().IsRegular() || !isEntryName(e.Name()) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, e.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// isEntryName reports whether name is a file the cache writes: a hashed key
+// with an entry extension, or a temporary file.
+func isEntryName(name string) bool {
+	if matched, _ := filepath.Match("entry-*.tmp", name); matched {
+		return true
+	}
+	ext := filepath.Ext(name)
+	if ext != ".json" && ext != ".gob" && ext != ".meta" {
+		return false
+	}
+	key, err := hex.DecodeString(strings.TrimSuffix(name, ext))
+	return err == nil && len(key) == sha256.Size
+}
+
+// read returns the contents of the entry at path if it is fresh for date.
+func (c *FileCache) read(path string, date string) ([]byte, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.isCurrentDay(date) && time.Since(info.ModTime()) > c.currentDayTTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// write atomically stores data at path.
+func (c *FileCache) write(path string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
+
+// isCurrentDay reports whether date refers to data that may still change.
+func (c *FileCache) isCurrentDay(date string) bool {
+	if date == "" {
+		return true
+	}
+	d, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return true
+	}
+	today := time.Now().UTC().Format(dateLayout)
+	return d.Format(dateLayout) >= today
+}
+
+// path returns the file path for key with the given extension.
+func (c *FileCache) path(key string, ext string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+ext)
+}
@@ -0,0 +1,199 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ageEntries backdates every cache entry in dir by age.
+func ageEntries(t *testing.T, dir string, age time.Duration) {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	old := time.Now().Add(-age)
+	for _, e := range entries {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, e.Name()), old, old))
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	t.Run("Success - Fresh Entry Is Served", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+		today := time.Now().UTC().Format("2006-01-02")
+
+		require.NoError(t, c.Set("key", today, []byte(`{"data":[]}`)))
+		data, ok := c.Get("key", today)
+
+		assert.True(t, ok)
+		assert.Equal(t, `{"data":[]}`, string(data))
+	})
+
+	t.Run("Expired - Today Key Expires After TTL", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+		today := time.Now().UTC().Format("2006-01-02")
+
+		require.NoError(t, c.Set("key", today, []byte(`{"data":[]}`)))
+		ageEntries(t, dir, 2*time.Hour)
+		_, ok := c.Get("key", today)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Expired - Undated Key Is Treated As Today", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+
+		require.NoError(t, c.Set("key", "", []byte(`{"data":[]}`)))
+		ageEntries(t, dir, 2*time.Hour)
+		_, ok := c.Get("key", "")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Success - Past Date Key Does Not Expire", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+
+		require.NoError(t, c.Set("key", "2024-10-18", []byte(`{"data":[]}`)))
+		ageEntries(t, dir, 24*365*time.Hour)
+		data, ok := c.Get("key", "2024-10-18")
+
+		assert.True(t, ok)
+		assert.Equal(t, `{"data":[]}`, string(data))
+	})
+
+	t.Run("Miss - Unknown Key", func(t *testing.T) {
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+		_, ok := c.Get("missing", "2024-10-18")
+
+		assert.False(t, ok)
+	})
+}
+
+func TestFileCacheCVEs(t *testing.T) {
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.00044, Percentile: 0.13, Date: "2024-10-18"}}
+
+	t.Run("Success - Round Trips Decoded CVEs", func(t *testing.T) {
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		require.NoError(t, c.SetCVEs("key", "2024-10-18", cves))
+		got, ok := c.GetCVEs("key", "2024-10-18")
+
+		assert.True(t, ok)
+		assert.Equal(t, cves, got)
+	})
+
+	t.Run("Expired - Today Key Expires After TTL", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+		today := time.Now().UTC().Format("2006-01-02")
+
+		require.NoError(t, c.SetCVEs("key", today, cves))
+		ageEntries(t, dir, 2*time.Hour)
+		_, ok := c.GetCVEs("key", today)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Miss - Raw And Decoded Entries Are Separate", func(t *testing.T) {
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		require.NoError(t, c.Set("key", "2024-10-18", []byte(`{"data":[]}`)))
+		_, ok := c.GetCVEs("key", "2024-10-18")
+
+		assert.False(t, ok)
+	})
+}
+
+func TestFileCacheValidators(t *testing.T) {
+	t.Run("Success - Stale Entry Keeps Its Validators", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+
+		require.NoError(t, c.Set("key", "", []byte(`{"data":[]}`)))
+		require.NoError(t, c.SetValidators("key", `"v1"`, "Fri, 18 Oct 2024 00:00:00 GMT"))
+		ageEntries(t, dir, 2*time.Hour)
+
+		_, fresh := c.Get("key", "")
+		body, etag, lastModified, ok := c.GetStale("key")
+
+		assert.False(t, fresh)
+		assert.True(t, ok)
+		assert.Equal(t, `{"data":[]}`, string(body))
+		assert.Equal(t, `"v1"`, etag)
+		assert.Equal(t, "Fri, 18 Oct 2024 00:00:00 GMT", lastModified)
+	})
+
+	t.Run("Success - Touch Makes An Entry Fresh Again", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+
+		require.NoError(t, c.Set("key", "", []byte(`{"data":[]}`)))
+		ageEntries(t, dir, 2*time.Hour)
+		require.NoError(t, c.Touch("key"))
+		_, ok := c.Get("key", "")
+
+		assert.True(t, ok)
+	})
+
+	t.Run("Success - Empty Validators Are Removed", func(t *testing.T) {
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		require.NoError(t, c.Set("key", "", []byte(`{"data":[]}`)))
+		require.NoError(t, c.SetValidators("key", `"v1"`, ""))
+		require.NoError(t, c.SetValidators("key", "", ""))
+		_, etag, lastModified, ok := c.GetStale("key")
+
+		assert.True(t, ok)
+		assert.Empty(t, etag)
+		assert.Empty(t, lastModified)
+	})
+
+	t.Run("Miss - Unknown Key", func(t *testing.T) {
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		_, _, _, ok := c.GetStale("missing")
+
+		assert.False(t, ok)
+	})
+}
+
+func TestFileCacheClear(t *testing.T) {
+	t.Run("Success - Removes Entries And Leaves Other Files", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.NewFileCache(dir, time.Hour)
+		require.NoError(t, c.Set("key", "2024-10-18", []byte(`{"data":[]}`)))
+		require.NoError(t, c.SetValidators("key", `"v1"`, ""))
+		require.NoError(t, c.SetCVEs("cves", "2024-10-18", []models.CVE{{ID: "CVE-2023-0001"}}))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "entry-123.tmp"), nil, 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), nil, 0o644))
+
+		removed, err := c.Clear()
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, removed)
+		_, ok := c.Get("key", "2024-10-18")
+		assert.False(t, ok)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "notes.json", entries[0].Name())
+	})
+
+	t.Run("Success - Missing Directory Is Empty", func(t *testing.T) {
+		c := cache.NewFileCache(filepath.Join(t.TempDir(), "missing"), time.Hour)
+
+		removed, err := c.Clear()
+
+		require.NoError(t, err)
+		assert.Zero(t, removed)
+	})
+}
@@ -0,0 +1,67 @@
+//go:build charts
+
+// Package chart renders EPSS time series as PNG line charts. It is only built
+// with the charts build tag so the plotting dependencies stay out of the
+// default binary.
+package chart
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// Chart dimensions, sized to fit a presentation slide.
+const (
+	width  = 10 * vg.Inch
+	height = 5 * vg.Inch
+)
+
+// WriteTimeSeriesPNG draws one line per CVE in cves, plotting EPSS score
+// against date, and saves the chart as a PNG file at path.
+func WriteTimeSeriesPNG(path string, cves []models.CVE) error {
+	series := make(map[string]plotter.XYs)
+	var ids []string
+	for _, cve := range cves {
+		date, err := time.Parse("2006-01-02", cve.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q for %s: %w", cve.Date, cve.ID, err)
+		}
+		if _, ok := series[cve.ID]; !ok {
+			ids = append(ids, cve.ID)
+		}
+		series[cve.ID] = append(series[cve.ID], plotter.XY{X: float64(date.Unix()), Y: cve.EPSSScore})
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no data to chart")
+	}
+
+	p := plot.New()
+	p.Title.Text = "EPSS score over time"
+	p.X.Label.Text = "Date"
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02"}
+	p.Y.Label.Text = "EPSS"
+	p.Y.Min = 0
+	p.Add(plotter.NewGrid())
+
+	var lines []interface{}
+	for _, id := range ids {
+		points := series[id]
+		sort.Slice(points, func(i, j int) bool { return points[i].X < points[j].X })
+		lines = append(lines, id, points)
+	}
+	if err := plotutil.AddLinePoints(p, lines...); err != nil {
+		return fmt.Errorf("failed to draw chart: %w", err)
+	}
+
+	if err := p.Save(width, height, path); err != nil {
+		return fmt.Errorf("failed to save chart %s: %w", path, err)
+	}
+	return nil
+}
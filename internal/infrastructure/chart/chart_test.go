@@ -0,0 +1,45 @@
+//go:build charts
+
+package chart_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/chart"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTimeSeriesPNG(t *testing.T) {
+	t.Run("Success - Writes PNG File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "series.png")
+		cves := []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.2, Date: "2024-10-18"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.1, Date: "2024-10-16"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.4, Date: "2024-10-16"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.5, Date: "2024-10-18"},
+		}
+
+		require.NoError(t, chart.WriteTimeSeriesPNG(path, cves))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("\x89PNG")))
+	})
+
+	t.Run("Fail - No Data", func(t *testing.T) {
+		err := chart.WriteTimeSeriesPNG(filepath.Join(t.TempDir(), "empty.png"), nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail - Invalid Date", func(t *testing.T) {
+		err := chart.WriteTimeSeriesPNG(filepath.Join(t.TempDir(), "bad.png"), []models.CVE{{ID: "CVE-2023-0001", Date: "yesterday"}})
+
+		assert.Error(t, err)
+	})
+}
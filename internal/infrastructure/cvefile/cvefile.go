@@ -0,0 +1,129 @@
+// Package cvefile reads lists of CVE IDs from CSV files, keeping the other
+// columns so enriched output can pass them through.
+package cvefile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Table is a CSV file holding a column of CVE IDs.
+type Table struct {
+	// Header names every column. Files without a header row get "cve" for
+	// the CVE column and column_N for the others.
+	Header []string
+	// Column is the index of the CVE column.
+	Column int
+	// Rows holds the data rows with a CVE ID, padded to the header's width.
+	Rows [][]string
+}
+
+// IDs returns the CVE ID of each row, upper-cased.
+func (t *Table) IDs() []string {
+	ids := make([]string, len(t.Rows))
+	for i, row := range t.Rows {
+		ids[i] = row[t.Column]
+	}
+	return ids
+}
+
+// ReadFile reads the CSV file at path; see Read.
+func ReadFile(path, column string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CVE file: %w", err)
+	}
+	defer f.Close()
+	return Read(f, column)
+}
+
+// Read reads a CSV list of CVE IDs. When column is set, the first row is a
+// header and the CVE IDs are in the column with that name (case-insensitive).
+// Otherwise they are in the first column, and the first row is taken as a
+// header when it does not hold a CVE ID. Rows without a CVE ID are skipped.
+func Read(r io.Reader, column string) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CVE file: %w", err)
+	}
+	if len(records) == 0 {
+		return &Table{Header: []string{"cve"}}, nil
+	}
+
+	table := &Table{}
+	switch {
+	case column != "":
+		table.Header = trimAll(records[0])
+		table.Column = -1
+		for i, name := range table.Header {
+			if strings.EqualFold(name, strings.TrimSpace(column)) {
+				table.Column = i
+				break
+			}
+		}
+		if table.Column < 0 {
+			return nil, fmt.Errorf("CVE column %q not found in header %v", column, table.Header)
+		}
+		records = records[1:]
+	case !isCVE(records[0][0]):
+		table.Header = trimAll(records[0])
+		records = records[1:]
+	default:
+		table.Header = positionalHeader(width(records))
+	}
+
+	var rows [][]string
+	for _, record := range records {
+		if table.Column < len(record) && isCVE(record[table.Column]) {
+			rows = append(rows, trimAll(record))
+		}
+	}
+	// Rows wider than the header get positional names for the extra columns.
+	if n := width(rows); n > len(table.Header) {
+		table.Header = append(table.Header, positionalHeader(n)[len(table.Header):]...)
+	}
+	for _, row := range rows {
+		row = append(row, make([]string, len(table.Header)-len(row))...)
+		row[table.Column] = strings.ToUpper(row[table.Column])
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+// positionalHeader names n columns of a file without a header row.
+func positionalHeader(n int) []string {
+	header := make([]string, n)
+	header[0] = "cve"
+	for i := 1; i < n; i++ {
+		header[i] = fmt.Sprintf("column_%d", i+1)
+	}
+	return header
+}
+
+// width returns the length of the longest record.
+func width(records [][]string) int {
+	n := 1
+	for _, record := range records {
+		n = max(n, len(record))
+	}
+	return n
+}
+
+// trimAll returns the fields with surrounding whitespace removed.
+func trimAll(fields []string) []string {
+	trimmed := make([]string, len(fields))
+	for i, f := range fields {
+		trimmed[i] = strings.TrimSpace(f)
+	}
+	return trimmed
+}
+
+// isCVE reports whether s is a CVE ID.
+func isCVE(s string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(s)), "CVE-")
+}
@@ -0,0 +1,59 @@
+package cvefile_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvefile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile(t *testing.T) {
+	t.Run("Success - Header Named Column", func(t *testing.T) {
+		table, err := cvefile.ReadFile("testdata/named.csv", "cve_id")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ticket", "Asset", "CVE_ID", "Owner"}, table.Header)
+		assert.Equal(t, 2, table.Column)
+		assert.Equal(t, [][]string{
+			{"SEC-101", "web-01", "CVE-2021-44228", "alice"},
+			{"SEC-102", "dc-01", "CVE-2020-1472", "bob"},
+			{"SEC-104", "db-01", "CVE-2023-0001", ""},
+		}, table.Rows)
+		assert.Equal(t, []string{"CVE-2021-44228", "CVE-2020-1472", "CVE-2023-0001"}, table.IDs())
+	})
+
+	t.Run("Success - Positional Without Header", func(t *testing.T) {
+		table, err := cvefile.ReadFile("testdata/positional.csv", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cve", "column_2", "column_3"}, table.Header)
+		assert.Equal(t, 0, table.Column)
+		assert.Equal(t, [][]string{
+			{"CVE-2021-44228", "log4j", ""},
+			{"CVE-2020-1472", "", ""},
+			{"CVE-2023-0001", "", "extra"},
+		}, table.Rows)
+	})
+
+	t.Run("Success - Positional With Header", func(t *testing.T) {
+		table, err := cvefile.Read(strings.NewReader("cve,note\nCVE-2021-44228,log4j\n"), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cve", "note"}, table.Header)
+		assert.Equal(t, []string{"CVE-2021-44228"}, table.IDs())
+	})
+
+	t.Run("Fail - Named Column Missing", func(t *testing.T) {
+		_, err := cvefile.ReadFile("testdata/named.csv", "Vulnerability")
+
+		assert.ErrorContains(t, err, `CVE column "Vulnerability" not found`)
+	})
+
+	t.Run("Fail - Missing File", func(t *testing.T) {
+		_, err := cvefile.ReadFile("testdata/missing.csv", "")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,83 @@
+// Package cvss looks up CVSS base scores in the NVD CVE API.
+package cvss
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/nvd"
+)
+
+// Client fetches CVSS base scores from the NVD, one CVE per request.
+type Client struct {
+	nvd *nvd.Client
+}
+
+// NewClient creates a Client sending its requests through client.
+func NewClient(client *nvd.Client) *Client {
+	return &Client{nvd: client}
+}
+
+// nvdResponse mirrors the fields of an NVD CVE API response the tool uses.
+type nvdResponse struct {
+	Vulnerabilities []struct {
+		CVE struct {
+			ID      string                 `json:"id"`
+			Metrics map[string][]nvdMetric `json:"metrics"`
+		} `json:"cve"`
+	} `json:"vulnerabilities"`
+}
+
+// nvdMetric is one CVSS assessment of a CVE.
+type nvdMetric struct {
+	Type     string `json:"type"`
+	CVSSData struct {
+		BaseScore float64 `json:"baseScore"`
+	} `json:"cvssData"`
+}
+
+// metricVersions lists the NVD metric keys from most to least preferred.
+var metricVersions = []string{"cvssMetricV40", "cvssMetricV31", "cvssMetricV30", "cvssMetricV2"}
+
+// BaseScore returns the CVSS base score of cveID, preferring the newest CVSS
+// version and the NVD's primary assessment over secondary ones. ctx cancels
+// the request and the wait before it.
+func (c *Client) BaseScore(ctx context.Context, cveID string) (float64, bool, error) {
+	data, err := c.nvd.Get(ctx, url.Values{"cveId": {strings.ToUpper(cveID)}})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get CVSS data for %s: %w", cveID, err)
+	}
+	score, ok, err := ParseBaseScore(data)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid CVSS data for %s: %w", cveID, err)
+	}
+	return score, ok, nil
+}
+
+// ParseBaseScore extracts the preferred CVSS base score from an NVD response.
+func ParseBaseScore(data []byte) (float64, bool, error) {
+	var resp nvdResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, false, fmt.Errorf("failed to unmarshal NVD response: %w", err)
+	}
+	if len(resp.Vulnerabilities) == 0 {
+		return 0, false, nil
+	}
+	metrics := resp.Vulnerabilities[0].CVE.Metrics
+	for _, version := range metricVersions {
+		assessments := metrics[version]
+		if len(assessments) == 0 {
+			continue
+		}
+		for _, m := range assessments {
+			if m.Type == "Primary" {
+				return m.CVSSData.BaseScore, true, nil
+			}
+		}
+		return assessments[0].CVSSData.BaseScore, true, nil
+	}
+	return 0, false, nil
+}
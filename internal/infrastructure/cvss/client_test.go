@@ -0,0 +1,76 @@
+package cvss_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cvss"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/nvd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nvdFixture = `{
+	"resultsPerPage": 1,
+	"totalResults": 1,
+	"vulnerabilities": [{
+		"cve": {
+			"id": "CVE-2021-44228",
+			"metrics": {
+				"cvssMetricV31": [
+					{"source": "security@apache.org", "type": "Secondary", "cvssData": {"version": "3.1", "baseScore": 10.0}},
+					{"source": "nvd@nist.gov", "type": "Primary", "cvssData": {"version": "3.1", "baseScore": 9.8}}
+				],
+				"cvssMetricV2": [
+					{"source": "nvd@nist.gov", "type": "Primary", "cvssData": {"version": "2.0", "baseScore": 9.3}}
+				]
+			}
+		}
+	}]
+}`
+
+func TestBaseScore(t *testing.T) {
+	t.Run("Success - Prefers Newest Primary Score", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "CVE-2021-44228", r.URL.Query().Get("cveId"))
+			assert.Equal(t, "secret", r.Header.Get("apiKey"))
+			fmt.Fprintln(w, nvdFixture)
+		}))
+		defer mockServer.Close()
+
+		score, ok, err := cvss.NewClient(nvd.NewClient(mockServer.URL, "secret", 0)).BaseScore(context.Background(), "cve-2021-44228")
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 9.8, score)
+	})
+
+	t.Run("Success - Falls Back To Older Versions", func(t *testing.T) {
+		score, ok, err := cvss.ParseBaseScore([]byte(`{"vulnerabilities":[{"cve":{"id":"CVE-2010-0001","metrics":{"cvssMetricV2":[{"type":"Primary","cvssData":{"baseScore":5.0}}]}}}]}`))
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 5.0, score)
+	})
+
+	t.Run("Success - No Score", func(t *testing.T) {
+		_, ok, err := cvss.ParseBaseScore([]byte(`{"vulnerabilities":[{"cve":{"id":"CVE-2024-0001","metrics":{}}}]}`))
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Fail - API Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		}))
+		defer mockServer.Close()
+
+		_, _, err := cvss.NewClient(nvd.NewClient(mockServer.URL, "", 0)).BaseScore(context.Background(), "CVE-2021-44228")
+
+		assert.Error(t, err)
+	})
+}
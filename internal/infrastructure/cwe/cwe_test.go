@@ -0,0 +1,74 @@
+package cwe_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cwe"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/nvd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMapper(t *testing.T) {
+	t.Run("Success - Maps CWEs To CVEs", func(t *testing.T) {
+		m, err := cwe.Read(strings.NewReader("cve,CWE\ncve-2023-0001,cwe-79\nCVE-2023-0002,CWE-79\nCVE-2023-0001,CWE-79\nCVE-2023-0003,CWE-89\n"))
+		require.NoError(t, err)
+
+		ids, err := m.CVEsForCWE(context.Background(), "CWE-79")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CVE-2023-0001", "CVE-2023-0002"}, ids)
+	})
+
+	t.Run("Success - Unknown CWE", func(t *testing.T) {
+		m, err := cwe.Read(strings.NewReader("cwe,cve\nCWE-79,CVE-2023-0001\n"))
+		require.NoError(t, err)
+
+		ids, err := m.CVEsForCWE(context.Background(), "CWE-20")
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("Fail - Missing Columns", func(t *testing.T) {
+		_, err := cwe.Read(strings.NewReader("id,weakness\nCVE-2023-0001,CWE-79\n"))
+		assert.ErrorContains(t, err, "cwe and cve columns")
+	})
+}
+
+func TestNVDMapper(t *testing.T) {
+	t.Run("Success - Follows Pages", func(t *testing.T) {
+		var starts []string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "CWE-79", r.URL.Query().Get("cweId"))
+			assert.Equal(t, "secret", r.Header.Get("apiKey"))
+			start := r.URL.Query().Get("startIndex")
+			starts = append(starts, start)
+			if start == "0" {
+				fmt.Fprint(w, `{"totalResults":3,"vulnerabilities":[{"cve":{"id":"CVE-2023-0001"}},{"cve":{"id":"CVE-2023-0002"}}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"totalResults":3,"vulnerabilities":[{"cve":{"id":"CVE-2023-0003"}}]}`)
+		}))
+		defer mockServer.Close()
+
+		ids, err := cwe.NewNVDMapper(nvd.NewClient(mockServer.URL, "secret", 0)).CVEsForCWE(context.Background(), "CWE-79")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CVE-2023-0001", "CVE-2023-0002", "CVE-2023-0003"}, ids)
+		assert.Equal(t, []string{"0", "2"}, starts)
+	})
+
+	t.Run("Fail - Server Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer mockServer.Close()
+
+		_, err := cwe.NewNVDMapper(nvd.NewClient(mockServer.URL, "", 0)).CVEsForCWE(context.Background(), "CWE-79")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+// Package cwe maps CWE weakness IDs to the CVEs classified under them.
+package cwe
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// FileMapper maps CWEs to CVEs from a CSV file with "cwe" and "cve" columns,
+// one pair per row. A CVE under several CWEs appears on several rows.
+type FileMapper struct {
+	cves map[string][]string
+}
+
+// ReadFile reads the mapping file at path; see Read.
+func ReadFile(path string) (*FileMapper, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CWE mapping: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads a CSV mapping whose header row names a "cwe" and a "cve" column
+// (case-insensitive). IDs are upper-cased and duplicate pairs are dropped.
+func Read(r io.Reader) (*FileMapper, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CWE mapping: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CWE mapping is empty")
+	}
+	cweCol, cveCol := -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "cwe":
+			cweCol = i
+		case "cve":
+			cveCol = i
+		}
+	}
+	if cweCol < 0 || cveCol < 0 {
+		return nil, fmt.Errorf("CWE mapping header must have cwe and cve columns")
+	}
+
+	m := &FileMapper{cves: make(map[string][]string)}
+	seen := make(map[[2]string]bool)
+	for _, row := range records[1:] {
+		if cweCol >= len(row) || cveCol >= len(row) {
+			continue
+		}
+		cwe := strings.ToUpper(strings.TrimSpace(row[cweCol]))
+		cve := strings.ToUpper(strings.TrimSpace(row[cveCol]))
+		if cwe == "" || cve == "" || seen[[2]string{cwe, cve}] {
+			continue
+		}
+		seen[[2]string{cwe, cve}] = true
+		m.cves[cwe] = append(m.cves[cwe], cve)
+	}
+	return m, nil
+}
+
+// CVEsForCWE returns the CVEs mapped to cwe in file order.
+func (m *FileMapper) CVEsForCWE(ctx context.Context, cwe string) ([]string, error) {
+	return m.cves[strings.ToUpper(cwe)], nil
+}
@@ -0,0 +1,71 @@
+package cwe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/nvd"
+)
+
+// nvdPageSize is the largest page the NVD CVE API returns.
+const nvdPageSize = 2000
+
+// NVDMapper lists the CVEs of a CWE with the NVD CVE API's cweId filter,
+// following its pages until every match is fetched.
+type NVDMapper struct {
+	nvd *nvd.Client
+}
+
+// NewNVDMapper creates an NVDMapper sending its requests through client.
+func NewNVDMapper(client *nvd.Client) *NVDMapper {
+	return &NVDMapper{nvd: client}
+}
+
+// nvdPage mirrors the fields of an NVD CVE API page the mapper uses.
+type nvdPage struct {
+	TotalResults    int `json:"totalResults"`
+	Vulnerabilities []struct {
+		CVE struct {
+			ID string `json:"id"`
+		} `json:"cve"`
+	} `json:"vulnerabilities"`
+}
+
+// CVEsForCWE returns the IDs of the CVEs the NVD classifies under cwe. ctx
+// cancels the page request in flight and the waits between pages.
+func (m *NVDMapper) CVEsForCWE(ctx context.Context, cwe string) ([]string, error) {
+	var ids []string
+	for start := 0; ; {
+		page, err := m.fetchPage(ctx, cwe, start)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Vulnerabilities {
+			ids = append(ids, v.CVE.ID)
+		}
+		start += len(page.Vulnerabilities)
+		if len(page.Vulnerabilities) == 0 || start >= page.TotalResults {
+			return ids, nil
+		}
+	}
+}
+
+// fetchPage requests the page of cwe's CVEs beginning at start.
+func (m *NVDMapper) fetchPage(ctx context.Context, cwe string, start int) (nvdPage, error) {
+	q := url.Values{}
+	q.Set("cweId", cwe)
+	q.Set("resultsPerPage", strconv.Itoa(nvdPageSize))
+	q.Set("startIndex", strconv.Itoa(start))
+	data, err := m.nvd.Get(ctx, q)
+	if err != nil {
+		return nvdPage{}, fmt.Errorf("failed to get CWE mapping: %w", err)
+	}
+	var page nvdPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nvdPage{}, fmt.Errorf("invalid CWE mapping for %s: %w", cwe, err)
+	}
+	return page, nil
+}
@@ -0,0 +1,197 @@
+package elasticsearch_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/elasticsearch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCluster is a minimal Elasticsearch bulk endpoint.
+type fakeCluster struct {
+	mu          sync.Mutex
+	indexExists bool
+	created     string
+	bulkSizes   []int
+	docs        map[string]map[string]interface{}
+	// rejectFirst makes the first bulk request reject its first document with 429.
+	rejectFirst bool
+}
+
+func (f *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodHead && r.URL.Path == "/epss":
+		if !f.indexExists {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut && r.URL.Path == "/epss":
+		body, _ := io.ReadAll(r.Body)
+		f.created = string(body)
+		f.indexExists = true
+		fmt.Fprint(w, `{"acknowledged":true}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+		f.bulk(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeCluster) bulk(w http.ResponseWriter, r *http.Request) {
+	if f.docs == nil {
+		f.docs = make(map[string]map[string]interface{})
+	}
+	var items []string
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var action map[string]map[string]string
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil || !scanner.Scan() {
+			http.Error(w, "malformed bulk body", http.StatusBadRequest)
+			return
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			http.Error(w, "malformed document", http.StatusBadRequest)
+			return
+		}
+		if f.rejectFirst {
+			f.rejectFirst = false
+			items = append(items, `{"index":{"status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}`)
+			continue
+		}
+		f.docs[action["index"]["_id"]] = doc
+		items = append(items, `{"index":{"status":201}}`)
+	}
+	f.bulkSizes = append(f.bulkSizes, len(items))
+	fmt.Fprintf(w, `{"errors":%t,"items":[%s]}`, strings.Contains(strings.Join(items, ""), "error"), strings.Join(items, ","))
+}
+
+func TestIndexerWriteDay(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.1, Percentile: 0.5, Date: "2024-10-18"},
+		{ID: "CVE-2023-0002", EPSSScore: 0.2, Percentile: 0.6, Date: "2024-10-18"},
+		{ID: "CVE-2023-0003", EPSSScore: 0.3, Percentile: 0.7, Date: "2024-10-18"},
+	}
+	noSleep := elasticsearch.WithSleep(func(time.Duration) {})
+
+	t.Run("Success - Creates Missing Index And Indexes In Batches", func(t *testing.T) {
+		cluster := &fakeCluster{}
+		server := httptest.NewServer(cluster)
+		defer server.Close()
+
+		indexer := elasticsearch.NewIndexer(server.URL, "epss", elasticsearch.WithBatchSize(2), noSleep)
+		dest, err := indexer.WriteDay("2024-10-18", cves)
+
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/epss", dest)
+		assert.Contains(t, cluster.created, `"cve": {"type": "keyword"}`)
+		assert.Contains(t, cluster.created, `"date": {"type": "date", "format": "yyyy-MM-dd"}`)
+		assert.Equal(t, []int{2, 1}, cluster.bulkSizes)
+		assert.Equal(t, map[string]interface{}{"cve": "CVE-2023-0002", "epss": 0.2, "percentile": 0.6, "date": "2024-10-18"}, cluster.docs["CVE-2023-0002_2024-10-18"])
+	})
+
+	t.Run("Success - Reuses Existing Index", func(t *testing.T) {
+		cluster := &fakeCluster{indexExists: true}
+		server := httptest.NewServer(cluster)
+		defer server.Close()
+
+		_, err := elasticsearch.NewIndexer(server.URL, "epss", noSleep).WriteDay("2024-10-18", cves)
+
+		require.NoError(t, err)
+		assert.Empty(t, cluster.created)
+		assert.Len(t, cluster.docs, 3)
+	})
+
+	t.Run("Success - Retries Rejected Documents", func(t *testing.T) {
+		cluster := &fakeCluster{indexExists: true, rejectFirst: true}
+		server := httptest.NewServer(cluster)
+		defer server.Close()
+
+		var delays []time.Duration
+		indexer := elasticsearch.NewIndexer(server.URL, "epss", elasticsearch.WithSleep(func(d time.Duration) { delays = append(delays, d) }))
+		_, err := indexer.WriteDay("2024-10-18", cves)
+
+		require.NoError(t, err)
+		assert.Len(t, cluster.docs, 3)
+		assert.Equal(t, []int{3, 1}, cluster.bulkSizes)
+		assert.Equal(t, []time.Duration{elasticsearch.DefaultRetryDelay}, delays)
+	})
+
+	t.Run("Fail - Gives Up After Retries", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				return
+			}
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := elasticsearch.NewIndexer(server.URL, "epss", elasticsearch.WithRetries(2, time.Millisecond), noSleep).WriteDay("2024-10-18", cves)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Success - Credentials Are Sent As Basic Auth", func(t *testing.T) {
+		cluster := &fakeCluster{indexExists: true}
+		var users, passwords []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, password, _ := r.BasicAuth()
+			users, passwords = append(users, user), append(passwords, password)
+			cluster.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+		withCredentials := strings.Replace(server.URL, "http://", "http://elastic:s3cret@", 1)
+
+		dest, err := elasticsearch.NewIndexer(withCredentials, "epss", noSleep).WriteDay("2024-10-18", cves)
+
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/epss", dest)
+		assert.Equal(t, []string{"elastic", "elastic"}, users)
+		assert.Equal(t, []string{"s3cret", "s3cret"}, passwords)
+	})
+
+	t.Run("Fail - Errors Leave Out Credentials", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		withCredentials := strings.Replace(server.URL, "http://", "http://elastic:s3cret@", 1)
+
+		_, err := elasticsearch.NewIndexer(withCredentials, "epss", noSleep).WriteDay("2024-10-18", cves)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+		assert.NotContains(t, err.Error(), "s3cret")
+	})
+
+	t.Run("Fail - Document Rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				return
+			}
+			fmt.Fprint(w, `{"errors":true,"items":[{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad date"}}}]}`)
+		}))
+		defer server.Close()
+
+		_, err := elasticsearch.NewIndexer(server.URL, "epss", noSleep).WriteDay("2024-10-18", cves)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CVE-2023-0001: mapper_parsing_exception: bad date")
+	})
+}
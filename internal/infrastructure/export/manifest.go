@@ -0,0 +1,80 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/state"
+)
+
+// ManifestName is the name of the manifest file in the output directory.
+const ManifestName = "manifest.json"
+
+// Manifest is the inventory of the files of an export.
+type Manifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes one exported file and the run that produced it.
+// Path is relative to the output directory and uses forward slashes.
+type ManifestFile struct {
+	Path        string `json:"path"`
+	Date        string `json:"date"`
+	Records     int    `json:"records"`
+	SHA256      string `json:"sha256"`
+	ToolVersion string `json:"tool_version"`
+	Source      string `json:"source"`
+}
+
+// provenance identifies the producer of the files a Writer records.
+type provenance struct {
+	toolVersion string
+	source      string
+}
+
+// WithManifest keeps a manifest.json in the output directory up to date as
+// files are written, recording toolVersion and source as the producer of each
+// file. Entries of a previous run are kept with their own provenance, and a
+// re-exported date replaces its entry, so resumed runs extend the same manifest.
+func WithManifest(toolVersion, source string) Option {
+	return func(w *Writer) {
+		w.manifest = &provenance{toolVersion: toolVersion, source: source}
+	}
+}
+
+// ReadManifest reads the manifest in dir. It returns an empty manifest when
+// none has been written yet.
+func ReadManifest(dir string) (*Manifest, error) {
+	var m Manifest
+	if _, err := state.Load(filepath.Join(dir, ManifestName), &m); err != nil {
+		return nil, fmt.Errorf("failed to read export manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// record adds or replaces the entry of file in the manifest on disk, stamped
+// with the provenance of w.
+func (w *Writer) record(file ManifestFile) error {
+	m, err := ReadManifest(w.dir)
+	if err != nil {
+		return err
+	}
+	file.ToolVersion = w.manifest.toolVersion
+	file.Source = w.manifest.source
+	replaced := false
+	for i, f := range m.Files {
+		if f.Date == file.Date {
+			m.Files[i] = file
+			replaced = true
+		}
+	}
+	if !replaced {
+		m.Files = append(m.Files, file)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Date < m.Files[j].Date })
+	if err := state.Save(filepath.Join(w.dir, ManifestName), m); err != nil {
+		return fmt.Errorf("failed to write export manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package export writes EPSS data to per-day files for downstream pipelines.
+package export
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// Writer writes one JSON Lines file per data date into a directory.
+type Writer struct {
+	dir      string
+	byYear   bool
+	manifest *provenance
+	// mu serializes manifest updates.
+	mu sync.Mutex
+}
+
+// Option configures a Writer.
+type Option func(*Writer)
+
+// WithPartitionByYear stores each file in a subdirectory named after the
+// year of its date, such as out/2024/epss-2024-10-18.jsonl.
+func WithPartitionByYear() Option {
+	return func(w *Writer) {
+		w.byYear = true
+	}
+}
+
+// NewWriter creates a Writer that stores files in dir.
+func NewWriter(dir string, opts ...Option) *Writer {
+	w := &Writer{dir: dir}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// FileName returns the name of the file holding the data for date.
+func FileName(date string) string {
+	return fmt.Sprintf("epss-%s.jsonl", date)
+}
+
+// Path returns the path of the file holding the data for date.
+func (w *Writer) Path(date string) string {
+	if w.byYear && len(date) >= 4 {
+		return filepath.Join(w.dir, date[:4], FileName(date))
+	}
+	return filepath.Join(w.dir, FileName(date))
+}
+
+// WriteDay atomically writes cves as JSON Lines to the file for date and
+// returns its path, creating its directory as needed. An existing file for
+// the date is replaced. With WithManifest, the file's entry in the manifest
+// is updated once the file is in place.
+func (w *Writer) WriteDay(date string, cves []models.CVE) (string, error) {
+	path := w.Path(date)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".export-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	buf := bufio.NewWriter(io.MultiWriter(tmp, hash))
+	enc := json.NewEncoder(buf)
+	for _, cve := range cves {
+		if err := enc.Encode(cve); err != nil {
+			tmp.Close()
+			return "", fmt.Errorf("failed to encode CVE %s: %w", cve.ID, err)
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store export file %s: %w", path, err)
+	}
+	if w.manifest != nil {
+		rel, err := filepath.Rel(w.dir, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to record export file %s: %w", path, err)
+		}
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		err = w.record(ManifestFile{
+			Path:    filepath.ToSlash(rel),
+			Date:    date,
+			Records: len(cves),
+			SHA256:  hex.EncodeToString(hash.Sum(nil)),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,131 @@
+package export_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDay(t *testing.T) {
+	t.Run("Success - Writes JSON Lines File", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "out")
+		w := export.NewWriter(dir)
+
+		path, err := w.WriteDay("2024-10-18", []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.5, Percentile: 0.9, Date: "2024-10-18"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.1, Percentile: 0.2, Date: "2024-10-18"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "epss-2024-10-18.jsonl"), path)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"cve":"CVE-2023-0001","epss":0.5,"percentile":0.9,"date":"2024-10-18"}`+"\n"+
+				`{"cve":"CVE-2023-0002","epss":0.1,"percentile":0.2,"date":"2024-10-18"}`+"\n",
+			string(data))
+	})
+
+	t.Run("Success - Replaces Existing File", func(t *testing.T) {
+		w := export.NewWriter(t.TempDir())
+		_, err := w.WriteDay("2024-10-18", []models.CVE{{ID: "CVE-2023-0001"}, {ID: "CVE-2023-0002"}})
+		require.NoError(t, err)
+
+		path, err := w.WriteDay("2024-10-18", nil)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+	t.Run("Success - Partitions By Year", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "out")
+		w := export.NewWriter(dir, export.WithPartitionByYear())
+
+		for _, date := range []string{"2023-12-31", "2024-01-01", "2024-01-02"} {
+			path, err := w.WriteDay(date, []models.CVE{{ID: "CVE-2023-0001", Date: date}})
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, date[:4], "epss-"+date+".jsonl"), path)
+			assert.FileExists(t, path)
+		}
+
+		entries, err := os.ReadDir(filepath.Join(dir, "2024"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+}
+
+func TestManifest(t *testing.T) {
+	t.Run("Success - Lists Each File With Count And Checksum", func(t *testing.T) {
+		dir := t.TempDir()
+		w := export.NewWriter(dir, export.WithManifest("1.2.3", "https://api.first.org/data/v1/epss"), export.WithPartitionByYear())
+
+		path, err := w.WriteDay("2024-10-18", []models.CVE{{ID: "CVE-2023-0001"}, {ID: "CVE-2023-0002"}})
+		require.NoError(t, err)
+		_, err = w.WriteDay("2024-10-17", []models.CVE{{ID: "CVE-2023-0001"}})
+		require.NoError(t, err)
+
+		m, err := export.ReadManifest(dir)
+		require.NoError(t, err)
+		require.Len(t, m.Files, 2)
+		assert.Equal(t, "2024-10-17", m.Files[0].Date)
+		assert.Equal(t, export.ManifestFile{
+			Path:        "2024/epss-2024-10-18.jsonl",
+			Date:        "2024-10-18",
+			Records:     2,
+			SHA256:      fileSHA256(t, path),
+			ToolVersion: "1.2.3",
+			Source:      "https://api.first.org/data/v1/epss",
+		}, m.Files[1])
+	})
+
+	t.Run("Success - Resumed Run Keeps And Replaces Entries", func(t *testing.T) {
+		dir := t.TempDir()
+		first := export.NewWriter(dir, export.WithManifest("1.0.0", "src"))
+		_, err := first.WriteDay("2024-10-17", []models.CVE{{ID: "CVE-2023-0001"}})
+		require.NoError(t, err)
+		_, err = first.WriteDay("2024-10-18", []models.CVE{{ID: "CVE-2023-0001"}})
+		require.NoError(t, err)
+
+		resumed := export.NewWriter(dir, export.WithManifest("1.1.0", "mirror"))
+		path, err := resumed.WriteDay("2024-10-18", []models.CVE{{ID: "CVE-2023-0001"}, {ID: "CVE-2023-0002"}})
+		require.NoError(t, err)
+		_, err = resumed.WriteDay("2024-10-19", nil)
+		require.NoError(t, err)
+
+		m, err := export.ReadManifest(dir)
+		require.NoError(t, err)
+		require.Len(t, m.Files, 3)
+		assert.Equal(t, []string{"2024-10-17", "2024-10-18", "2024-10-19"}, []string{m.Files[0].Date, m.Files[1].Date, m.Files[2].Date})
+		assert.Equal(t, "1.0.0", m.Files[0].ToolVersion)
+		assert.Equal(t, "src", m.Files[0].Source)
+		assert.Equal(t, 2, m.Files[1].Records)
+		assert.Equal(t, fileSHA256(t, path), m.Files[1].SHA256)
+		assert.Equal(t, "1.1.0", m.Files[1].ToolVersion)
+		assert.Equal(t, "mirror", m.Files[1].Source)
+	})
+
+	t.Run("Success - No Manifest Without The Option", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := export.NewWriter(dir).WriteDay("2024-10-18", nil)
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, filepath.Join(dir, export.ManifestName))
+	})
+}
+
+// fileSHA256 returns the hex SHA-256 digest of the file at path.
+func fileSHA256(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+// Package health diagnoses the environment the CLI runs in.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// apiTimeout bounds the API reachability probe.
+const apiTimeout = 10 * time.Second
+
+// CheckAPI requests a single row from the EPSS API at baseURL and reports
+// whether it answered successfully and how long it took.
+func CheckAPI(baseURL string) models.Check {
+	check := models.Check{Name: "API reachable"}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		check.Detail = fmt.Sprintf("invalid base URL: %v", err)
+		return check
+	}
+	query := u.Query()
+	query.Set("limit", "1")
+	u.RawQuery = query.Encode()
+
+	client := &http.Client{Timeout: apiTimeout}
+	start := time.Now()
+	resp, err := client.Get(u.String())
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		check.Detail = fmt.Sprintf("request to %s failed: %v", u.Redacted(), err)
+		return check
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		check.Detail = fmt.Sprintf("%s returned status %d in %s", u.Redacted(), resp.StatusCode, latency)
+		return check
+	}
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%s answered in %s", u.Redacted(), latency)
+	return check
+}
+
+// CheckWritableDir verifies that files can be created in dir, creating it if needed.
+func CheckWritableDir(name, dir string) models.Check {
+	check := models.Check{Name: name}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		check.Detail = fmt.Sprintf("cannot create %s: %v", dir, err)
+		return check
+	}
+	f, err := os.CreateTemp(dir, ".doctor-*.tmp")
+	if err != nil {
+		check.Detail = fmt.Sprintf("cannot write to %s: %v", dir, err)
+		return check
+	}
+	f.Close()
+	os.Remove(f.Name())
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%s is writable", dir)
+	return check
+}
+
+// CheckReadableFile verifies that path is a regular file that can be opened.
+func CheckReadableFile(name, path string) models.Check {
+	check := models.Check{Name: name}
+	f, err := os.Open(path)
+	if err != nil {
+		check.Detail = fmt.Sprintf("cannot read %s: %v", path, err)
+		return check
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		check.Detail = fmt.Sprintf("%s is not a regular file", path)
+		return check
+	}
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%s is readable (%d bytes)", path, info.Size())
+	return check
+}
+
+// CheckReadableDir verifies that dir exists and can be listed.
+func CheckReadableDir(name, dir string) models.Check {
+	check := models.Check{Name: name}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		check.Detail = fmt.Sprintf("cannot read %s: %v", dir, err)
+		return check
+	}
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%s is readable (%d entries)", dir, len(entries))
+	return check
+}
@@ -0,0 +1,100 @@
+// Package kev reads the CISA Known Exploited Vulnerabilities (KEV) catalog.
+package kev
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// DefaultCatalogURL is the CISA KEV catalog JSON feed.
+const DefaultCatalogURL = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+
+// Catalog is an indexed snapshot of the KEV catalog.
+type Catalog struct {
+	entries map[string]models.KEVEntry
+}
+
+// Lookup returns the catalog entry for cveID, if listed.
+func (c *Catalog) Lookup(cveID string) (models.KEVEntry, bool) {
+	entry, ok := c.entries[strings.ToUpper(cveID)]
+	return entry, ok
+}
+
+// Contains reports whether cveID is listed in the catalog.
+func (c *Catalog) Contains(cveID string) bool {
+	_, ok := c.Lookup(cveID)
+	return ok
+}
+
+// Len returns the number of vulnerabilities in the catalog.
+func (c *Catalog) Len() int {
+	return len(c.entries)
+}
+
+// Client fetches the KEV catalog over HTTP.
+type Client struct {
+	url string
+}
+
+// NewClient creates a Client for the catalog feed at url.
+func NewClient(url string) *Client {
+	return &Client{url: url}
+}
+
+// catalogFeed mirrors the fields of the CISA feed the tool uses.
+type catalogFeed struct {
+	Vulnerabilities []struct {
+		CVEID             string `json:"cveID"`
+		VendorProject     string `json:"vendorProject"`
+		Product           string `json:"product"`
+		VulnerabilityName string `json:"vulnerabilityName"`
+		DateAdded         string `json:"dateAdded"`
+	} `json:"vulnerabilities"`
+}
+
+// FetchCatalog downloads and indexes the KEV catalog.
+func (c *Client) FetchCatalog() (*Catalog, error) {
+	log.Printf("Fetching KEV catalog from: %s", c.url)
+	resp, err := http.Get(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch KEV catalog from %s: %w", c.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &apierr.StatusError{StatusCode: resp.StatusCode, URL: c.url}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KEV catalog from %s: %w", c.url, err)
+	}
+	return ParseCatalog(data)
+}
+
+// ParseCatalog indexes a KEV catalog JSON document.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var feed catalogFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal KEV catalog: %w", err)
+	}
+	entries := make(map[string]models.KEVEntry, len(feed.Vulnerabilities))
+	for _, v := range feed.Vulnerabilities {
+		id := strings.ToUpper(v.CVEID)
+		entries[id] = models.KEVEntry{
+			CVE:               id,
+			VendorProject:     v.VendorProject,
+			Product:           v.Product,
+			VulnerabilityName: v.VulnerabilityName,
+			DateAdded:         v.DateAdded,
+		}
+	}
+	return &Catalog{entries: entries}, nil
+}
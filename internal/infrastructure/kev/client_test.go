@@ -0,0 +1,62 @@
+package kev_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/kev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const catalogFixture = `{
+	"title": "CISA Catalog of Known Exploited Vulnerabilities",
+	"count": 2,
+	"vulnerabilities": [
+		{"cveID": "CVE-2021-44228", "vendorProject": "Apache", "product": "Log4j2", "vulnerabilityName": "Apache Log4j2 Remote Code Execution Vulnerability", "dateAdded": "2021-12-10"},
+		{"cveID": "CVE-2020-1472", "vendorProject": "Microsoft", "product": "Netlogon", "vulnerabilityName": "Microsoft Netlogon Privilege Escalation Vulnerability", "dateAdded": "2021-11-03"}
+	]
+}`
+
+func TestFetchCatalog(t *testing.T) {
+	t.Run("Success - Indexes Catalog", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, catalogFixture)
+		}))
+		defer mockServer.Close()
+
+		catalog, err := kev.NewClient(mockServer.URL).FetchCatalog()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, catalog.Len())
+		assert.True(t, catalog.Contains("cve-2021-44228"))
+		assert.False(t, catalog.Contains("CVE-2023-0001"))
+		entry, ok := catalog.Lookup("CVE-2020-1472")
+		assert.True(t, ok)
+		assert.Equal(t, "2021-11-03", entry.DateAdded)
+	})
+
+	t.Run("Fail - Feed Unavailable", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+		}))
+		defer mockServer.Close()
+
+		_, err := kev.NewClient(mockServer.URL).FetchCatalog()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail - Malformed Feed", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `<html>maintenance</html>`)
+		}))
+		defer mockServer.Close()
+
+		_, err := kev.NewClient(mockServer.URL).FetchCatalog()
+
+		assert.Error(t, err)
+	})
+}
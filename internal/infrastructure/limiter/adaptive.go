@@ -0,0 +1,145 @@
+package limiter
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultSlowdownBelow is the remaining request budget under which
+// AdaptiveTransport starts spacing requests out.
+const DefaultSlowdownBelow = 10
+
+// epochThreshold tells the two X-RateLimit-Reset conventions apart: larger
+// values are Unix timestamps, smaller ones seconds until the reset.
+const epochThreshold = 1_000_000_000
+
+// AdaptiveTransport is an http.RoundTripper that follows the server's
+// X-RateLimit-Remaining and X-RateLimit-Reset headers. While plenty of budget
+// remains it adds no delay. Below the slowdown threshold it spreads the
+// remaining requests evenly over the time left until the reset, and once the
+// budget is exhausted it pauses until the reset. When the server sends no such
+// headers, requests pass straight through to the wrapped transport, which
+// applies any fixed limit.
+type AdaptiveTransport struct {
+	base          http.RoundTripper
+	slowdownBelow int
+	now           func() time.Time
+	sleep         func(ctx context.Context, d time.Duration) error
+
+	mu sync.Mutex
+	// remaining and resetAt are the budget last reported by the server,
+	// decremented locally for requests sent since. known is false until a
+	// response carried both headers.
+	remaining int
+	resetAt   time.Time
+	known     bool
+}
+
+// AdaptiveOption configures an AdaptiveTransport.
+type AdaptiveOption func(*AdaptiveTransport)
+
+// WithSlowdownBelow starts spacing requests out when fewer than n remain.
+func WithSlowdownBelow(n int) AdaptiveOption {
+	return func(t *AdaptiveTransport) {
+		t.slowdownBelow = n
+	}
+}
+
+// WithClock replaces the time source and the wait between requests. Tests
+// use it to record delays instead of waiting.
+func WithClock(now func() time.Time, sleep func(ctx context.Context, d time.Duration) error) AdaptiveOption {
+	return func(t *AdaptiveTransport) {
+		t.now = now
+		t.sleep = sleep
+	}
+}
+
+// NewAdaptiveTransport wraps base (http.DefaultTransport when nil).
+func NewAdaptiveTransport(base http.RoundTripper, opts ...AdaptiveOption) *AdaptiveTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &AdaptiveTransport{base: base, slowdownBelow: DefaultSlowdownBelow, now: time.Now, sleep: sleepContext}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *AdaptiveTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if d := t.reserve(); d > 0 {
+		log.Printf("Upstream rate limit nearly exhausted; waiting %s", d.Round(time.Millisecond))
+		if err := t.sleep(req.Context(), d); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.observe(resp.Header)
+	return resp, nil
+}
+
+// reserve takes one request from the known budget and returns how long to
+// wait before sending it.
+func (t *AdaptiveTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.known {
+		return 0
+	}
+	now := t.now()
+	untilReset := t.resetAt.Sub(now)
+	if untilReset <= 0 {
+		// The window has reset; the next response reports the new budget.
+		t.known = false
+		return 0
+	}
+	remaining := t.remaining
+	t.remaining--
+	switch {
+	case remaining <= 0:
+		return untilReset
+	case remaining < t.slowdownBelow:
+		return untilReset / time.Duration(remaining+1)
+	default:
+		return 0
+	}
+}
+
+// observe records the budget reported in a response, if any.
+func (t *AdaptiveTransport) observe(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	resetAt := time.Unix(reset, 0)
+	if reset < epochThreshold {
+		resetAt = t.now().Add(time.Duration(reset) * time.Second)
+	}
+	t.remaining, t.resetAt, t.known = remaining, resetAt, true
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,116 @@
+package limiter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitedServer reports a budget that starts at remaining and drops by
+// one per request, resetting in reset (a header value).
+func rateLimitedServer(t *testing.T, remaining int, reset string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(remaining, 0)))
+		w.Header().Set("X-RateLimit-Reset", reset)
+		remaining--
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// recordingClock returns a fixed clock and a sleep that records its delays.
+func recordingClock(delays *[]time.Duration) limiter.AdaptiveOption {
+	now := time.Unix(1729238400, 0)
+	return limiter.WithClock(func() time.Time { return now }, func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	})
+}
+
+func get(t *testing.T, client *http.Client, url string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestAdaptiveTransport(t *testing.T) {
+	t.Run("Success - Slows Down As Remaining Approaches Zero", func(t *testing.T) {
+		server := rateLimitedServer(t, 3, "60")
+		var delays []time.Duration
+		client := &http.Client{Transport: limiter.NewAdaptiveTransport(nil, recordingClock(&delays))}
+
+		for i := 0; i < 5; i++ {
+			get(t, client, server.URL)
+		}
+
+		assert.Equal(t, []time.Duration{15 * time.Second, 20 * time.Second, 30 * time.Second, 60 * time.Second}, delays)
+	})
+
+	t.Run("Success - No Delay With Ample Budget", func(t *testing.T) {
+		server := rateLimitedServer(t, 100, "60")
+		var delays []time.Duration
+		client := &http.Client{Transport: limiter.NewAdaptiveTransport(nil, recordingClock(&delays))}
+
+		for i := 0; i < 5; i++ {
+			get(t, client, server.URL)
+		}
+
+		assert.Empty(t, delays)
+	})
+
+	t.Run("Success - Unix Timestamp Reset", func(t *testing.T) {
+		server := rateLimitedServer(t, 0, strconv.Itoa(1729238400+30))
+		var delays []time.Duration
+		client := &http.Client{Transport: limiter.NewAdaptiveTransport(nil, recordingClock(&delays))}
+
+		get(t, client, server.URL)
+		get(t, client, server.URL)
+
+		assert.Equal(t, []time.Duration{30 * time.Second}, delays)
+	})
+
+	t.Run("Success - Expired Window Is Forgotten", func(t *testing.T) {
+		server := rateLimitedServer(t, 0, strconv.Itoa(1729238400-1))
+		var delays []time.Duration
+		client := &http.Client{Transport: limiter.NewAdaptiveTransport(nil, recordingClock(&delays))}
+
+		get(t, client, server.URL)
+		get(t, client, server.URL)
+
+		assert.Empty(t, delays)
+	})
+
+	t.Run("Success - Passes Through Without Headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		var delays []time.Duration
+		client := &http.Client{Transport: limiter.NewAdaptiveTransport(nil, recordingClock(&delays))}
+
+		for i := 0; i < 3; i++ {
+			get(t, client, server.URL)
+		}
+
+		assert.Empty(t, delays)
+	})
+
+	t.Run("Fail - Cancelled While Waiting For Reset", func(t *testing.T) {
+		server := rateLimitedServer(t, 0, "3600")
+		client := &http.Client{Transport: limiter.NewAdaptiveTransport(nil)}
+		get(t, client, server.URL)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,75 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+)
+
+// TimeoutTransport is an http.RoundTripper that fails any request outlasting
+// a fixed timeout, including reading its body, with apierr.ErrRequestTimeout.
+// Wrapped by Transport and AdaptiveTransport, it starts timing only once a
+// request holds a slot and any rate-limit pause is over, so time spent waiting
+// there does not count against it.
+type TimeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+// NewTimeoutTransport wraps base (http.DefaultTransport when nil) so that each
+// request is aborted after timeout.
+func NewTimeoutTransport(base http.RoundTripper, timeout time.Duration) *TimeoutTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TimeoutTransport{base: base, timeout: timeout}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, t.timedOut(req.Context(), ctx, err)
+	}
+	resp.Body = &timeoutBody{ReadCloser: resp.Body, transport: t, parent: req.Context(), ctx: ctx, cancel: cancel}
+	return resp, nil
+}
+
+// timedOut returns apierr.ErrRequestTimeout in place of err when ctx, the
+// request's own context, expired while parent, the caller's, is still live.
+func (t *TimeoutTransport) timedOut(parent, ctx context.Context, err error) error {
+	if parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", apierr.ErrRequestTimeout, t.timeout)
+	}
+	return err
+}
+
+// timeoutBody reports reads cut short by its request's timeout as such, and
+// releases the timer when closed.
+type timeoutBody struct {
+	io.ReadCloser
+	transport   *TimeoutTransport
+	parent, ctx context.Context
+	cancel      context.CancelFunc
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = b.transport.timedOut(b.parent, b.ctx, err)
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
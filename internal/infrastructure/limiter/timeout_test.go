@@ -0,0 +1,104 @@
+package limiter_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutTransport(t *testing.T) {
+	t.Run("Success - Queueing For A Slot Does Not Count", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(60 * time.Millisecond)
+		}))
+		defer mockServer.Close()
+
+		// Each request takes 60ms of its 100ms timeout, but the second also
+		// queues 60ms for the single slot first.
+		client := &http.Client{Transport: limiter.NewTransport(limiter.NewTimeoutTransport(nil, 100*time.Millisecond), 1, time.Second)}
+		errs := make(chan error, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				resp, err := client.Get(mockServer.URL)
+				if err == nil {
+					_, err = io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				errs <- err
+			}()
+		}
+
+		assert.NoError(t, <-errs)
+		assert.NoError(t, <-errs)
+	})
+
+	t.Run("Fail - Slow Response", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer mockServer.Close()
+		defer close(release)
+		client := &http.Client{Transport: limiter.NewTimeoutTransport(nil, 20*time.Millisecond)}
+
+		_, err := client.Get(mockServer.URL)
+
+		assert.ErrorIs(t, err, apierr.ErrRequestTimeout)
+		assert.True(t, apierr.IsRetryable(err))
+	})
+
+	t.Run("Fail - Slow Body", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":[`))
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer mockServer.Close()
+		defer close(release)
+		client := &http.Client{Transport: limiter.NewTimeoutTransport(nil, 50*time.Millisecond)}
+
+		resp, err := client.Get(mockServer.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, err = io.ReadAll(resp.Body)
+
+		assert.ErrorIs(t, err, apierr.ErrRequestTimeout)
+	})
+
+	t.Run("Fail - Cancelled Caller Is Not A Timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer mockServer.Close()
+		defer close(release)
+		client := &http.Client{Transport: limiter.NewTimeoutTransport(nil, time.Hour)}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, apierr.ErrRequestTimeout)
+	})
+}
@@ -0,0 +1,118 @@
+// Package limiter bounds and paces the requests sent upstream.
+package limiter
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+)
+
+// Transport is an http.RoundTripper allowing at most a fixed number of requests
+// in flight. Further requests queue until a slot frees up; a request that waits
+// longer than the queue timeout fails with a 503 apierr.StatusError, so callers
+// treat it like an overloaded server (it is retryable).
+//
+// A slot is held until the response body is closed, so slow downloads count
+// against the limit.
+type Transport struct {
+	base    http.RoundTripper
+	slots   chan struct{}
+	timeout time.Duration
+
+	mu     sync.Mutex
+	queued int
+	peak   int
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) so that at most max
+// requests run at once, each waiting at most timeout for a slot.
+func NewTransport(base http.RoundTripper, max int, timeout time.Duration) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if max < 1 {
+		max = 1
+	}
+	return &Transport{base: base, slots: make(chan struct{}, max), timeout: timeout}
+}
+
+// QueueDepth returns the number of requests currently waiting for a slot.
+func (t *Transport) QueueDepth() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.queued
+}
+
+// PeakQueueDepth returns the largest queue depth seen so far.
+func (t *Transport) PeakQueueDepth() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.peak
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.acquire(req); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+	return resp, nil
+}
+
+// acquire takes a slot, queueing until one frees up, the queue timeout passes
+// or the request is cancelled.
+func (t *Transport) acquire(req *http.Request) error {
+	select {
+	case t.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	t.mu.Lock()
+	t.queued++
+	if t.queued > t.peak {
+		t.peak = t.queued
+	}
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.queued--
+		t.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(t.timeout)
+	defer timer.Stop()
+	select {
+	case t.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return &apierr.StatusError{StatusCode: http.StatusServiceUnavailable, URL: req.URL.Redacted()}
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func (t *Transport) release() {
+	<-t.slots
+}
+
+// releasingBody frees its request's slot when closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,78 @@
+package limiter_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	t.Run("Success - Limits Concurrent Requests", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}))
+		defer mockServer.Close()
+
+		transport := limiter.NewTransport(nil, 2, time.Second)
+		client := &http.Client{Transport: transport}
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := client.Get(mockServer.URL)
+				if assert.NoError(t, err) {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+		assert.Greater(t, transport.PeakQueueDepth(), 0)
+		assert.Equal(t, 0, transport.QueueDepth())
+	})
+
+	t.Run("Fail - Saturated Queue Returns 503", func(t *testing.T) {
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			<-release
+		}))
+		defer mockServer.Close()
+		defer close(release)
+
+		transport := limiter.NewTransport(nil, 1, 20*time.Millisecond)
+		client := &http.Client{Transport: transport}
+		go client.Get(mockServer.URL)
+		<-started
+
+		_, err := client.Get(mockServer.URL)
+
+		var statusErr *apierr.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+		assert.True(t, apierr.IsRetryable(err))
+		assert.Equal(t, 1, transport.PeakQueueDepth())
+	})
+}
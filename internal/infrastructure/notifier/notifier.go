@@ -0,0 +1,87 @@
+// Package notifier delivers alerts raised by monitoring commands.
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// Notifier delivers a batch of alerts.
+type Notifier interface {
+	Notify(alerts []models.Alert) error
+}
+
+// writerNotifier prints alerts, one per line.
+type writerNotifier struct {
+	w io.Writer
+}
+
+// NewWriterNotifier creates a Notifier that prints alerts to w.
+func NewWriterNotifier(w io.Writer) Notifier {
+	return &writerNotifier{w: w}
+}
+
+// Notify prints each alert.
+func (n *writerNotifier) Notify(alerts []models.Alert) error {
+	for _, alert := range alerts {
+		if _, err := fmt.Fprintf(n.w, "ALERT [%s] %s: %s\n", alert.Kind, alert.CVE, alert.Message); err != nil {
+			return fmt.Errorf("failed to write alert: %w", err)
+		}
+	}
+	return nil
+}
+
+// webhookNotifier posts alerts as JSON to a URL.
+type webhookNotifier struct {
+	url string
+}
+
+// NewWebhookNotifier creates a Notifier that POSTs {"alerts": [...]} to url.
+func NewWebhookNotifier(url string) Notifier {
+	return &webhookNotifier{url: url}
+}
+
+// Notify posts all alerts in a single request.
+func (n *webhookNotifier) Notify(alerts []models.Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(map[string][]models.Alert{"alerts": alerts})
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+	resp, err := http.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post alerts to %s: %w", n.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apierr.StatusError{StatusCode: resp.StatusCode, URL: n.url}
+	}
+	return nil
+}
+
+// multiNotifier fans alerts out to several notifiers.
+type multiNotifier []Notifier
+
+// NewMultiNotifier creates a Notifier that delivers to every notifier in order,
+// stopping at the first error.
+func NewMultiNotifier(notifiers ...Notifier) Notifier {
+	return multiNotifier(notifiers)
+}
+
+// Notify delivers alerts to each notifier.
+func (m multiNotifier) Notify(alerts []models.Alert) error {
+	for _, n := range m {
+		if err := n.Notify(alerts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package notifier_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var alerts = []models.Alert{{CVE: "CVE-2021-44228", Kind: "kev-added", Message: "added to the CISA KEV catalog"}}
+
+func TestWriterNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, notifier.NewWriterNotifier(&buf).Notify(alerts))
+
+	assert.Equal(t, "ALERT [kev-added] CVE-2021-44228: added to the CISA KEV catalog\n", buf.String())
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("Success - Posts Alerts As JSON", func(t *testing.T) {
+		var received map[string][]models.Alert
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		}))
+		defer mockServer.Close()
+
+		require.NoError(t, notifier.NewWebhookNotifier(mockServer.URL).Notify(alerts))
+
+		assert.Equal(t, alerts, received["alerts"])
+	})
+
+	t.Run("Fail - Webhook Error Status", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer mockServer.Close()
+
+		assert.Error(t, notifier.NewWebhookNotifier(mockServer.URL).Notify(alerts))
+	})
+}
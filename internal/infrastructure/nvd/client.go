@@ -0,0 +1,119 @@
+// Package nvd sends paced requests to the NVD CVE API.
+package nvd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+)
+
+// DefaultURL is the NVD CVE API 2.0 endpoint.
+const DefaultURL = "https://services.nvd.nist.gov/rest/json/cves/2.0"
+
+// NVD allows 5 requests per 30 seconds without an API key and 50 with one, so
+// requests are spaced out accordingly.
+const (
+	DefaultInterval    = 6 * time.Second
+	DefaultKeyInterval = 600 * time.Millisecond
+)
+
+// DefaultTimeout bounds each request sent through the default HTTP client.
+const DefaultTimeout = 30 * time.Second
+
+// Client sends requests to the NVD API, spacing them at least its interval
+// apart and passing the API key, if any.
+type Client struct {
+	url      string
+	apiKey   string
+	interval time.Duration
+	client   *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends requests through client instead of the default client,
+// which times out after DefaultTimeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// NewClient creates a Client for the NVD API at url. apiKey may be empty.
+// Requests are spaced at least interval apart.
+func NewClient(url, apiKey string, interval time.Duration, opts ...Option) *Client {
+	c := &Client{url: url, apiKey: apiKey, interval: interval, client: &http.Client{Timeout: DefaultTimeout}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get requests the API with query and returns the response body, first
+// waiting until the interval has passed since the previous request. ctx
+// cancels both the wait and the request.
+func (c *Client) Get(ctx context.Context, query url.Values) ([]byte, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	u := c.url + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NVD URL: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("apiKey", c.apiKey)
+	}
+
+	log.Printf("Fetching NVD data from: %s", u)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch NVD data from %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &apierr.StatusError{StatusCode: resp.StatusCode, URL: u}
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NVD data from %s: %w", u, err)
+	}
+	return data, nil
+}
+
+// wait blocks until interval has passed since the previous request, or until
+// ctx is done. The slot is reserved before waiting, so concurrent callers are
+// spaced out without holding the lock while they sleep.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	at := time.Now()
+	if next := c.last.Add(c.interval); !c.last.IsZero() && next.After(at) {
+		at = next
+	}
+	c.last = at
+	c.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
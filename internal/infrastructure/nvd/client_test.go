@@ -0,0 +1,84 @@
+package nvd_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/nvd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("Success - Sends Query And API Key", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "CVE-2021-44228", r.URL.Query().Get("cveId"))
+			assert.Equal(t, "secret", r.Header.Get("apiKey"))
+			fmt.Fprint(w, `{"totalResults":1}`)
+		}))
+		defer mockServer.Close()
+
+		data, err := nvd.NewClient(mockServer.URL, "secret", 0).Get(context.Background(), url.Values{"cveId": {"CVE-2021-44228"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"totalResults":1}`, string(data))
+	})
+
+	t.Run("Fail - API Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		}))
+		defer mockServer.Close()
+
+		_, err := nvd.NewClient(mockServer.URL, "", 0).Get(context.Background(), url.Values{})
+
+		var statusErr *apierr.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+	})
+
+	t.Run("Fail - Cancelled While Waiting For The Interval", func(t *testing.T) {
+		var calls int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+		}))
+		defer mockServer.Close()
+		client := nvd.NewClient(mockServer.URL, "", time.Hour)
+		_, err := client.Get(context.Background(), url.Values{})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = client.Get(ctx, url.Values{})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Fail - Cancelled Request", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer mockServer.Close()
+		defer close(release)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := nvd.NewClient(mockServer.URL, "", 0).Get(ctx, url.Values{})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,64 @@
+package printer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// hashedIDPrefix marks CVE IDs replaced by WithHashedCVEs.
+const hashedIDPrefix = "anon-"
+
+// errRecordsNotHashable is returned for passthrough records, whose input
+// columns may hold CVE IDs the printer cannot identify.
+var errRecordsNotHashable = errors.New("CVE hashing is not supported when input columns are passed through")
+
+// WithHashedCVEs replaces every CVE ID in the output with a stable pseudonym
+// derived from the ID and salt, keeping scores intact, so distribution data
+// can be shared without disclosing which CVEs it covers. The same ID and salt
+// always give the same pseudonym. Only a secret salt protects the IDs: there
+// are few enough CVEs that unsalted or known-salt hashes can be reversed by
+// hashing every ID.
+func (p *Printer) WithHashedCVEs(salt string) *Printer {
+	p.hashSalt = []byte(salt)
+	return p
+}
+
+// HashCVEID returns the pseudonym WithHashedCVEs gives id under salt: an
+// HMAC-SHA256 of the ID keyed by the salt, truncated to 64 bits.
+func HashCVEID(id string, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(id))
+	return hashedIDPrefix + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// hashID returns the pseudonym of id when hashing is enabled.
+func (p *Printer) hashID(id string) string {
+	if p.hashSalt == nil || id == "" {
+		return id
+	}
+	return HashCVEID(id, string(p.hashSalt))
+}
+
+// hashIDs returns a copy of items with the CVE ID selected by field hashed,
+// or items itself when hashing is disabled.
+func hashIDs[T any](p *Printer, items []T, field func(*T) *string) []T {
+	if p.hashSalt == nil || items == nil {
+		return items
+	}
+	hashed := make([]T, len(items))
+	copy(hashed, items)
+	for i := range hashed {
+		id := field(&hashed[i])
+		*id = p.hashID(*id)
+	}
+	return hashed
+}
+
+// hashCVEs hashes the IDs of cves.
+func (p *Printer) hashCVEs(cves []models.CVE) []models.CVE {
+	return hashIDs(p, cves, func(c *models.CVE) *string { return &c.ID })
+}
@@ -0,0 +1,72 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHashedCVEs(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.1, Percentile: 0.5, Date: "2024-10-18"},
+		{ID: "CVE-2023-0002", EPSSScore: 0.2, Percentile: 0.6, Date: "2024-10-18"},
+	}
+
+	t.Run("Success - IDs Are Replaced And Scores Kept", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithHashedCVEs("salt")
+
+		require.NoError(t, p.PrintCVEs(cves))
+		var got []models.CVE
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+		require.Len(t, got, 2)
+		assert.Equal(t, printer.HashCVEID("CVE-2023-0001", "salt"), got[0].ID)
+		assert.Regexp(t, `^anon-[0-9a-f]{16}$`, got[0].ID)
+		assert.Equal(t, 0.1, got[0].EPSSScore)
+		assert.Equal(t, 0.5, got[0].Percentile)
+		assert.NotContains(t, buf.String(), "CVE-2023")
+		assert.Equal(t, "CVE-2023-0001", cves[0].ID, "input must not be modified")
+	})
+
+	t.Run("Success - Hashes Are Stable Per Salt", func(t *testing.T) {
+		assert.Equal(t, printer.HashCVEID("CVE-2023-0001", "salt"), printer.HashCVEID("CVE-2023-0001", "salt"))
+		assert.NotEqual(t, printer.HashCVEID("CVE-2023-0001", "salt"), printer.HashCVEID("CVE-2023-0001", "other"))
+		assert.NotEqual(t, printer.HashCVEID("CVE-2023-0001", "salt"), printer.HashCVEID("CVE-2023-0002", "salt"))
+	})
+
+	t.Run("Success - Other Result Types Are Hashed", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithHashedCVEs("salt")
+
+		require.NoError(t, p.PrintCVE(&cves[0]))
+		require.NoError(t, p.PrintScoreChanges([]models.ScoreChange{{CVE: "CVE-2023-0001", ScoreChange: 0.1}}))
+		require.NoError(t, p.PrintTrend(models.Trend{CVE: "CVE-2023-0001", Direction: models.TrendStable}))
+		require.NoError(t, p.PrintReportDiff(models.ReportDiff{Added: cves[:1], Changed: []models.CVEDelta{{CVE: "CVE-2023-0002"}}}))
+
+		assert.NotContains(t, buf.String(), "CVE-2023")
+		assert.Contains(t, buf.String(), printer.HashCVEID("CVE-2023-0002", "salt"))
+	})
+
+	t.Run("Success - Off By Default", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintCVEs(cves))
+
+		assert.Contains(t, buf.String(), "CVE-2023-0001")
+	})
+
+	t.Run("Fail - Passthrough Records", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithHashedCVEs("salt")
+
+		err := p.PrintRecords([]string{"cve"}, [][]string{{"CVE-2023-0001"}}, []*models.CVE{&cves[0]})
+
+		assert.Error(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,61 @@
+package printer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// WithChunkedJSON makes PrintCVEs build one JSON array across calls in JSON
+// output, writing each call's CVEs as they arrive instead of holding the
+// whole result, so paged fetches stream with bounded memory. The bytes match
+// the array a single PrintCVEs call would write. Finish closes the array.
+func (p *Printer) WithChunkedJSON() *Printer {
+	p.chunked = true
+	return p
+}
+
+// Finish completes output spread over several calls. In chunked JSON mode it
+// writes the closing bracket, or an empty array when PrintCVEs was called
+// without CVEs. It does nothing otherwise and may be called more than once.
+func (p *Printer) Finish() error {
+	if !p.chunkOpen {
+		return nil
+	}
+	p.chunkOpen = false
+	closing := "\n]\n"
+	if p.chunkItems == 0 {
+		closing = "[]\n"
+	}
+	if _, err := fmt.Fprint(p.w, closing); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// writeChunkedCVEs appends cves to the open JSON array, flushing once per call.
+func (p *Printer) writeChunkedCVEs(cves []models.CVE) error {
+	p.chunkOpen = true
+	w := bufio.NewWriter(p.w)
+	for _, cve := range cves {
+		data, err := json.MarshalIndent(cve, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		// The separator goes before each item after the first, so the array
+		// never ends with a trailing comma however the CVEs were split.
+		if p.chunkItems == 0 {
+			w.WriteString("[\n  ")
+		} else {
+			w.WriteString(",\n  ")
+		}
+		w.Write(data)
+		p.chunkItems++
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithChunkedJSON(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.1, Percentile: 0.5, Date: "2024-10-18"},
+		{ID: "CVE-2023-0002", EPSSScore: 0.2, Percentile: 0.6, Date: "2024-10-18"},
+		{ID: "CVE-2023-0003", EPSSScore: 0.3, Percentile: 0.7, Date: "2024-10-18"},
+	}
+
+	t.Run("Success - Chunks Form One Valid Array", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithChunkedJSON()
+
+		require.NoError(t, p.PrintCVEs(cves[:1]))
+		require.NoError(t, p.PrintCVEs(nil))
+		require.NoError(t, p.PrintCVEs(cves[1:]))
+		require.NoError(t, p.Finish())
+
+		require.True(t, json.Valid(buf.Bytes()), buf.String())
+		var decoded []models.CVE
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, cves, decoded)
+	})
+
+	t.Run("Success - Matches Unchunked Output", func(t *testing.T) {
+		var chunked, whole bytes.Buffer
+		p := printer.New(&chunked, printer.FormatJSON).WithChunkedJSON()
+		require.NoError(t, p.PrintCVEs(cves[:2]))
+		require.NoError(t, p.PrintCVEs(cves[2:]))
+		require.NoError(t, p.Finish())
+
+		require.NoError(t, printer.New(&whole, printer.FormatJSON).PrintCVEs(cves))
+
+		assert.Equal(t, whole.String(), chunked.String())
+	})
+
+	t.Run("Success - Empty Result Is Empty Array", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithChunkedJSON()
+
+		require.NoError(t, p.PrintCVEs(nil))
+		require.NoError(t, p.Finish())
+		require.NoError(t, p.Finish())
+
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("Success - Nothing Written Without A List", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithChunkedJSON()
+
+		require.NoError(t, p.Finish())
+
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,19 @@
+package printer
+
+import "encoding/csv"
+
+// csvCVEFields is the default long-format column order for CSV output.
+var csvCVEFields = []string{"cve", "date", "epss", "percentile"}
+
+// writeCSVTable writes rows as CSV. The header is only written before the
+// first rows, so output streamed across several calls forms one table.
+func (p *Printer) writeCSVTable(header []string, rows [][]string) error {
+	w := csv.NewWriter(p.w)
+	if !p.csvHeaderWritten {
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		p.csvHeaderWritten = true
+	}
+	return w.WriteAll(rows)
+}
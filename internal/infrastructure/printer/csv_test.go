@@ -0,0 +1,42 @@
+package printer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVOutput(t *testing.T) {
+	t.Run("Success - Streamed Rows Share One Header", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatCSV)
+
+		require.NoError(t, p.PrintCVEs([]models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.000443, Percentile: 0.13, Date: "2024-10-17"}}))
+		require.NoError(t, p.PrintCVEs([]models.CVE{{ID: "CVE-2023-0002", EPSSScore: 0.9, Percentile: 0.99, Date: "2024-10-17"}}))
+
+		assert.Equal(t, "cve,date,epss,percentile\n"+
+			"CVE-2023-0001,2024-10-17,0.000443,0.13\n"+
+			"CVE-2023-0002,2024-10-17,0.9,0.99\n", buf.String())
+	})
+
+	t.Run("Success - Fields Select Columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatCSV).WithFields([]string{"cve", "epss"})
+
+		require.NoError(t, p.PrintCVEs([]models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.5}}))
+
+		assert.Equal(t, "cve,epss\nCVE-2023-0001,0.5\n", buf.String())
+	})
+
+	t.Run("Success - Empty Result Writes Header", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintCVEs(nil))
+
+		assert.Equal(t, "cve,date,epss,percentile\n", buf.String())
+	})
+}
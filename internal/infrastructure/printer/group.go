@@ -0,0 +1,72 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// GroupKey selects how CVE lists are grouped in text and JSON output.
+type GroupKey string
+
+const (
+	// GroupNone prints a flat list.
+	GroupNone GroupKey = ""
+	// GroupDate separates results by their data date.
+	GroupDate GroupKey = "date"
+)
+
+// ParseGroupKey validates a grouping key name. An empty name disables grouping.
+func ParseGroupKey(s string) (GroupKey, error) {
+	switch GroupKey(s) {
+	case GroupNone, GroupDate:
+		return GroupKey(s), nil
+	default:
+		return "", fmt.Errorf("unsupported grouping: %s (expected date)", s)
+	}
+}
+
+// WithGrouping groups CVE lists by key: text output prints a "== key =="
+// header before each group and JSON output becomes an object keyed by it.
+// Tabular formats stay flat.
+func (p *Printer) WithGrouping(key GroupKey) *Printer {
+	p.group = key
+	return p
+}
+
+// groupValue returns the value of the grouping key for cve.
+func (p *Printer) groupValue(cve models.CVE) string {
+	return cve.Date
+}
+
+// groupCVEs splits cves by the grouping key, keeping groups in order of first
+// appearance and rows in input order within each group.
+func (p *Printer) groupCVEs(cves []models.CVE) (keys []string, groups map[string][]models.CVE) {
+	groups = make(map[string][]models.CVE)
+	for _, cve := range cves {
+		key := p.groupValue(cve)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], cve)
+	}
+	return keys, groups
+}
+
+// writeGroupedTextCVEs writes cves under a header per group. A group
+// continuing the one the previous call ended with gets no second header, so
+// results printed one batch at a time read as a single list.
+func (p *Printer) writeGroupedTextCVEs(cves []models.CVE) {
+	keys, groups := p.groupCVEs(cves)
+	for _, key := range keys {
+		if !p.groupStarted || key != p.lastGroup {
+			if p.groupStarted {
+				fmt.Fprintln(p.w)
+			}
+			fmt.Fprintf(p.w, "== %s ==\n", key)
+			p.groupStarted = true
+			p.lastGroup = key
+		}
+		p.writeTextCVEs(groups[key])
+	}
+}
@@ -0,0 +1,75 @@
+package printer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithGrouping(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.1, Percentile: 0.5, Date: "2024-10-17"},
+		{ID: "CVE-2023-0002", EPSSScore: 0.2, Percentile: 0.6, Date: "2024-10-18"},
+		{ID: "CVE-2023-0003", EPSSScore: 0.3, Percentile: 0.7, Date: "2024-10-17"},
+	}
+
+	t.Run("Success - Text Output Has A Header Per Date", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithGrouping(printer.GroupDate)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.Equal(t, "== 2024-10-17 ==\n"+
+			"CVE ID: CVE-2023-0001, EPSS Score: 0.100000, Percentile: 0.500000, Date: 2024-10-17\n"+
+			"CVE ID: CVE-2023-0003, EPSS Score: 0.300000, Percentile: 0.700000, Date: 2024-10-17\n"+
+			"\n== 2024-10-18 ==\n"+
+			"CVE ID: CVE-2023-0002, EPSS Score: 0.200000, Percentile: 0.600000, Date: 2024-10-18\n", buf.String())
+	})
+
+	t.Run("Success - Batches Of The Same Date Share A Header", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithGrouping(printer.GroupDate)
+
+		require.NoError(t, p.PrintCVEs(cves[:1]))
+		require.NoError(t, p.PrintCVEs(cves[2:]))
+		require.NoError(t, p.PrintCVEs(cves[1:2]))
+
+		assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("==\n")))
+	})
+
+	t.Run("Success - JSON Output Is Keyed By Date", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithGrouping(printer.GroupDate)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.JSONEq(t, `{
+			"2024-10-17": [
+				{"cve":"CVE-2023-0001","epss":0.1,"percentile":0.5,"date":"2024-10-17"},
+				{"cve":"CVE-2023-0003","epss":0.3,"percentile":0.7,"date":"2024-10-17"}
+			],
+			"2024-10-18": [
+				{"cve":"CVE-2023-0002","epss":0.2,"percentile":0.6,"date":"2024-10-18"}
+			]
+		}`, buf.String())
+	})
+
+	t.Run("Success - Flat By Default", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.NotContains(t, buf.String(), "==")
+	})
+
+	t.Run("Fail - Unknown Key", func(t *testing.T) {
+		_, err := printer.ParseGroupKey("cve")
+
+		assert.EqualError(t, err, "unsupported grouping: cve (expected date)")
+	})
+}
@@ -0,0 +1,61 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Locale holds the separators used to write numbers for a language. The zero
+// Locale leaves numbers in their canonical form.
+type Locale struct {
+	Decimal string
+	Group   string
+}
+
+// ParseLocale returns the number separators of a BCP 47 language tag such as
+// "en", "de-DE" or "fr". POSIX-style names like "de_DE.UTF-8" are accepted too.
+func ParseLocale(name string) (Locale, error) {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	tag, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+	if err != nil {
+		return Locale{}, fmt.Errorf("unsupported locale: %s", name)
+	}
+	// Probe the locale's formatting of a known number to learn its separators.
+	probe := message.NewPrinter(tag).Sprintf("%.1f", 1234.5)
+	i1, i2 := strings.Index(probe, "1"), strings.Index(probe, "2")
+	i4, i5 := strings.Index(probe, "4"), strings.Index(probe, "5")
+	if i1 < 0 || i2 < i1 || i4 < 0 || i5 < i4 {
+		return Locale{}, fmt.Errorf("unsupported locale: %s", name)
+	}
+	return Locale{Group: probe[i1+1 : i2], Decimal: probe[i4+1 : i5]}, nil
+}
+
+// apply rewrites a canonical decimal such as "-1234.5" with the locale's separators.
+func (l Locale) apply(s string) string {
+	if l.Decimal == "" {
+		return s
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFrac {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package printer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocale(t *testing.T) {
+	t.Run("Success - Separators", func(t *testing.T) {
+		for name, want := range map[string]printer.Locale{
+			"en":          {Decimal: ".", Group: ","},
+			"de-DE":       {Decimal: ",", Group: "."},
+			"de_DE.UTF-8": {Decimal: ",", Group: "."},
+			"pt-BR":       {Decimal: ",", Group: "."},
+		} {
+			locale, err := printer.ParseLocale(name)
+			require.NoError(t, err, name)
+			assert.Equal(t, want, locale, name)
+		}
+	})
+
+	t.Run("Fail - Invalid Tag", func(t *testing.T) {
+		_, err := printer.ParseLocale("not a locale")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWithLocale(t *testing.T) {
+	german, err := printer.ParseLocale("de")
+	require.NoError(t, err)
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.97531, Percentile: 0.99, Date: "2024-10-18"}}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).WithLocale(german).PrintCVEs(cves))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, EPSS Score: 0,975310, Percentile: 0,990000, Date: 2024-10-18\n", buf.String())
+	})
+
+	t.Run("Success - Thousands Are Grouped", func(t *testing.T) {
+		var buf bytes.Buffer
+		summaries := []models.YearSummary{{Year: 2023, Count: 2, MeanEPSS: -1234567.5}}
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).WithLocale(german).PrintYearSummaries(summaries))
+
+		assert.Equal(t, "year,count,mean_epss\n2023,2,\"-1.234.567,5\"\n", buf.String())
+	})
+
+	t.Run("Success - JSON Stays Canonical", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).WithLocale(german).PrintCVEs(cves))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","epss":0.97531,"percentile":0.99,"date":"2024-10-18"}]`, buf.String())
+	})
+}
@@ -0,0 +1,150 @@
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// cveFields lists the selectable CVE fields in their default order.
+var cveFields = []string{"cve", "epss", "percentile", "date"}
+
+// ParseFields validates a comma-separated list of CVE field names.
+func ParseFields(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	var fields []string
+	for _, f := range strings.Split(list, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		switch f {
+		case "cve", "epss", "percentile", "date", "rank", "total", "normalized", "source", "age_days", "stale":
+			fields = append(fields, f)
+		default:
+			return nil, fmt.Errorf("unsupported field: %s (expected cve, epss, percentile, date, rank, total, normalized, source, age_days or stale)", f)
+		}
+	}
+	return fields, nil
+}
+
+// WithFields selects and orders the CVE columns in tabular output.
+func (p *Printer) WithFields(fields []string) *Printer {
+	p.fields = fields
+	return p
+}
+
+// columns returns the CVE fields to print.
+func (p *Printer) columns() []string {
+	if len(p.fields) > 0 {
+		return p.fields
+	}
+	columns := cveFields
+	if p.format == FormatCSV {
+		columns = csvCVEFields
+	}
+	if p.showSource {
+		columns = append(columns[:len(columns):len(columns)], "source")
+	}
+	return columns
+}
+
+// cveValue formats one field of a CVE for tabular output.
+func (p *Printer) cveValue(cve models.CVE, field string) string {
+	switch field {
+	case "cve":
+		return cve.ID
+	case "epss":
+		return p.score(cve.EPSSScore, cve.RawEPSS)
+	case "percentile":
+		return p.score(cve.Percentile, cve.RawPercentile)
+	case "date":
+		return cve.Date
+	case "rank":
+		if cve.Total == 0 {
+			return ""
+		}
+		return strconv.Itoa(cve.Rank)
+	case "total":
+		if cve.Total == 0 {
+			return ""
+		}
+		return strconv.Itoa(cve.Total)
+	case "normalized":
+		if cve.Normalized == nil {
+			return ""
+		}
+		return p.num(*cve.Normalized)
+	case "source":
+		return cve.Source
+	case "age_days":
+		if cve.AgeDays == nil {
+			return ""
+		}
+		return strconv.Itoa(*cve.AgeDays)
+	case "stale":
+		if cve.Stale == nil {
+			return ""
+		}
+		return strconv.FormatBool(*cve.Stale)
+	default:
+		return ""
+	}
+}
+
+// tabular reports whether the output format is a table.
+func (p *Printer) tabular() bool {
+	return p.format == FormatMarkdown || p.format == FormatCSV
+}
+
+// writeTable writes rows as a table in the output format.
+func (p *Printer) writeTable(header []string, rows [][]string) error {
+	if p.format == FormatCSV {
+		return p.writeCSVTable(header, rows)
+	}
+	return p.writeMarkdownTable(header, rows)
+}
+
+// writeTableCVEs writes cves as a table with the selected columns.
+func (p *Printer) writeTableCVEs(cves []models.CVE) error {
+	columns := p.columns()
+	rows := make([][]string, len(cves))
+	for i, cve := range cves {
+		rows[i] = make([]string, len(columns))
+		for j, field := range columns {
+			rows[i][j] = p.cveValue(cve, field)
+		}
+	}
+	return p.writeTable(columns, rows)
+}
+
+// writeMarkdownTable writes a Markdown table with a header separator row.
+func (p *Printer) writeMarkdownTable(header []string, rows [][]string) error {
+	writeRow := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = escapeMarkdownCell(cell)
+		}
+		fmt.Fprintf(p.w, "| %s |\n", strings.Join(escaped, " | "))
+	}
+
+	writeRow(header)
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	fmt.Fprintf(p.w, "|%s|\n", strings.Join(separator, "|"))
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return nil
+}
+
+// escapeMarkdownCell escapes pipes and flattens newlines so a value stays in its cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,46 @@
+package printer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownOutput(t *testing.T) {
+	cves := []models.CVE{
+		{ID: "CVE-2023-0001", EPSSScore: 0.5, Percentile: 0.9, Date: "2024-10-18"},
+		{ID: "CVE-2023-|0002", EPSSScore: 0.25, Percentile: 0.8, Date: "2024-10-18"},
+	}
+
+	t.Run("Success - Table With Header Separator", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatMarkdown).WithRounding(2, printer.RoundHalfUp)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.Equal(t, "| cve | epss | percentile | date |\n"+
+			"|---|---|---|---|\n"+
+			"| CVE-2023-0001 | 0.50 | 0.90 | 2024-10-18 |\n"+
+			"| CVE-2023-\\|0002 | 0.25 | 0.80 | 2024-10-18 |\n", buf.String())
+	})
+
+	t.Run("Success - Selected Fields", func(t *testing.T) {
+		fields, err := printer.ParseFields("epss, cve")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatMarkdown).WithRounding(2, printer.RoundHalfUp).WithFields(fields)
+
+		require.NoError(t, p.PrintCVEs(cves[:1]))
+
+		assert.Equal(t, "| epss | cve |\n|---|---|\n| 0.50 | CVE-2023-0001 |\n", buf.String())
+	})
+
+	t.Run("Fail - Unknown Field", func(t *testing.T) {
+		_, err := printer.ParseFields("cve,cvss")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,880 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// Format selects how results are rendered.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+	FormatCSV      Format = "csv"
+)
+
+// ParseFormat validates an output format name. "table" names the default
+// human-readable text format.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatText, FormatJSON, FormatMarkdown, FormatCSV:
+		return Format(s), nil
+	case "table":
+		return FormatText, nil
+	default:
+		return "", fmt.Errorf("unsupported output format: %s", s)
+	}
+}
+
+// Metadata describes how a result set was produced. It is emitted alongside
+// the data when JSON output is wrapped in an envelope.
+type Metadata struct {
+	Command     string            `json:"command"`
+	Parameters  map[string]string `json:"parameters"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Version     string            `json:"version"`
+	BaseURL     string            `json:"base_url"`
+	Count       int               `json:"count"`
+
+	// UpstreamQueries lists the query parameters sent to the API, in order,
+	// with credentials redacted. It is filled in by the printer when a source
+	// was configured with WithUpstreamQueries.
+	UpstreamQueries []map[string]string `json:"upstream_queries,omitempty"`
+}
+
+// envelope wraps JSON results together with their metadata.
+type envelope struct {
+	Meta Metadata    `json:"meta"`
+	Data interface{} `json:"data"`
+}
+
+// Printer renders EPSS results to a writer in the configured format.
+type Printer struct {
+	w          io.Writer
+	format     Format
+	meta       *Metadata
+	upstream   func() []map[string]string
+	precision  int
+	rounding   RoundingMode
+	fields     []string
+	locale     Locale
+	sciBelow   float64
+	hashSalt   []byte
+	group      GroupKey
+	showSource bool
+
+	// lastGroup is the group the previous grouped text output ended with.
+	lastGroup    string
+	groupStarted bool
+
+	// csvHeaderWritten makes streamed CSV output a single table.
+	csvHeaderWritten bool
+
+	// chunked streams JSON CVE lists as one array; chunkOpen records that the
+	// array was started and chunkItems how many CVEs it holds.
+	chunked    bool
+	chunkOpen  bool
+	chunkItems int
+
+	// rows counts the result rows passed to the list printers; listed records
+	// that one was called, telling an empty result apart from a non-list command.
+	rows   int
+	listed bool
+}
+
+// Results returns the number of result rows printed so far and whether any
+// result list was printed at all.
+func (p *Printer) Results() (rows int, listed bool) {
+	return p.rows, p.listed
+}
+
+// count records that a result list of n rows is being printed.
+func (p *Printer) count(n int) {
+	p.rows += n
+	p.listed = true
+}
+
+// New creates a Printer writing to w.
+func New(w io.Writer, format Format) *Printer {
+	return &Printer{w: w, format: format, precision: defaultPrecision, rounding: RoundHalfUp}
+}
+
+// WithRounding sets the number of decimals and rounding mode used for scores
+// in text output. JSON output always keeps full precision.
+func (p *Printer) WithRounding(precision int, mode RoundingMode) *Printer {
+	p.precision = precision
+	p.rounding = mode
+	return p
+}
+
+// WithLocale writes numbers in text and tabular output with the separators of l.
+// JSON output stays canonical.
+func (p *Printer) WithLocale(l Locale) *Printer {
+	p.locale = l
+	return p
+}
+
+// num formats a score for text output. CSV output keeps full precision.
+func (p *Printer) num(v float64) string {
+	if p.format == FormatCSV {
+		return p.locale.apply(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	if v != 0 && math.Abs(v) < p.sciBelow {
+		return p.formatScientific(v)
+	}
+	return p.locale.apply(formatDecimal(v, p.precision, p.rounding))
+}
+
+// score formats a score, preferring its text as sent by the API when kept.
+func (p *Printer) score(v float64, raw string) string {
+	if raw != "" {
+		return raw
+	}
+	return p.num(v)
+}
+
+// WithMetadata makes JSON output an envelope holding meta and a data array
+// instead of a bare array. Count is filled in by the printer.
+func (p *Printer) WithMetadata(meta Metadata) *Printer {
+	p.meta = &meta
+	return p
+}
+
+// WithUpstreamQueries fills in the metadata's UpstreamQueries from queries
+// whenever an envelope is written, so it covers every query made so far.
+func (p *Printer) WithUpstreamQueries(queries func() []map[string]string) *Printer {
+	p.upstream = queries
+	return p
+}
+
+// WithSource adds the source that answered each CVE (see models.CVE.Source) to
+// text and tabular output. JSON output includes it whenever it is known.
+func (p *Printer) WithSource() *Printer {
+	p.showSource = true
+	return p
+}
+
+// PrintCVE prints a single CVE in detail.
+func (p *Printer) PrintCVE(cve *models.CVE) error {
+	if p.hashSalt != nil {
+		hashed := *cve
+		hashed.ID = p.hashID(cve.ID)
+		cve = &hashed
+	}
+	p.count(1)
+	if p.format == FormatJSON {
+		if p.meta != nil {
+			return p.writeEnvelope([]models.CVE{*cve}, 1)
+		}
+		return p.writeJSON(cve)
+	}
+	if p.tabular() {
+		return p.writeTableCVEs([]models.CVE{*cve})
+	}
+	fmt.Fprintf(p.w, "CVE ID: %s\n", cve.ID)
+	fmt.Fprintf(p.w, "EPSS Score: %s\n", p.score(cve.EPSSScore, cve.RawEPSS))
+	fmt.Fprintf(p.w, "Percentile: %s\n", p.score(cve.Percentile, cve.RawPercentile))
+	fmt.Fprintf(p.w, "Date: %s\n", cve.Date)
+	if cve.Total > 0 {
+		fmt.Fprintf(p.w, "Rank: ~#%d of %d\n", cve.Rank, cve.Total)
+	}
+	if cve.Normalized != nil {
+		fmt.Fprintf(p.w, "Normalized: %s (approximate)\n", p.num(*cve.Normalized))
+	}
+	if p.showSource && cve.Source != "" {
+		fmt.Fprintf(p.w, "Source: %s\n", cve.Source)
+	}
+	if cve.AgeDays != nil {
+		fmt.Fprintf(p.w, "Age: %s\n", ageText(*cve))
+	}
+	return nil
+}
+
+// ageText describes the age of a CVE's data, noting when it is stale.
+func ageText(cve models.CVE) string {
+	text := fmt.Sprintf("%d days", *cve.AgeDays)
+	if cve.Stale != nil && *cve.Stale {
+		text += " (stale)"
+	}
+	return text
+}
+
+// PrintExplanation prints a plain-language note in text output. Structured
+// formats omit it so their output stays machine-readable.
+func (p *Printer) PrintExplanation(text string) error {
+	if p.format != FormatText {
+		return nil
+	}
+	_, err := fmt.Fprintln(p.w, text)
+	return err
+}
+
+// PrintCVEs prints a list of CVEs, one per line in text mode.
+func (p *Printer) PrintCVEs(cves []models.CVE) error {
+	cves = p.hashCVEs(cves)
+	p.count(len(cves))
+	if p.format == FormatJSON {
+		if p.chunked {
+			return p.writeChunkedCVEs(cves)
+		}
+		if p.group != GroupNone {
+			_, groups := p.groupCVEs(cves)
+			return p.writeEnvelope(groups, len(cves))
+		}
+		return p.writeEnvelope(nonNil(cves), len(cves))
+	}
+	if p.tabular() {
+		return p.writeTableCVEs(cves)
+	}
+	if p.group != GroupNone {
+		p.writeGroupedTextCVEs(cves)
+		return nil
+	}
+	p.writeTextCVEs(cves)
+	return nil
+}
+
+// writeTextCVEs writes cves one per line.
+func (p *Printer) writeTextCVEs(cves []models.CVE) {
+	for _, cve := range cves {
+		fmt.Fprintf(p.w, "CVE ID: %s, EPSS Score: %s, Percentile: %s, Date: %s", cve.ID, p.score(cve.EPSSScore, cve.RawEPSS), p.score(cve.Percentile, cve.RawPercentile), cve.Date)
+		if cve.Total > 0 {
+			fmt.Fprintf(p.w, ", Rank: ~#%d of %d", cve.Rank, cve.Total)
+		}
+		if cve.Normalized != nil {
+			fmt.Fprintf(p.w, ", Normalized: %s (approximate)", p.num(*cve.Normalized))
+		}
+		if p.showSource && cve.Source != "" {
+			fmt.Fprintf(p.w, ", Source: %s", cve.Source)
+		}
+		if cve.AgeDays != nil {
+			fmt.Fprintf(p.w, ", Age: %s", ageText(cve))
+		}
+		fmt.Fprintln(p.w)
+	}
+}
+
+// PrintRecords prints input rows with the EPSS score of each row's CVE appended,
+// passing the other input columns through. scores is aligned with rows and holds
+// nil for CVEs without a score.
+func (p *Printer) PrintRecords(header []string, rows [][]string, scores []*models.CVE) error {
+	if p.hashSalt != nil {
+		return errRecordsNotHashable
+	}
+	p.count(len(rows))
+	if p.format == FormatJSON {
+		records := make([]map[string]interface{}, len(rows))
+		for i, row := range rows {
+			record := make(map[string]interface{}, len(header)+3)
+			for j, name := range header {
+				record[name] = row[j]
+			}
+			record["epss"], record["percentile"], record["date"] = nil, nil, nil
+			if cve := scores[i]; cve != nil {
+				record["epss"], record["percentile"], record["date"] = cve.EPSSScore, cve.Percentile, cve.Date
+			}
+			records[i] = record
+		}
+		return p.writeEnvelope(records, len(records))
+	}
+
+	cells := func(cve *models.CVE, missing string) []string {
+		if cve == nil {
+			return []string{missing, missing, missing}
+		}
+		return []string{p.score(cve.EPSSScore, cve.RawEPSS), p.score(cve.Percentile, cve.RawPercentile), cve.Date}
+	}
+	if p.tabular() {
+		table := make([][]string, len(rows))
+		for i, row := range rows {
+			table[i] = append(append([]string(nil), row...), cells(scores[i], "")...)
+		}
+		return p.writeTable(append(append([]string(nil), header...), "epss", "percentile", "date"), table)
+	}
+	for i, row := range rows {
+		for j, name := range header {
+			fmt.Fprintf(p.w, "%s: %s, ", name, row[j])
+		}
+		values := cells(scores[i], "n/a")
+		fmt.Fprintf(p.w, "EPSS Score: %s, Percentile: %s, Date: %s\n", values[0], values[1], values[2])
+	}
+	return nil
+}
+
+// PrintBatchResult prints the scores of a batch run followed by the CVEs that
+// failed. JSON output keeps both lists so a later run can retry the failures;
+// tables hold only the scores.
+func (p *Printer) PrintBatchResult(r models.BatchResult) error {
+	r.Results = p.hashCVEs(r.Results)
+	r.Failed = hashIDs(p, r.Failed, func(f *models.BatchFailure) *string { return &f.CVE })
+	p.count(len(r.Results))
+	if p.format == FormatJSON {
+		r.Results, r.Failed = nonNil(r.Results), nonNil(r.Failed)
+		return p.writeEnvelope(r, len(r.Results))
+	}
+	if p.tabular() {
+		return p.writeTableCVEs(r.Results)
+	}
+	p.writeTextCVEs(r.Results)
+	for _, f := range r.Failed {
+		fmt.Fprintf(p.w, "CVE ID: %s, Failed: %s\n", f.CVE, f.Error)
+	}
+	return nil
+}
+
+// PrintCWEResult prints the scored CVEs of a CWE under a header naming it,
+// followed by the CVEs that failed. Tables name the CWE in a leading column
+// and list the failures as rows with only the cve and error cells filled.
+func (p *Printer) PrintCWEResult(r models.CWEResult) error {
+	r.CVEs = p.hashCVEs(r.CVEs)
+	r.Failed = hashIDs(p, r.Failed, func(f *models.BatchFailure) *string { return &f.CVE })
+	p.count(len(r.CVEs))
+	if p.format == FormatJSON {
+		r.CVEs, r.Failed = nonNil(r.CVEs), nonNil(r.Failed)
+		return p.writeEnvelope(r, len(r.CVEs))
+	}
+	if p.tabular() {
+		columns := p.columns()
+		rows := make([][]string, 0, len(r.CVEs)+len(r.Failed))
+		for _, cve := range r.CVEs {
+			row := []string{r.CWE}
+			for _, field := range columns {
+				row = append(row, p.cveValue(cve, field))
+			}
+			rows = append(rows, append(row, ""))
+		}
+		for _, f := range r.Failed {
+			row := []string{r.CWE}
+			for _, field := range columns {
+				cell := ""
+				if field == "cve" {
+					cell = f.CVE
+				}
+				row = append(row, cell)
+			}
+			rows = append(rows, append(row, f.Error))
+		}
+		header := append(append([]string{"cwe"}, columns...), "error")
+		return p.writeTable(header, rows)
+	}
+	fmt.Fprintf(p.w, "== %s (%d CVEs) ==\n", r.CWE, len(r.CVEs))
+	p.writeTextCVEs(r.CVEs)
+	for _, f := range r.Failed {
+		fmt.Fprintf(p.w, "CVE ID: %s, Failed: %s\n", f.CVE, f.Error)
+	}
+	return nil
+}
+
+// PrintScoreChanges prints a list of score changes, one per line in text mode.
+func (p *Printer) PrintScoreChanges(changes []models.ScoreChange) error {
+	changes = hashIDs(p, changes, func(c *models.ScoreChange) *string { return &c.CVE })
+	p.count(len(changes))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(nonNil(changes), len(changes))
+	}
+	if p.tabular() {
+		rows := make([][]string, len(changes))
+		for i, change := range changes {
+			rows[i] = []string{change.CVE, change.Date.Format("2006-01-02"), p.num(change.ScoreChange)}
+		}
+		return p.writeTable([]string{"cve", "date", "score_change"}, rows)
+	}
+	for _, change := range changes {
+		fmt.Fprintf(p.w, "CVE ID: %s, Date: %s, Score Change: %s\n", change.CVE, change.Date, p.num(change.ScoreChange))
+	}
+	return nil
+}
+
+// PrintPercentileMovers prints percentile changes alongside the matching EPSS changes.
+func (p *Printer) PrintPercentileMovers(changes []models.ScoreChange) error {
+	changes = hashIDs(p, changes, func(c *models.ScoreChange) *string { return &c.CVE })
+	p.count(len(changes))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(nonNil(changes), len(changes))
+	}
+	if p.tabular() {
+		rows := make([][]string, len(changes))
+		for i, change := range changes {
+			rows[i] = []string{change.CVE, change.Date.Format("2006-01-02"), p.num(change.PercentileChange), p.num(change.ScoreChange)}
+		}
+		return p.writeTable([]string{"cve", "date", "percentile_change", "score_change"}, rows)
+	}
+	for _, change := range changes {
+		fmt.Fprintf(p.w, "CVE ID: %s, Date: %s, Percentile Change: %s, Score Change: %s\n",
+			change.CVE, change.Date.Format("2006-01-02"), p.num(change.PercentileChange), p.num(change.ScoreChange))
+	}
+	return nil
+}
+
+// PrintFindings prints scanner findings with their EPSS scores. Findings whose CVE
+// has no score show n/a in text output and empty cells in tables.
+func (p *Printer) PrintFindings(findings []models.Finding) error {
+	findings = hashIDs(p, findings, func(f *models.Finding) *string { return &f.CVE })
+	p.count(len(findings))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(nonNil(findings), len(findings))
+	}
+	score := func(v *float64, missing string) string {
+		if v == nil {
+			return missing
+		}
+		return p.num(*v)
+	}
+	if p.tabular() {
+		rows := make([][]string, len(findings))
+		for i, f := range findings {
+			rows[i] = []string{f.CVE, f.Package, f.Version, f.Artifact, score(f.EPSSScore, ""), score(f.Percentile, ""), f.Date}
+		}
+		return p.writeTable([]string{"cve", "package", "version", "artifact", "epss", "percentile", "date"}, rows)
+	}
+	for _, f := range findings {
+		fmt.Fprintf(p.w, "CVE ID: %s, Package: %s %s, Artifact: %s, EPSS Score: %s, Percentile: %s\n",
+			f.CVE, f.Package, f.Version, f.Artifact, score(f.EPSSScore, "n/a"), score(f.Percentile, "n/a"))
+	}
+	return nil
+}
+
+// PrintComponents prints per-component summaries of scanner findings, in rank order.
+func (p *Printer) PrintComponents(components []models.ComponentSummary) error {
+	components = hashIDs(p, components, func(c *models.ComponentSummary) *string { return &c.TopCVE })
+	p.count(len(components))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(nonNil(components), len(components))
+	}
+	maxEPSS := func(c models.ComponentSummary, missing string) string {
+		if c.MaxEPSS == nil {
+			return missing
+		}
+		return p.num(*c.MaxEPSS)
+	}
+	if p.tabular() {
+		rows := make([][]string, len(components))
+		for i, c := range components {
+			rows[i] = []string{c.Package, c.Version, strconv.Itoa(c.CVECount), maxEPSS(c, ""), c.TopCVE, strconv.FormatBool(c.InKEV)}
+		}
+		return p.writeTable([]string{"package", "version", "cve_count", "max_epss", "top_cve", "in_kev"}, rows)
+	}
+	for _, c := range components {
+		fmt.Fprintf(p.w, "Package: %s %s, CVEs: %d, Max EPSS: %s", c.Package, c.Version, c.CVECount, maxEPSS(c, "n/a"))
+		if c.TopCVE != "" {
+			fmt.Fprintf(p.w, " (%s)", c.TopCVE)
+		}
+		if c.InKEV {
+			fmt.Fprint(p.w, ", In KEV")
+		}
+		fmt.Fprintln(p.w)
+	}
+	return nil
+}
+
+// PrintYearSummaries prints per-year aggregates as a table, or as a JSON
+// object keyed by year.
+func (p *Printer) PrintYearSummaries(summaries []models.YearSummary) error {
+	p.count(len(summaries))
+	if p.format == FormatJSON {
+		byYear := make(map[string]models.YearSummary, len(summaries))
+		for _, s := range summaries {
+			byYear[strconv.Itoa(s.Year)] = s
+		}
+		return p.writeEnvelope(byYear, len(summaries))
+	}
+	if p.tabular() {
+		rows := make([][]string, len(summaries))
+		for i, s := range summaries {
+			rows[i] = []string{strconv.Itoa(s.Year), strconv.Itoa(s.Count), p.num(s.MeanEPSS)}
+		}
+		return p.writeTable([]string{"year", "count", "mean_epss"}, rows)
+	}
+	fmt.Fprintf(p.w, "%-6s %8s %10s\n", "Year", "Count", "Mean EPSS")
+	for _, s := range summaries {
+		fmt.Fprintf(p.w, "%-6d %8d %10s\n", s.Year, s.Count, p.num(s.MeanEPSS))
+	}
+	return nil
+}
+
+// PrintThresholdCounts prints how many CVEs exceed each threshold.
+func (p *Printer) PrintThresholdCounts(counts []models.ThresholdCount) error {
+	p.count(len(counts))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(nonNil(counts), len(counts))
+	}
+	if p.tabular() {
+		rows := make([][]string, len(counts))
+		for i, c := range counts {
+			rows[i] = []string{strconv.FormatFloat(c.Threshold, 'f', -1, 64), strconv.Itoa(c.Count)}
+		}
+		return p.writeTable([]string{"threshold", "count"}, rows)
+	}
+	for _, c := range counts {
+		fmt.Fprintf(p.w, "Above %g: %d\n", c.Threshold, c.Count)
+	}
+	return nil
+}
+
+// PrintCohortComparison prints the statistics of two CVE sets side by side,
+// one metric per row.
+func (p *Printer) PrintCohortComparison(cmp models.CohortComparison) error {
+	if p.format == FormatJSON {
+		cmp.A.Above, cmp.B.Above = nonNil(cmp.A.Above), nonNil(cmp.B.Above)
+		return p.writeEnvelope(cmp, 2)
+	}
+	metric := func(name string, value func(s models.SetStats) string) []string {
+		return []string{name, value(cmp.A), value(cmp.B)}
+	}
+	rows := [][]string{
+		metric("CVEs", func(s models.SetStats) string { return strconv.Itoa(s.CVEs) }),
+		metric("Scored", func(s models.SetStats) string { return strconv.Itoa(s.Scored) }),
+		metric("Mean EPSS", func(s models.SetStats) string { return p.num(s.MeanEPSS) }),
+		metric("Median EPSS", func(s models.SetStats) string { return p.num(s.MedianEPSS) }),
+		metric("Max EPSS", func(s models.SetStats) string { return p.num(s.MaxEPSS) }),
+	}
+	for i, above := range cmp.A.Above {
+		rows = append(rows, metric(fmt.Sprintf("EPSS > %g", above.Threshold), func(s models.SetStats) string { return strconv.Itoa(s.Above[i].Count) }))
+	}
+	rows = append(rows, metric("In KEV", func(s models.SetStats) string { return strconv.Itoa(s.KEV) }))
+
+	if p.tabular() {
+		return p.writeTable([]string{"metric", cmp.A.Name, cmp.B.Name}, rows)
+	}
+	if cmp.Date != "" {
+		fmt.Fprintf(p.w, "Date: %s\n", cmp.Date)
+	}
+	widthA, widthB := len(cmp.A.Name), len(cmp.B.Name)
+	for _, row := range rows {
+		widthA, widthB = max(widthA, len(row[1])), max(widthB, len(row[2]))
+	}
+	fmt.Fprintf(p.w, "%-12s  %*s  %*s\n", "Metric", widthA, cmp.A.Name, widthB, cmp.B.Name)
+	for _, row := range rows {
+		fmt.Fprintf(p.w, "%-12s  %*s  %*s\n", row[0], widthA, row[1], widthB, row[2])
+	}
+	return nil
+}
+
+// PrintPortfolioTrend prints the weighted risk of a portfolio on each date,
+// one point per line in text mode.
+func (p *Printer) PrintPortfolioTrend(points []models.PortfolioPoint) error {
+	p.count(len(points))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(nonNil(points), len(points))
+	}
+	if p.tabular() {
+		rows := make([][]string, len(points))
+		for i, point := range points {
+			rows[i] = []string{point.Date, p.num(point.Risk), strconv.Itoa(point.Scored), strconv.Itoa(point.CVEs)}
+		}
+		return p.writeTable([]string{"date", "weighted_risk", "scored", "cves"}, rows)
+	}
+	for _, point := range points {
+		fmt.Fprintf(p.w, "Date: %s, Weighted Risk: %s, Scored: %d/%d\n", point.Date, p.num(point.Risk), point.Scored, point.CVEs)
+	}
+	return nil
+}
+
+// PrintYearCohort prints a year's CVEs followed by the cohort's summary statistics.
+func (p *Printer) PrintYearCohort(stats models.CohortStats, cves []models.CVE) error {
+	cves = p.hashCVEs(cves)
+	p.count(len(cves))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(struct {
+			Summary models.CohortStats `json:"summary"`
+			CVEs    []models.CVE       `json:"cves"`
+		}{stats, nonNil(cves)}, len(cves))
+	}
+	if p.format == FormatCSV {
+		// A CSV file holds one table, so the summary is left out.
+		return p.writeTableCVEs(cves)
+	}
+	if p.format == FormatMarkdown {
+		row := []string{strconv.Itoa(stats.Year), strconv.Itoa(stats.Count), p.num(stats.MeanEPSS), p.num(stats.MedianEPSS), p.num(stats.MaxEPSS)}
+		if err := p.writeMarkdownTable([]string{"year", "count", "mean_epss", "median_epss", "max_epss"}, [][]string{row}); err != nil {
+			return err
+		}
+		fmt.Fprintln(p.w)
+		return p.writeTableCVEs(cves)
+	}
+	p.writeTextCVEs(cves)
+	fmt.Fprintf(p.w, "Year %d: %d CVE(s), Mean EPSS: %s, Median EPSS: %s, Max EPSS: %s\n",
+		stats.Year, stats.Count, p.num(stats.MeanEPSS), p.num(stats.MedianEPSS), p.num(stats.MaxEPSS))
+	return nil
+}
+
+// PrintCorrelation prints the EPSS/CVSS correlation with its scatter summary.
+func (p *Printer) PrintCorrelation(c models.Correlation) error {
+	c.Missing = hashIDs(p, c.Missing, func(id *string) *string { return id })
+	if p.format == FormatJSON {
+		c.Missing = nonNil(c.Missing)
+		return p.writeEnvelope(c, c.Count)
+	}
+	rows := make([][]string, len(c.Scatter))
+	for i, cell := range c.Scatter {
+		rows[i] = []string{cell.Severity, cell.RiskLevel, strconv.Itoa(cell.Count)}
+	}
+	header := []string{"cvss_severity", "epss_risk_level", "count"}
+	if p.format == FormatCSV {
+		// A CSV file holds one table, so the coefficient is left out.
+		return p.writeTable(header, rows)
+	}
+	if p.format == FormatMarkdown {
+		if err := p.writeMarkdownTable([]string{"count", "pearson", "missing"}, [][]string{{strconv.Itoa(c.Count), p.num(c.Pearson), strconv.Itoa(len(c.Missing))}}); err != nil {
+			return err
+		}
+		fmt.Fprintln(p.w)
+		return p.writeMarkdownTable(header, rows)
+	}
+	fmt.Fprintf(p.w, "CVEs: %d\n", c.Count)
+	fmt.Fprintf(p.w, "Pearson correlation (EPSS vs CVSS): %s\n", p.num(c.Pearson))
+	fmt.Fprintf(p.w, "%-15s %-15s %6s\n", "CVSS Severity", "EPSS Risk", "Count")
+	for _, cell := range c.Scatter {
+		fmt.Fprintf(p.w, "%-15s %-15s %6d\n", cell.Severity, cell.RiskLevel, cell.Count)
+	}
+	if len(c.Missing) > 0 {
+		fmt.Fprintf(p.w, "Skipped %d CVE(s) without both scores: %s\n", len(c.Missing), strings.Join(c.Missing, ", "))
+	}
+	return nil
+}
+
+// PrintDiagnosis prints the effective configuration and a pass/fail checklist.
+func (p *Printer) PrintDiagnosis(config map[string]string, checks []models.Check) error {
+	if p.format == FormatJSON {
+		return p.writeEnvelope(struct {
+			Config map[string]string `json:"config"`
+			Checks []models.Check    `json:"checks"`
+		}{config, nonNil(checks)}, len(checks))
+	}
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(p.w, "Effective configuration:")
+	for _, k := range keys {
+		fmt.Fprintf(p.w, "  %s: %s\n", k, config[k])
+	}
+	fmt.Fprintln(p.w, "Checks:")
+	for _, check := range checks {
+		status := "FAIL"
+		if check.Passed {
+			status = "PASS"
+		}
+		fmt.Fprintf(p.w, "  [%s] %s: %s\n", status, check.Name, check.Detail)
+	}
+	return nil
+}
+
+// PrintDataRange prints the span of dates with available data.
+func (p *Printer) PrintDataRange(r models.DataRange) error {
+	if p.format == FormatJSON {
+		return p.writeEnvelope(r, 1)
+	}
+	if p.tabular() {
+		return p.writeTable([]string{"earliest", "latest"}, [][]string{{r.Earliest, r.Latest}})
+	}
+	fmt.Fprintf(p.w, "Earliest: %s\n", r.Earliest)
+	fmt.Fprintf(p.w, "Latest: %s\n", r.Latest)
+	return nil
+}
+
+// PrintCount prints the number of records a query matches.
+func (p *Printer) PrintCount(n int) error {
+	if p.format == FormatJSON {
+		return p.writeEnvelope(struct {
+			Count int `json:"count"`
+		}{n}, 1)
+	}
+	if p.tabular() {
+		return p.writeTable([]string{"count"}, [][]string{{strconv.Itoa(n)}})
+	}
+	fmt.Fprintf(p.w, "Count: %d\n", n)
+	return nil
+}
+
+// PrintTrend prints a CVE's trend direction and its slope in EPSS per day.
+func (p *Printer) PrintTrend(t models.Trend) error {
+	t.CVE = p.hashID(t.CVE)
+	if p.format == FormatJSON {
+		return p.writeEnvelope(t, 1)
+	}
+	if p.tabular() {
+		return p.writeTable([]string{"cve", "direction", "slope", "points", "start", "end"},
+			[][]string{{t.CVE, t.Direction, p.num(t.Slope), strconv.Itoa(t.Points), t.Start, t.End}})
+	}
+	fmt.Fprintf(p.w, "%s is %s\n", t.CVE, t.Direction)
+	fmt.Fprintf(p.w, "Slope: %s EPSS/day over %d points (%s to %s)\n", p.num(t.Slope), t.Points, t.Start, t.End)
+	return nil
+}
+
+// PrintStability prints how many days a CVE's score has held its current value.
+func (p *Printer) PrintStability(s models.Stability) error {
+	s.CVE = p.hashID(s.CVE)
+	if p.format == FormatJSON {
+		return p.writeEnvelope(s, 1)
+	}
+	if p.tabular() {
+		return p.writeTable([]string{"cve", "epss", "date", "stable_days", "since", "changed"},
+			[][]string{{s.CVE, p.num(s.Current), s.Date, strconv.Itoa(s.StableDays), s.Since, strconv.FormatBool(s.Changed)}})
+	}
+	atLeast := ""
+	if !s.Changed {
+		atLeast = "at least "
+	}
+	fmt.Fprintf(p.w, "%s has been stable for %s%d days (since %s)\n", s.CVE, atLeast, s.StableDays, s.Since)
+	fmt.Fprintf(p.w, "Current EPSS Score: %s (%s)\n", p.num(s.Current), s.Date)
+	return nil
+}
+
+// PrintScoreComparison prints a CVE's scores on the compared dates with the
+// change since the previous date with data.
+func (p *Printer) PrintScoreComparison(cmp models.ScoreComparison) error {
+	cmp.CVE = p.hashID(cmp.CVE)
+	p.count(len(cmp.Scores))
+	if p.format == FormatJSON {
+		return p.writeEnvelope(cmp, len(cmp.Scores))
+	}
+	value := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return p.num(*v)
+	}
+	delta := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return sign(*v) + p.num(*v)
+	}
+	if p.tabular() {
+		rows := make([][]string, len(cmp.Scores))
+		for i, s := range cmp.Scores {
+			rows[i] = []string{cmp.CVE, s.Date, value(s.EPSS), value(s.Percentile), delta(s.EPSSDelta), delta(s.PercentileDelta)}
+		}
+		return p.writeTable([]string{"cve", "date", "epss", "percentile", "epss_delta", "percentile_delta"}, rows)
+	}
+	fmt.Fprintf(p.w, "CVE ID: %s\n", cmp.CVE)
+	fmt.Fprintf(p.w, "%-10s  %12s  %12s  %12s  %12s\n", "Date", "EPSS Score", "Percentile", "EPSS Change", "Pct Change")
+	for _, s := range cmp.Scores {
+		if s.EPSS == nil {
+			fmt.Fprintf(p.w, "%-10s  %12s\n", s.Date, "no data")
+			continue
+		}
+		line := fmt.Sprintf("%-10s  %12s  %12s  %12s  %12s", s.Date, value(s.EPSS), value(s.Percentile), delta(s.EPSSDelta), delta(s.PercentileDelta))
+		fmt.Fprintln(p.w, strings.TrimRight(line, " "))
+	}
+	return nil
+}
+
+// PrintReportDiff prints the CVEs added, removed and changed between two
+// reports. Tables hold one row per CVE with a change column.
+func (p *Printer) PrintReportDiff(d models.ReportDiff) error {
+	d.Added, d.Removed = p.hashCVEs(d.Added), p.hashCVEs(d.Removed)
+	d.Changed = hashIDs(p, d.Changed, func(c *models.CVEDelta) *string { return &c.CVE })
+	n := len(d.Added) + len(d.Removed) + len(d.Changed)
+	p.count(n)
+	if p.format == FormatJSON {
+		return p.writeEnvelope(d, n)
+	}
+	if p.tabular() {
+		rows := make([][]string, 0, n)
+		for _, c := range d.Added {
+			rows = append(rows, []string{"added", c.ID, "", p.num(c.EPSSScore), "", "", p.num(c.Percentile), ""})
+		}
+		for _, c := range d.Removed {
+			rows = append(rows, []string{"removed", c.ID, p.num(c.EPSSScore), "", "", p.num(c.Percentile), "", ""})
+		}
+		for _, c := range d.Changed {
+			rows = append(rows, []string{"changed", c.CVE, p.num(c.OldEPSS), p.num(c.NewEPSS), p.num(c.EPSSDelta),
+				p.num(c.OldPercentile), p.num(c.NewPercentile), p.num(c.PercentileDelta)})
+		}
+		return p.writeTable([]string{"change", "cve", "old_epss", "new_epss", "epss_delta", "old_percentile", "new_percentile", "percentile_delta"}, rows)
+	}
+	fmt.Fprintf(p.w, "Added (%d):\n", len(d.Added))
+	for _, c := range d.Added {
+		fmt.Fprintf(p.w, "  %s, EPSS Score: %s, Percentile: %s\n", c.ID, p.num(c.EPSSScore), p.num(c.Percentile))
+	}
+	fmt.Fprintf(p.w, "Removed (%d):\n", len(d.Removed))
+	for _, c := range d.Removed {
+		fmt.Fprintf(p.w, "  %s, EPSS Score: %s, Percentile: %s\n", c.ID, p.num(c.EPSSScore), p.num(c.Percentile))
+	}
+	fmt.Fprintf(p.w, "Changed (%d):\n", len(d.Changed))
+	for _, c := range d.Changed {
+		fmt.Fprintf(p.w, "  %s, EPSS Score: %s -> %s (%s%s), Percentile: %s -> %s (%s%s)\n", c.CVE,
+			p.num(c.OldEPSS), p.num(c.NewEPSS), sign(c.EPSSDelta), p.num(c.EPSSDelta),
+			p.num(c.OldPercentile), p.num(c.NewPercentile), sign(c.PercentileDelta), p.num(c.PercentileDelta))
+	}
+	return nil
+}
+
+// sign returns "+" for positive v, so deltas show their direction.
+func sign(v float64) string {
+	if v > 0 {
+		return "+"
+	}
+	return ""
+}
+
+// discrepancyCategories fixes the order categories are reported in.
+var discrepancyCategories = []models.DiscrepancyCategory{models.MissingInAPI, models.MissingInCSV, models.ScoreDiff}
+
+// PrintDiscrepancies prints the count of discrepancies per category and, when
+// verbose, one line per discrepancy. JSON output always includes the details.
+func (p *Printer) PrintDiscrepancies(discrepancies []models.Discrepancy, counts map[models.DiscrepancyCategory]int, verbose bool) error {
+	discrepancies = hashIDs(p, discrepancies, func(d *models.Discrepancy) *string { return &d.CVE })
+	if p.format == FormatJSON {
+		return p.writeEnvelope(struct {
+			Counts        map[models.DiscrepancyCategory]int `json:"counts"`
+			Discrepancies []models.Discrepancy               `json:"discrepancies"`
+		}{counts, nonNil(discrepancies)}, len(discrepancies))
+	}
+	for _, category := range discrepancyCategories {
+		fmt.Fprintf(p.w, "%s: %d\n", category, counts[category])
+	}
+	if !verbose {
+		return nil
+	}
+	for _, d := range discrepancies {
+		fmt.Fprintf(p.w, "CVE ID: %s, Category: %s, API EPSS: %s, CSV EPSS: %s, API Percentile: %s, CSV Percentile: %s\n",
+			d.CVE, d.Category, p.num(d.APIEPSS), p.num(d.CSVEPSS), p.num(d.APIPercentile), p.num(d.CSVPercentile))
+	}
+	return nil
+}
+
+// writeEnvelope writes data as JSON, wrapped with metadata when configured.
+func (p *Printer) writeEnvelope(data interface{}, count int) error {
+	if p.meta == nil {
+		return p.writeJSON(data)
+	}
+	meta := *p.meta
+	meta.Count = count
+	if p.upstream != nil {
+		meta.UpstreamQueries = p.upstream()
+	}
+	return p.writeJSON(envelope{Meta: meta, Data: data})
+}
+
+// writeJSON writes v as indented JSON.
+func (p *Printer) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return nil
+}
+
+// nonNil ensures empty results encode as [] rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
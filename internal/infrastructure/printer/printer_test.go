@@ -0,0 +1,716 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintCVEs(t *testing.T) {
+	t.Run("Success - JSON Includes Rank When Known", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON)
+		cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.9952, Date: "2024-10-18", Rank: 1201, Total: 250000}}
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","epss":0.9,"percentile":0.9952,"date":"2024-10-18","rank":1201,"total":250000}]`, buf.String())
+	})
+
+	t.Run("Success - JSON Omits Unknown Rank", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON)
+		cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.99, Date: "2024-10-18"}}
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","epss":0.9,"percentile":0.99,"date":"2024-10-18"}]`, buf.String())
+	})
+
+	t.Run("Success - JSON Encodes Empty Result As Array", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON)
+
+		require.NoError(t, p.PrintCVEs(nil))
+
+		assert.JSONEq(t, `[]`, buf.String())
+	})
+
+	t.Run("Success - Text Shows Rank", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+		cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.9952, Date: "2024-10-18", Rank: 1201, Total: 250000}}
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, EPSS Score: 0.900000, Percentile: 0.995200, Date: 2024-10-18, Rank: ~#1201 of 250000\n", buf.String())
+	})
+}
+
+func TestPrintNormalized(t *testing.T) {
+	position := 1.281552
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.9, Date: "2024-10-18", Normalized: &position}}
+
+	t.Run("Success - Text Marks Normalized Position", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, EPSS Score: 0.900000, Percentile: 0.900000, Date: 2024-10-18, Normalized: 1.281552 (approximate)\n", buf.String())
+	})
+
+	t.Run("Success - JSON Includes Normalized Field", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","epss":0.9,"percentile":0.9,"date":"2024-10-18","normalized":1.281552}]`, buf.String())
+	})
+}
+
+func TestPrintFreshness(t *testing.T) {
+	age, stale := 3, true
+	cve := models.CVE{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.9, Date: "2024-10-18", AgeDays: &age, Stale: &stale}
+
+	t.Run("Success - Text Shows Age And Staleness", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintCVEs([]models.CVE{cve}))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, EPSS Score: 0.900000, Percentile: 0.900000, Date: 2024-10-18, Age: 3 days (stale)\n", buf.String())
+	})
+
+	t.Run("Success - CSV Fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).WithFields([]string{"cve", "age_days", "stale"}).PrintCVEs([]models.CVE{cve}))
+
+		assert.Equal(t, "cve,age_days,stale\nCVE-2023-0001,3,true\n", buf.String())
+	})
+
+	t.Run("Success - JSON Omits Freshness Unless Set", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintCVE(&models.CVE{ID: "CVE-2023-0001", Date: "2024-10-18"}))
+
+		assert.NotContains(t, buf.String(), "age_days")
+	})
+}
+
+func TestWithSource(t *testing.T) {
+	cve := models.CVE{ID: "CVE-2023-0001", EPSSScore: 0.9, Percentile: 0.9, Date: "2024-10-18", Source: "cache"}
+
+	t.Run("Success - Text Hides Source By Default", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+
+		require.NoError(t, p.PrintCVE(&cve))
+
+		assert.NotContains(t, buf.String(), "Source")
+	})
+
+	t.Run("Success - Text Shows Source", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithSource()
+
+		require.NoError(t, p.PrintCVE(&cve))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001\nEPSS Score: 0.900000\nPercentile: 0.900000\nDate: 2024-10-18\nSource: cache\n", buf.String())
+	})
+
+	t.Run("Success - Markdown Adds Source Column", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatMarkdown).WithSource()
+
+		require.NoError(t, p.PrintCVEs([]models.CVE{cve}))
+
+		assert.Equal(t, "| cve | epss | percentile | date | source |\n|---|---|---|---|---|\n| CVE-2023-0001 | 0.900000 | 0.900000 | 2024-10-18 | cache |\n", buf.String())
+	})
+
+	t.Run("Success - JSON Always Includes Source", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON)
+
+		require.NoError(t, p.PrintCVE(&cve))
+
+		assert.JSONEq(t, `{"cve":"CVE-2023-0001","epss":0.9,"percentile":0.9,"date":"2024-10-18","source":"cache"}`, buf.String())
+	})
+}
+
+func TestPrintYearCohort(t *testing.T) {
+	stats := models.CohortStats{Year: 2021, Count: 1, MeanEPSS: 0.5, MedianEPSS: 0.5, MaxEPSS: 0.5}
+	cves := []models.CVE{{ID: "CVE-2021-0001", EPSSScore: 0.5, Percentile: 0.9, Date: "2024-10-18"}}
+
+	t.Run("Success - Text Ends With Summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintYearCohort(stats, cves))
+
+		assert.Equal(t, "CVE ID: CVE-2021-0001, EPSS Score: 0.500000, Percentile: 0.900000, Date: 2024-10-18\n"+
+			"Year 2021: 1 CVE(s), Mean EPSS: 0.500000, Median EPSS: 0.500000, Max EPSS: 0.500000\n", buf.String())
+	})
+
+	t.Run("Success - JSON Holds Summary And CVEs", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintYearCohort(stats, cves))
+
+		assert.JSONEq(t, `{"summary":{"year":2021,"count":1,"mean_epss":0.5,"median_epss":0.5,"max_epss":0.5},
+			"cves":[{"cve":"CVE-2021-0001","epss":0.5,"percentile":0.9,"date":"2024-10-18"}]}`, buf.String())
+	})
+}
+
+func TestPrintDataRange(t *testing.T) {
+	dataRange := models.DataRange{Earliest: "2021-04-14", Latest: "2024-10-18"}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintDataRange(dataRange))
+
+		assert.Equal(t, "Earliest: 2021-04-14\nLatest: 2024-10-18\n", buf.String())
+	})
+
+	t.Run("Success - JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintDataRange(dataRange))
+
+		assert.JSONEq(t, `{"earliest":"2021-04-14","latest":"2024-10-18"}`, buf.String())
+	})
+}
+
+func TestPrintScoreChanges(t *testing.T) {
+	t.Run("Success - JSON Uses API Field Names", func(t *testing.T) {
+		changes := []models.ScoreChange{{CVE: "CVE-2023-0001", Date: time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC), ScoreChange: 0.25}}
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintScoreChanges(changes))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","date":"2024-10-18T00:00:00Z","score_change":0.25}]`, buf.String())
+		assert.Contains(t, buf.String(), "\n  {", "JSON output is indented")
+	})
+
+	t.Run("Success - Empty JSON Is An Array", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintScoreChanges(nil))
+
+		assert.JSONEq(t, `[]`, buf.String())
+	})
+}
+
+func TestPrintPercentileMovers(t *testing.T) {
+	changes := []models.ScoreChange{{CVE: "CVE-2023-0001", Date: time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC), ScoreChange: 0.0001, PercentileChange: -0.25}}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintPercentileMovers(changes))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, Date: 2024-10-18, Percentile Change: -0.250000, Score Change: 0.000100\n", buf.String())
+	})
+
+	t.Run("Success - CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintPercentileMovers(changes))
+
+		assert.Equal(t, "cve,date,percentile_change,score_change\nCVE-2023-0001,2024-10-18,-0.25,0.0001\n", buf.String())
+	})
+}
+
+func TestPrintFindings(t *testing.T) {
+	epss, percentile := 0.03, 0.9
+	findings := []models.Finding{
+		{CVE: "CVE-2021-23337", Package: "lodash", Version: "4.17.20", Artifact: "app/package-lock.json", EPSSScore: &epss, Percentile: &percentile, Date: "2024-10-18"},
+		{CVE: "CVE-2023-9999", Package: "openssl", Version: "3.0.0", Artifact: "alpine:3.10"},
+	}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintFindings(findings))
+
+		assert.Equal(t, "CVE ID: CVE-2021-23337, Package: lodash 4.17.20, Artifact: app/package-lock.json, EPSS Score: 0.030000, Percentile: 0.900000\n"+
+			"CVE ID: CVE-2023-9999, Package: openssl 3.0.0, Artifact: alpine:3.10, EPSS Score: n/a, Percentile: n/a\n", buf.String())
+	})
+
+	t.Run("Success - CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintFindings(findings))
+
+		assert.Equal(t, "cve,package,version,artifact,epss,percentile,date\n"+
+			"CVE-2021-23337,lodash,4.17.20,app/package-lock.json,0.03,0.9,2024-10-18\n"+
+			"CVE-2023-9999,openssl,3.0.0,alpine:3.10,,,\n", buf.String())
+	})
+
+	t.Run("Success - JSON Marks Unscored As Null", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintFindings(findings[1:]))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-9999","package":"openssl","version":"3.0.0","artifact":"alpine:3.10","epss":null,"percentile":null}]`, buf.String())
+	})
+}
+
+func TestPrintRawValues(t *testing.T) {
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.00044, Percentile: 0.13, Date: "2024-10-18", RawEPSS: "0.000440000", RawPercentile: "0.130000000"}}
+
+	t.Run("Success - Text Prints Raw Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintCVEs(cves))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, EPSS Score: 0.000440000, Percentile: 0.130000000, Date: 2024-10-18\n", buf.String())
+	})
+
+	t.Run("Success - JSON Keeps Both", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintCVEs(cves))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","epss":0.00044,"percentile":0.13,"date":"2024-10-18","raw_epss":"0.000440000","raw_percentile":"0.130000000"}]`, buf.String())
+	})
+}
+
+func TestPrintCorrelation(t *testing.T) {
+	correlation := models.Correlation{
+		Count:   3,
+		Pearson: 0.5,
+		Scatter: []models.ScatterCell{{Severity: "critical", RiskLevel: "very high", Count: 2}, {Severity: "medium", RiskLevel: "low", Count: 1}},
+		Missing: []string{"CVE-2023-0004"},
+	}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintCorrelation(correlation))
+
+		assert.Equal(t, "CVEs: 3\n"+
+			"Pearson correlation (EPSS vs CVSS): 0.500000\n"+
+			"CVSS Severity   EPSS Risk        Count\n"+
+			"critical        very high            2\n"+
+			"medium          low                  1\n"+
+			"Skipped 1 CVE(s) without both scores: CVE-2023-0004\n", buf.String())
+	})
+
+	t.Run("Success - CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintCorrelation(correlation))
+
+		assert.Equal(t, "cvss_severity,epss_risk_level,count\ncritical,very high,2\nmedium,low,1\n", buf.String())
+	})
+}
+
+func TestPrintComponents(t *testing.T) {
+	maxEPSS := 0.97
+	components := []models.ComponentSummary{
+		{Package: "log4j-core", Version: "2.14.1", CVECount: 2, MaxEPSS: &maxEPSS, TopCVE: "CVE-2021-44228", InKEV: true},
+		{Package: "unscored", Version: "1.0", CVECount: 1},
+	}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintComponents(components))
+
+		assert.Equal(t, "Package: log4j-core 2.14.1, CVEs: 2, Max EPSS: 0.970000 (CVE-2021-44228), In KEV\n"+
+			"Package: unscored 1.0, CVEs: 1, Max EPSS: n/a\n", buf.String())
+	})
+
+	t.Run("Success - CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintComponents(components))
+
+		assert.Equal(t, "package,version,cve_count,max_epss,top_cve,in_kev\n"+
+			"log4j-core,2.14.1,2,0.97,CVE-2021-44228,true\n"+
+			"unscored,1.0,1,,,false\n", buf.String())
+	})
+}
+
+func TestPrintCohortComparison(t *testing.T) {
+	cmp := models.CohortComparison{
+		Date: "2024-10-18",
+		A:    models.SetStats{Name: "critical.csv", CVEs: 3, Scored: 3, MeanEPSS: 0.59, MedianEPSS: 0.6, MaxEPSS: 0.97, Above: []models.ThresholdCount{{Threshold: 0.5, Count: 2}}, KEV: 1},
+		B:    models.SetStats{Name: "low.csv", CVEs: 4, Scored: 3, MeanEPSS: 0.03, MedianEPSS: 0.03, MaxEPSS: 0.05, Above: []models.ThresholdCount{{Threshold: 0.5, Count: 0}}},
+	}
+
+	t.Run("Success - Two Column Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintCohortComparison(cmp))
+
+		assert.Equal(t, "Date: 2024-10-18\n"+
+			"Metric        critical.csv   low.csv\n"+
+			"CVEs                     3         4\n"+
+			"Scored                   3         3\n"+
+			"Mean EPSS         0.590000  0.030000\n"+
+			"Median EPSS       0.600000  0.030000\n"+
+			"Max EPSS          0.970000  0.050000\n"+
+			"EPSS > 0.5               2         0\n"+
+			"In KEV                   1         0\n", buf.String())
+	})
+
+	t.Run("Success - CSV Table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintCohortComparison(cmp))
+
+		assert.Contains(t, buf.String(), "metric,critical.csv,low.csv\n")
+		assert.Contains(t, buf.String(), "EPSS > 0.5,2,0\n")
+	})
+}
+
+func TestPrintCWEResult(t *testing.T) {
+	result := models.CWEResult{
+		CWE:    "CWE-79",
+		CVEs:   []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.5, Percentile: 0.9, Date: "2024-10-18"}},
+		Failed: []models.BatchFailure{{CVE: "CVE-2023-0002", Error: "timeout"}},
+	}
+
+	t.Run("Success - Text Grouped Under The CWE", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintCWEResult(result))
+
+		assert.Equal(t, "== CWE-79 (1 CVEs) ==\n"+
+			"CVE ID: CVE-2023-0001, EPSS Score: 0.500000, Percentile: 0.900000, Date: 2024-10-18\n"+
+			"CVE ID: CVE-2023-0002, Failed: timeout\n", buf.String())
+	})
+
+	t.Run("Success - CSV Names The CWE And Lists Failures", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintCWEResult(result))
+
+		assert.Equal(t, "cwe,cve,date,epss,percentile,error\n"+
+			"CWE-79,CVE-2023-0001,2024-10-18,0.5,0.9,\n"+
+			"CWE-79,CVE-2023-0002,,,,timeout\n", buf.String())
+	})
+
+	t.Run("Success - JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintCWEResult(result))
+
+		assert.Contains(t, buf.String(), `"cwe": "CWE-79"`)
+		assert.Contains(t, buf.String(), `"failed": [`)
+	})
+}
+
+func TestPrintPortfolioTrend(t *testing.T) {
+	points := []models.PortfolioPoint{{Date: "2024-10-01", Risk: 0.015, Scored: 2, CVEs: 3}, {Date: "2024-10-08", Risk: 0.12, Scored: 3, CVEs: 3}}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintPortfolioTrend(points))
+
+		assert.Equal(t, "Date: 2024-10-01, Weighted Risk: 0.015000, Scored: 2/3\n"+
+			"Date: 2024-10-08, Weighted Risk: 0.120000, Scored: 3/3\n", buf.String())
+	})
+
+	t.Run("Success - CSV For Charting", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintPortfolioTrend(points))
+
+		assert.Equal(t, "date,weighted_risk,scored,cves\n2024-10-01,0.015,2,3\n2024-10-08,0.12,3,3\n", buf.String())
+	})
+}
+
+func TestPrintThresholdCounts(t *testing.T) {
+	counts := []models.ThresholdCount{{Threshold: 0.1, Count: 3}, {Threshold: 0.5, Count: 2}}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintThresholdCounts(counts))
+
+		assert.Equal(t, "Above 0.1: 3\nAbove 0.5: 2\n", buf.String())
+	})
+
+	t.Run("Success - CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintThresholdCounts(counts))
+
+		assert.Equal(t, "threshold,count\n0.1,3\n0.5,2\n", buf.String())
+	})
+}
+
+func TestPrintTrend(t *testing.T) {
+	trend := models.Trend{CVE: "CVE-2024-0001", Start: "2024-10-01", End: "2024-10-04", Points: 4, Slope: -0.02, Direction: models.TrendFalling}
+
+	var buf bytes.Buffer
+	require.NoError(t, printer.New(&buf, printer.FormatText).PrintTrend(trend))
+
+	assert.Equal(t, "CVE-2024-0001 is falling\nSlope: -0.020000 EPSS/day over 4 points (2024-10-01 to 2024-10-04)\n", buf.String())
+}
+
+func TestPrintStability(t *testing.T) {
+	t.Run("Success - Changed Within Window", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := models.Stability{CVE: "CVE-2024-0001", Current: 0.3, Date: "2024-10-10", Since: "2024-10-05", StableDays: 5, Changed: true}
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintStability(s))
+
+		assert.Equal(t, "CVE-2024-0001 has been stable for 5 days (since 2024-10-05)\nCurrent EPSS Score: 0.300000 (2024-10-10)\n", buf.String())
+	})
+
+	t.Run("Success - Unchanged Over Whole Window", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := models.Stability{CVE: "CVE-2024-0001", Current: 0.3, Date: "2024-10-10", Since: "2024-09-11", StableDays: 29}
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintStability(s))
+
+		assert.Contains(t, buf.String(), "stable for at least 29 days")
+	})
+}
+
+func TestPrintScoreComparison(t *testing.T) {
+	first, firstPct, last, lastPct, delta, deltaPct := 0.1, 0.5, 0.4, 0.9, 0.3, 0.4
+	cmp := models.ScoreComparison{CVE: "CVE-2023-0001", Scores: []models.DatedScore{
+		{Date: "2024-01-01", EPSS: &first, Percentile: &firstPct},
+		{Date: "2024-06-01"},
+		{Date: "2024-12-01", EPSS: &last, Percentile: &lastPct, EPSSDelta: &delta, PercentileDelta: &deltaPct},
+	}}
+
+	t.Run("Success - Aligned Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintScoreComparison(cmp))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001\n"+
+			"Date          EPSS Score    Percentile   EPSS Change    Pct Change\n"+
+			"2024-01-01      0.100000      0.500000\n"+
+			"2024-06-01       no data\n"+
+			"2024-12-01      0.400000      0.900000     +0.300000     +0.400000\n", buf.String())
+	})
+
+	t.Run("Success - JSON Marks Missing Dates With Null", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintScoreComparison(cmp))
+
+		assert.JSONEq(t, `{"cve":"CVE-2023-0001","scores":[
+			{"date":"2024-01-01","epss":0.1,"percentile":0.5},
+			{"date":"2024-06-01","epss":null,"percentile":null},
+			{"date":"2024-12-01","epss":0.4,"percentile":0.9,"epss_delta":0.3,"percentile_delta":0.4}]}`, buf.String())
+	})
+}
+
+func TestPrintReportDiff(t *testing.T) {
+	diff := models.ReportDiff{
+		Added:   []models.CVE{{ID: "CVE-2024-0003", EPSSScore: 0.5, Percentile: 0.97}},
+		Removed: []models.CVE{},
+		Changed: []models.CVEDelta{{CVE: "CVE-2023-0001", OldEPSS: 0.1, NewEPSS: 0.35, EPSSDelta: 0.25, OldPercentile: 0.8, NewPercentile: 0.95, PercentileDelta: 0.15}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printer.New(&buf, printer.FormatText).PrintReportDiff(diff))
+
+	assert.Equal(t, "Added (1):\n  CVE-2024-0003, EPSS Score: 0.500000, Percentile: 0.970000\n"+
+		"Removed (0):\n"+
+		"Changed (1):\n  CVE-2023-0001, EPSS Score: 0.100000 -> 0.350000 (+0.250000), Percentile: 0.800000 -> 0.950000 (+0.150000)\n", buf.String())
+}
+
+func TestPrintRecords(t *testing.T) {
+	header := []string{"Ticket", "CVE_ID"}
+	rows := [][]string{{"SEC-101", "CVE-2021-44228"}, {"SEC-102", "CVE-2099-0001"}}
+	scores := []*models.CVE{{ID: "CVE-2021-44228", EPSSScore: 0.97, Percentile: 0.99, Date: "2024-10-18"}, nil}
+
+	t.Run("Success - Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintRecords(header, rows, scores))
+
+		assert.Equal(t, "Ticket: SEC-101, CVE_ID: CVE-2021-44228, EPSS Score: 0.970000, Percentile: 0.990000, Date: 2024-10-18\n"+
+			"Ticket: SEC-102, CVE_ID: CVE-2099-0001, EPSS Score: n/a, Percentile: n/a, Date: n/a\n", buf.String())
+	})
+
+	t.Run("Success - CSV Passes Columns Through", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatCSV).PrintRecords(header, rows, scores))
+
+		assert.Equal(t, "Ticket,CVE_ID,epss,percentile,date\n"+
+			"SEC-101,CVE-2021-44228,0.97,0.99,2024-10-18\n"+
+			"SEC-102,CVE-2099-0001,,,\n", buf.String())
+	})
+
+	t.Run("Success - JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintRecords(header, rows, scores))
+
+		assert.JSONEq(t, `[
+			{"Ticket":"SEC-101","CVE_ID":"CVE-2021-44228","epss":0.97,"percentile":0.99,"date":"2024-10-18"},
+			{"Ticket":"SEC-102","CVE_ID":"CVE-2099-0001","epss":null,"percentile":null,"date":null}
+		]`, buf.String())
+	})
+}
+
+func TestResults(t *testing.T) {
+	t.Run("Success - Counts Rows Across Calls", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+		_, listed := p.Results()
+		assert.False(t, listed)
+
+		require.NoError(t, p.PrintCVEs(nil))
+		rows, listed := p.Results()
+		assert.True(t, listed)
+		assert.Equal(t, 0, rows)
+
+		require.NoError(t, p.PrintCVEs([]models.CVE{{ID: "CVE-2023-0001"}, {ID: "CVE-2023-0002"}}))
+		rows, _ = p.Results()
+		assert.Equal(t, 2, rows)
+	})
+
+	t.Run("Success - Year Cohort Counted Once", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+
+		require.NoError(t, p.PrintYearCohort(models.CohortStats{Year: 2023, Count: 1}, []models.CVE{{ID: "CVE-2023-0001"}}))
+
+		rows, _ := p.Results()
+		assert.Equal(t, 1, rows)
+	})
+}
+
+func TestPrintExplanation(t *testing.T) {
+	t.Run("Success - Text Includes Explanation", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText)
+
+		require.NoError(t, p.PrintExplanation("very high"))
+
+		assert.Equal(t, "very high\n", buf.String())
+	})
+
+	t.Run("Success - JSON Omits Explanation", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON)
+
+		require.NoError(t, p.PrintExplanation("very high"))
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Run("Success - Known Formats", func(t *testing.T) {
+		f, err := printer.ParseFormat("json")
+		assert.NoError(t, err)
+		assert.Equal(t, printer.FormatJSON, f)
+	})
+
+	t.Run("Success - Table Is The Text Format", func(t *testing.T) {
+		f, err := printer.ParseFormat("table")
+		assert.NoError(t, err)
+		assert.Equal(t, printer.FormatText, f)
+	})
+
+	t.Run("Fail - Unknown Format", func(t *testing.T) {
+		_, err := printer.ParseFormat("xml")
+		assert.Error(t, err)
+	})
+}
+
+func TestWithMetadata(t *testing.T) {
+	meta := printer.Metadata{
+		Command:     "threshold",
+		Parameters:  map[string]string{"threshold": "0.95", "field": "epss"},
+		GeneratedAt: time.Date(2024, 10, 18, 12, 0, 0, 0, time.UTC),
+		Version:     "1.2.3",
+		BaseURL:     "https://api.first.org/data/v1/epss",
+	}
+
+	t.Run("Success - JSON Is Wrapped In Envelope", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithMetadata(meta)
+		cves := []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.97, Percentile: 0.99, Date: "2024-10-18"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.96, Percentile: 0.99, Date: "2024-10-18"},
+		}
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.JSONEq(t, `{
+			"meta": {
+				"command": "threshold",
+				"parameters": {"threshold": "0.95", "field": "epss"},
+				"generated_at": "2024-10-18T12:00:00Z",
+				"version": "1.2.3",
+				"base_url": "https://api.first.org/data/v1/epss",
+				"count": 2
+			},
+			"data": [
+				{"cve":"CVE-2023-0001","epss":0.97,"percentile":0.99,"date":"2024-10-18"},
+				{"cve":"CVE-2023-0002","epss":0.96,"percentile":0.99,"date":"2024-10-18"}
+			]
+		}`, buf.String())
+	})
+
+	t.Run("Success - Single CVE Is Wrapped As Array", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithMetadata(meta)
+
+		require.NoError(t, p.PrintCVE(&models.CVE{ID: "CVE-2023-0001", Date: "2024-10-18"}))
+
+		var out struct {
+			Meta printer.Metadata `json:"meta"`
+			Data []models.CVE     `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		assert.Equal(t, 1, out.Meta.Count)
+		assert.Len(t, out.Data, 1)
+	})
+
+	t.Run("Success - Text Output Ignores Metadata", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithMetadata(meta)
+
+		require.NoError(t, p.PrintCVEs([]models.CVE{{ID: "CVE-2023-0001", Date: "2024-10-18"}}))
+
+		assert.NotContains(t, buf.String(), "meta")
+	})
+
+	t.Run("Success - Includes Upstream Queries", func(t *testing.T) {
+		var buf bytes.Buffer
+		queries := []map[string]string{{"epss-gt": "0.95", "date": "2024-10-18"}}
+		p := printer.New(&buf, printer.FormatJSON).WithMetadata(meta).WithUpstreamQueries(func() []map[string]string { return queries })
+
+		require.NoError(t, p.PrintCVEs(nil))
+
+		var out struct {
+			Meta map[string]json.RawMessage `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		assert.Contains(t, out.Meta, "upstream_queries")
+		assert.JSONEq(t, `[{"epss-gt":"0.95","date":"2024-10-18"}]`, string(out.Meta["upstream_queries"]))
+	})
+}
+
+func TestPrintYearSummaries(t *testing.T) {
+	summaries := []models.YearSummary{
+		{Year: 2019, Count: 1, MeanEPSS: 0.9},
+		{Year: 2021, Count: 2, MeanEPSS: 0.3},
+	}
+
+	t.Run("Success - JSON Map Keyed By Year", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatJSON).PrintYearSummaries(summaries))
+
+		assert.JSONEq(t, `{
+			"2019": {"year": 2019, "count": 1, "mean_epss": 0.9},
+			"2021": {"year": 2021, "count": 2, "mean_epss": 0.3}
+		}`, buf.String())
+	})
+
+	t.Run("Success - Text Table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer.New(&buf, printer.FormatText).PrintYearSummaries(summaries))
+
+		assert.Equal(t, "Year      Count  Mean EPSS\n2019          1   0.900000\n2021          2   0.300000\n", buf.String())
+	})
+}
+
+func TestWithRounding(t *testing.T) {
+	cves := []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.125, Percentile: 0.999, Date: "2024-10-18"}}
+
+	t.Run("Success - Text Uses Precision And Mode", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatText).WithRounding(2, printer.Truncate)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.Equal(t, "CVE ID: CVE-2023-0001, EPSS Score: 0.12, Percentile: 0.99, Date: 2024-10-18\n", buf.String())
+	})
+
+	t.Run("Success - JSON Keeps Full Precision", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := printer.New(&buf, printer.FormatJSON).WithRounding(2, printer.Truncate)
+
+		require.NoError(t, p.PrintCVEs(cves))
+
+		assert.JSONEq(t, `[{"cve":"CVE-2023-0001","epss":0.125,"percentile":0.999,"date":"2024-10-18"}]`, buf.String())
+	})
+}
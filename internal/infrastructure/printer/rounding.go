@@ -0,0 +1,84 @@
+package printer
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode selects how scores are rounded to the output precision.
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds to the nearest value, with ties away from zero.
+	RoundHalfUp RoundingMode = "round"
+	// Truncate drops digits beyond the precision.
+	Truncate RoundingMode = "truncate"
+	// Ceil rounds toward positive infinity.
+	Ceil RoundingMode = "ceil"
+	// Floor rounds toward negative infinity.
+	Floor RoundingMode = "floor"
+)
+
+// defaultPrecision matches the six decimals of the original %f output.
+const defaultPrecision = 6
+
+// ParseRoundingMode validates a rounding mode name.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch RoundingMode(s) {
+	case RoundHalfUp, Truncate, Ceil, Floor:
+		return RoundingMode(s), nil
+	default:
+		return "", fmt.Errorf("unsupported rounding mode: %s (expected round, truncate, ceil or floor)", s)
+	}
+}
+
+// WithScientificBelow writes non-zero scores smaller in magnitude than
+// threshold in scientific notation, such as 1.2e-05, with every significant
+// digit kept, so tiny scores are not rounded away by the fixed precision.
+// Zero disables it. CSV and JSON output already keep full precision.
+func (p *Printer) WithScientificBelow(threshold float64) *Printer {
+	p.sciBelow = threshold
+	return p
+}
+
+// formatScientific renders v in the shortest scientific notation that
+// round-trips, localizing only the mantissa.
+func (p *Printer) formatScientific(v float64) string {
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(v, 'e', -1, 64), "e")
+	return p.locale.apply(mantissa) + "e" + exponent
+}
+
+// formatDecimal renders v with precision decimals using mode.
+//
+// Rounding works on the shortest decimal representation of v rather than its
+// binary value, so 0.125 rounds half-up to 0.13 as a reader would expect.
+func formatDecimal(v float64, precision int, mode RoundingMode) string {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	if !ok {
+		return strconv.FormatFloat(v, 'f', precision, 64)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	r.Mul(r, new(big.Rat).SetInt(scale))
+
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	switch mode {
+	case Truncate:
+	case Floor:
+		if m.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case Ceil:
+		if m.Sign() > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	default:
+		twice := new(big.Int).Abs(m)
+		twice.Lsh(twice, 1)
+		if twice.Cmp(r.Denom()) >= 0 {
+			q.Add(q, big.NewInt(int64(m.Sign())))
+		}
+	}
+	return new(big.Rat).SetFrac(q, scale).FloatString(precision)
+}
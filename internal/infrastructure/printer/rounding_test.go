@@ -0,0 +1,60 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		precision int
+		mode      RoundingMode
+		want      string
+	}{
+		{"Round Half Up", 0.125, 2, RoundHalfUp, "0.13"},
+		{"Round Down", 0.124, 2, RoundHalfUp, "0.12"},
+		{"Round Default Precision", 0.00044, 6, RoundHalfUp, "0.000440"},
+		{"Truncate", 0.129, 2, Truncate, "0.12"},
+		{"Ceil", 0.121, 2, Ceil, "0.13"},
+		{"Ceil Exact", 0.12, 2, Ceil, "0.12"},
+		{"Floor", 0.129, 2, Floor, "0.12"},
+		{"Floor Negative", -0.121, 2, Floor, "-0.13"},
+		{"Truncate Negative", -0.129, 2, Truncate, "-0.12"},
+		{"Round Negative", -0.125, 2, RoundHalfUp, "-0.13"},
+		{"Zero Precision", 0.5, 0, RoundHalfUp, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatDecimal(tt.value, tt.precision, tt.mode))
+		})
+	}
+}
+
+func TestWithScientificBelow(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		threshold float64
+		locale    Locale
+		want      string
+	}{
+		{"Below Threshold", 1.2e-05, 1e-04, Locale{}, "1.2e-05"},
+		{"Keeps Every Digit", 1.23456789e-07, 1e-04, Locale{}, "1.23456789e-07"},
+		{"Whole Mantissa", 1e-05, 1e-04, Locale{Decimal: ",", Group: "."}, "1e-05"},
+		{"Localized Mantissa", 1.2e-05, 1e-04, Locale{Decimal: ",", Group: "."}, "1,2e-05"},
+		{"At Threshold", 1e-04, 1e-04, Locale{}, "0.000100"},
+		{"Zero Stays Fixed", 0, 1e-04, Locale{}, "0.000000"},
+		{"Disabled", 1.2e-05, 0, Locale{}, "0.000012"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(nil, FormatText).WithLocale(tt.locale).WithScientificBelow(tt.threshold)
+			assert.Equal(t, tt.want, p.num(tt.value))
+		})
+	}
+}
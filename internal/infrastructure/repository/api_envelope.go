@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// apiEnvelope is the JSON envelope wrapping every EPSS API response.
+type apiEnvelope struct {
+	apiStatus
+	Total  *int     `json:"total"`
+	Offset int      `json:"offset"`
+	Limit  int      `json:"limit"`
+	Data   []apiRow `json:"data"`
+}
+
+// apiStatus holds the status fields of an API response. The API reports
+// some failures, such as invalid parameters, in them with HTTP status 200.
+// Mirrors may omit them.
+type apiStatus struct {
+	Status     string `json:"status"`
+	StatusCode int    `json:"status-code"`
+	Message    string `json:"message"`
+}
+
+// err returns the failure the status fields report, if any.
+func (s apiStatus) err() error {
+	if s.StatusCode == 0 || (s.StatusCode >= 200 && s.StatusCode < 300) {
+		return nil
+	}
+	return &apierr.EnvelopeError{StatusCode: s.StatusCode, Status: s.Status, Message: s.Message}
+}
+
+// checkStatus returns the failure reported by the status fields of a JSON
+// response body, so it is surfaced before the body is cached. It reads the
+// top-level fields up to data only, leaving the rows to be decoded once by
+// the caller; a status sent after them is caught when they are decoded.
+func checkStatus(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("failed to unmarshal JSON response: expected an object, got %v", tok)
+	}
+	var status apiStatus
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal JSON response: %w", err)
+		}
+		var dst interface{}
+		switch tok {
+		case "status":
+			dst = &status.Status
+		case "status-code":
+			dst = &status.StatusCode
+		case "message":
+			dst = &status.Message
+		case "data":
+			return status.err()
+		default:
+			dst = new(json.RawMessage)
+		}
+		if err := dec.Decode(dst); err != nil {
+			return fmt.Errorf("failed to unmarshal JSON response: %w", err)
+		}
+	}
+	return status.err()
+}
+
+// apiRow is one CVE row of an API response.
+type apiRow struct {
+	CVE        string       `json:"cve"`
+	EPSS       lenientFloat `json:"epss"`
+	Percentile lenientFloat `json:"percentile"`
+	Date       string       `json:"date"`
+
+	// TimeSeries holds the earlier daily scores of the CVE when the request
+	// used scope=time-series. Its rows omit the CVE ID.
+	TimeSeries []apiRow `json:"time-series"`
+}
+
+// lenientFloat decodes a number sent either as a JSON number or as a string,
+// as the API sends scores as strings. Raw holds the text as sent, and is
+// only filled in when raw values were requested.
+type lenientFloat struct {
+	Value float64
+	Raw   string
+	Set   bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *lenientFloat) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	f.Value, f.Set = v, true
+	return nil
+}
+
+// FieldMapping names the JSON keys that hold each CVE field in a response row,
+// for mirrors that rename the First.org fields.
+type FieldMapping struct {
+	CVE        string
+	EPSS       string
+	Percentile string
+	Date       string
+}
+
+// DefaultFieldMapping matches the First.org API.
+var DefaultFieldMapping = FieldMapping{CVE: "cve", EPSS: "epss", Percentile: "percentile", Date: "date"}
+
+// ParseFieldMapping parses comma-separated field=key overrides, such as
+// "epss=score,date=day", applied on top of DefaultFieldMapping.
+func ParseFieldMapping(list string) (FieldMapping, error) {
+	mapping := DefaultFieldMapping
+	if strings.TrimSpace(list) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(list, ",") {
+		field, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return mapping, fmt.Errorf("invalid field mapping %q (expected field=key)", pair)
+		}
+		switch strings.TrimSpace(field) {
+		case "cve":
+			mapping.CVE = key
+		case "epss":
+			mapping.EPSS = key
+		case "percentile":
+			mapping.Percentile = key
+		case "date":
+			mapping.Date = key
+		default:
+			return mapping, fmt.Errorf("unsupported mapped field: %s (expected cve, epss, percentile or date)", field)
+		}
+	}
+	return mapping, nil
+}
+
+// decodeEnvelope decodes a JSON response body whose rows use the keys in mapping.
+func decodeEnvelope(data []byte, mapping FieldMapping) (*apiEnvelope, error) {
+	return decodeEnvelopeRows(data, mapping, false)
+}
+
+// decodeEnvelopeRows is decodeEnvelope, additionally keeping the score text of
+// each row as sent when keepRaw is set.
+func decodeEnvelopeRows(data []byte, mapping FieldMapping, keepRaw bool) (*apiEnvelope, error) {
+	var envelope apiEnvelope
+	if mapping == DefaultFieldMapping && !keepRaw {
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+		}
+		if err := envelope.err(); err != nil {
+			return nil, err
+		}
+		return &envelope, nil
+	}
+
+	// Renamed keys cannot use the struct tags, and raw text is not worth
+	// keeping on the fast path, so rows are decoded key by key.
+	var raw struct {
+		apiEnvelope
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	envelope = raw.apiEnvelope
+	if err := envelope.err(); err != nil {
+		return nil, err
+	}
+	if raw.Data != nil {
+		envelope.Data = make([]apiRow, len(raw.Data))
+	}
+	for i, fields := range raw.Data {
+		if err := decodeRow(fields, mapping, keepRaw, &envelope.Data[i]); err != nil {
+			return nil, err
+		}
+	}
+	return &envelope, nil
+}
+
+// decodeRow decodes one response row, and its time series, key by key.
+func decodeRow(fields map[string]json.RawMessage, mapping FieldMapping, keepRaw bool, row *apiRow) error {
+	for key, dst := range map[string]interface{}{
+		mapping.CVE:        &row.CVE,
+		mapping.EPSS:       &row.EPSS,
+		mapping.Percentile: &row.Percentile,
+		mapping.Date:       &row.Date,
+	} {
+		value, ok := fields[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(value, dst); err != nil {
+			return fmt.Errorf("failed to unmarshal %s field: %w", key, err)
+		}
+	}
+	if keepRaw {
+		row.EPSS.Raw = rawNumber(fields[mapping.EPSS])
+		row.Percentile.Raw = rawNumber(fields[mapping.Percentile])
+	}
+
+	series, ok := fields["time-series"]
+	if !ok {
+		return nil
+	}
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(series, &entries); err != nil {
+		return fmt.Errorf("failed to unmarshal time-series field: %w", err)
+	}
+	row.TimeSeries = make([]apiRow, len(entries))
+	for i, entry := range entries {
+		if err := decodeRow(entry, mapping, keepRaw, &row.TimeSeries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rawNumber returns the text of a number sent as a JSON number or string.
+func rawNumber(value json.RawMessage) string {
+	if value == nil || string(value) == "null" {
+		return ""
+	}
+	return strings.Trim(string(value), `"`)
+}
+
+// cves converts the envelope's rows to CVEs. Time series entries follow the
+// row they belong to.
+func (e *apiEnvelope) cves() ([]models.CVE, error) {
+	if e.Data == nil {
+		return nil, fmt.Errorf("missing data field")
+	}
+	cves := make([]models.CVE, 0, len(e.Data))
+	for _, row := range e.Data {
+		cve, err := row.cve()
+		if err != nil {
+			return nil, err
+		}
+		cves = append(cves, cve)
+		for _, entry := range row.TimeSeries {
+			entry.CVE = row.CVE
+			cve, err := entry.cve()
+			if err != nil {
+				return nil, fmt.Errorf("invalid time series entry for %s: %w", row.CVE, err)
+			}
+			cves = append(cves, cve)
+		}
+	}
+	return cves, nil
+}
+
+// cve converts a row to a CVE, requiring every field.
+func (r apiRow) cve() (models.CVE, error) {
+	switch {
+	case r.CVE == "":
+		return models.CVE{}, fmt.Errorf("missing cve field")
+	case !r.EPSS.Set:
+		return models.CVE{}, fmt.Errorf("missing epss field")
+	case !r.Percentile.Set:
+		return models.CVE{}, fmt.Errorf("missing percentile field")
+	case r.Date == "":
+		return models.CVE{}, fmt.Errorf("missing date field")
+	}
+	return models.CVE{ID: r.CVE, EPSSScore: r.EPSS.Value, Percentile: r.Percentile.Value, Date: r.Date,
+		RawEPSS: r.EPSS.Raw, RawPercentile: r.Percentile.Raw}, nil
+}
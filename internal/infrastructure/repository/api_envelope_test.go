@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEnvelope(t *testing.T) {
+	t.Run("Success - Decodes Metadata Fields", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"status":"OK","status-code":200,"version":"1.0","total":250000,"offset":100,"limit":2,"data":[]}`), DefaultFieldMapping)
+
+		require.NoError(t, err)
+		assert.Equal(t, "OK", envelope.Status)
+		require.NotNil(t, envelope.Total)
+		assert.Equal(t, 250000, *envelope.Total)
+		assert.Equal(t, 100, envelope.Offset)
+		assert.Equal(t, 2, envelope.Limit)
+	})
+
+	t.Run("Fail - Error Status In Body", func(t *testing.T) {
+		_, err := decodeEnvelope([]byte(`{"status":"Bad Request","status-code":400,"message":"invalid parameter","data":[]}`), DefaultFieldMapping)
+
+		assert.EqualError(t, err, "API reported status 400 (Bad Request): invalid parameter")
+	})
+
+	t.Run("Fail - Error Status With Mapped Fields", func(t *testing.T) {
+		_, err := decodeEnvelope([]byte(`{"status-code":422,"data":[]}`), FieldMapping{CVE: "id", EPSS: "score", Percentile: "percentile", Date: "date"})
+
+		assert.EqualError(t, err, "API reported status 422")
+	})
+
+	t.Run("Success - Scores As Strings Or Numbers", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"data":[
+			{"cve":"CVE-2023-0001","epss":"0.00044","percentile":"0.13","date":"2024-10-18"},
+			{"cve":"CVE-2023-0002","epss":0.5,"percentile":0.99,"date":"2024-10-18"}]}`), DefaultFieldMapping)
+		require.NoError(t, err)
+
+		cves, err := envelope.cves()
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.00044, Percentile: 0.13, Date: "2024-10-18"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.5, Percentile: 0.99, Date: "2024-10-18"},
+		}, cves)
+	})
+
+	t.Run("Success - Scores In Scientific Notation", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"data":[
+			{"cve":"CVE-2023-0001","epss":"1.2e-05","percentile":"3.4E-3","date":"2024-10-18"},
+			{"cve":"CVE-2023-0002","epss":1.23456789e-07,"percentile":1e-2,"date":"2024-10-18"}]}`), DefaultFieldMapping)
+		require.NoError(t, err)
+
+		cves, err := envelope.cves()
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.000012, Percentile: 0.0034, Date: "2024-10-18"},
+			{ID: "CVE-2023-0002", EPSSScore: 0.000000123456789, Percentile: 0.01, Date: "2024-10-18"},
+		}, cves)
+	})
+
+	t.Run("Success - Time Series Entries Follow Their Row", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"data":[{"cve":"CVE-2023-0001","epss":"0.3","percentile":"0.9","date":"2024-10-18",
+			"time-series":[{"epss":"0.2","percentile":"0.8","date":"2024-10-17"},{"epss":"0.1","percentile":"0.7","date":"2024-10-16"}]}]}`), DefaultFieldMapping)
+		require.NoError(t, err)
+
+		cves, err := envelope.cves()
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.3, Percentile: 0.9, Date: "2024-10-18"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.2, Percentile: 0.8, Date: "2024-10-17"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.1, Percentile: 0.7, Date: "2024-10-16"},
+		}, cves)
+	})
+
+	t.Run("Success - Missing Total Is Distinguishable From Zero", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"data":[]}`), DefaultFieldMapping)
+
+		require.NoError(t, err)
+		assert.Nil(t, envelope.Total)
+	})
+
+	t.Run("Fail - Unparseable Score", func(t *testing.T) {
+		_, err := decodeEnvelope([]byte(`{"data":[{"cve":"CVE-2023-0001","epss":"high","percentile":"0.13","date":"2024-10-18"}]}`), DefaultFieldMapping)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail - Missing Row Field", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"data":[{"cve":"CVE-2023-0001","percentile":"0.13","date":"2024-10-18"}]}`), DefaultFieldMapping)
+		require.NoError(t, err)
+
+		_, err = envelope.cves()
+
+		assert.EqualError(t, err, "missing epss field")
+	})
+
+	t.Run("Fail - Missing Data", func(t *testing.T) {
+		envelope, err := decodeEnvelope([]byte(`{"status":"OK"}`), DefaultFieldMapping)
+		require.NoError(t, err)
+
+		_, err = envelope.cves()
+
+		assert.EqualError(t, err, "missing data field")
+	})
+
+	t.Run("Success - Remapped Keys", func(t *testing.T) {
+		mapping, err := ParseFieldMapping("cve=id, epss=score,percentile=pct,date=day")
+		require.NoError(t, err)
+
+		envelope, err := decodeEnvelope([]byte(`{"total":1,"data":[{"id":"CVE-2023-0001","score":"0.5","pct":0.9,"day":"2024-10-18","extra":true}]}`), mapping)
+		require.NoError(t, err)
+		cves, err := envelope.cves()
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.5, Percentile: 0.9, Date: "2024-10-18"}}, cves)
+		assert.Equal(t, 1, *envelope.Total)
+	})
+
+	t.Run("Fail - Remapped Key Missing", func(t *testing.T) {
+		mapping, err := ParseFieldMapping("epss=score")
+		require.NoError(t, err)
+
+		envelope, err := decodeEnvelope([]byte(`{"data":[{"cve":"CVE-2023-0001","epss":"0.5","percentile":"0.9","date":"2024-10-18"}]}`), mapping)
+		require.NoError(t, err)
+		_, err = envelope.cves()
+
+		assert.EqualError(t, err, "missing epss field")
+	})
+
+	t.Run("Success - Keeps Raw Values", func(t *testing.T) {
+		envelope, err := decodeEnvelopeRows([]byte(`{"data":[{"cve":"CVE-2023-0001","epss":"0.000440000","percentile":1.0e-1,"date":"2024-10-18",
+			"time-series":[{"epss":"0.000430000","percentile":"0.098000000","date":"2024-10-17"}]}]}`), DefaultFieldMapping, true)
+		require.NoError(t, err)
+		cves, err := envelope.cves()
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.CVE{
+			{ID: "CVE-2023-0001", EPSSScore: 0.00044, Percentile: 0.1, Date: "2024-10-18", RawEPSS: "0.000440000", RawPercentile: "1.0e-1"},
+			{ID: "CVE-2023-0001", EPSSScore: 0.00043, Percentile: 0.098, Date: "2024-10-17", RawEPSS: "0.000430000", RawPercentile: "0.098000000"},
+		}, cves)
+	})
+}
+
+func TestCheckStatus(t *testing.T) {
+	t.Run("Success - OK Status", func(t *testing.T) {
+		assert.NoError(t, checkStatus([]byte(`{"status":"OK","status-code":200,"total":1,"data":[]}`)))
+	})
+
+	t.Run("Success - Rows Are Left For The Caller", func(t *testing.T) {
+		assert.NoError(t, checkStatus([]byte(`{"status":"OK","data":[{"cve":`)))
+	})
+
+	t.Run("Fail - Error Status", func(t *testing.T) {
+		err := checkStatus([]byte(`{"status":"Bad Request","status-code":400,"message":"invalid parameter"}`))
+
+		assert.EqualError(t, err, "API reported status 400 (Bad Request): invalid parameter")
+	})
+
+	t.Run("Fail - Body Is Not An Object", func(t *testing.T) {
+		assert.Error(t, checkStatus([]byte(`[{"cve":"CVE-2023-0001"}]`)))
+		assert.Error(t, checkStatus([]byte(`not json`)))
+	})
+}
+
+func TestParseFieldMapping(t *testing.T) {
+	t.Run("Success - Empty Is Default", func(t *testing.T) {
+		mapping, err := ParseFieldMapping("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultFieldMapping, mapping)
+	})
+
+	t.Run("Fail - Invalid Entries", func(t *testing.T) {
+		for _, list := range []string{"epss", "epss=", "score=epss"} {
+			_, err := ParseFieldMapping(list)
+			assert.Error(t, err, list)
+		}
+	})
+}
+
+func TestDecodeMalformedResponses(t *testing.T) {
+	bodies := map[string]string{
+		"Data Is An Object":        `{"data":{"cve":"CVE-2023-0001"}}`,
+		"Data Is A String":         `{"data":"CVE-2023-0001"}`,
+		"Element Is A Number":      `{"data":[42]}`,
+		"Element Is A String":      `{"data":["CVE-2023-0001"]}`,
+		"Element Is An Array":      `{"data":[["CVE-2023-0001","0.5"]]}`,
+		"Element Is Null":          `{"data":[null]}`,
+		"Element Has No Fields":    `{"data":[{}]}`,
+		"CVE Is A Number":          `{"data":[{"cve":1,"epss":"0.5","percentile":"0.9","date":"2024-10-18"}]}`,
+		"Score Is An Object":       `{"data":[{"cve":"CVE-2023-0001","epss":{},"percentile":"0.9","date":"2024-10-18"}]}`,
+		"Body Is An Array":         `[{"cve":"CVE-2023-0001"}]`,
+		"Body Is Truncated":        `{"data":[{"cve":"CVE-2023-0001",`,
+		"Total Is A String":        `{"total":"many","data":[]}`,
+		"Time Series Is An Object": `{"data":[{"cve":"CVE-2023-0001","epss":"0.5","percentile":"0.9","date":"2024-10-18","time-series":{}}]}`,
+	}
+	// Raw values are decoded key by key, like remapped fields, rather than
+	// through the struct tags, so both decoding paths are covered.
+	paths := map[string]bool{"Struct Decoding": false, "Key By Key Decoding": true}
+
+	for pathName, keepRaw := range paths {
+		for name, body := range bodies {
+			t.Run("Fail - "+name+" With "+pathName, func(t *testing.T) {
+				assert.NotPanics(t, func() {
+					envelope, err := decodeEnvelopeRows([]byte(body), DefaultFieldMapping, keepRaw)
+					if err == nil {
+						_, err = envelope.cves()
+					}
+					assert.Error(t, err)
+				})
+			})
+		}
+	}
+}
@@ -1,7 +1,8 @@
 package repository
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -9,20 +10,197 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
 	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
 	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
 )
 
+// ResponseCache stores raw API responses keyed by request URL.
+type ResponseCache interface {
+	Get(key string, date string) ([]byte, bool)
+	Set(key string, date string, body []byte) error
+}
+
+// CVECache stores decoded CVE rows keyed by request URL.
+type CVECache interface {
+	GetCVEs(key string, date string) ([]models.CVE, bool)
+	SetCVEs(key string, date string, cves []models.CVE) error
+}
+
 // apiRepository implements the ports.EPSSRepository interface using the First.org EPSS API.
 type apiRepository struct {
-	baseURL string
+	baseURL      string
+	client       *http.Client
+	cache        ResponseCache
+	cveCache     CVECache
+	cacheOnly    bool
+	maxURLLength int
+	pageSize     int
+	fieldMapping FieldMapping
+	pretty       bool
+	rawValues    bool
+	debugHeaders bool
+	scope        string
+	retries      int
+	retryDelay   time.Duration
+	sleeper      Sleeper
+	observe      func(params map[string]string)
+
+	// requestTimeout bounds each request attempt; 0 means no limit.
+	requestTimeout time.Duration
+
+	// memo holds responses downloaded during this repository's lifetime so
+	// repeated requests within one command are served without refetching.
+	// It is nil when WithoutMemo is given.
+	memoMu sync.Mutex
+	memo   map[string][]byte
+}
+
+// DefaultBaseURL is the First.org EPSS API endpoint.
+const DefaultBaseURL = "https://api.first.org/data/v1/epss"
+
+// DefaultMaxURLLength keeps batch request URLs within limits commonly enforced by servers and proxies.
+const DefaultMaxURLLength = 2000
+
+// DefaultTimeout bounds each request sent through the default HTTP client,
+// so a hung connection fails instead of blocking forever.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultPageSize is the number of rows requested per page by paginated queries.
+const DefaultPageSize = 1000
+
+// Option configures an apiRepository.
+type Option func(*apiRepository)
+
+// WithCache serves responses from c when fresh and stores new responses in it.
+func WithCache(c ResponseCache) Option {
+	return func(r *apiRepository) {
+		r.cache = c
+	}
+}
+
+// WithCVECache serves decoded CVE lists from c when fresh and stores new ones in it.
+// Unlike WithCache, hits skip JSON decoding entirely.
+func WithCVECache(c CVECache) Option {
+	return func(r *apiRepository) {
+		r.cveCache = c
+	}
+}
+
+// WithMaxURLLength caps the length of batch request URLs, splitting large batches into several requests.
+func WithMaxURLLength(n int) Option {
+	return func(r *apiRepository) {
+		r.maxURLLength = n
+	}
+}
+
+// WithPageSize sets the number of rows requested per page by paginated queries.
+func WithPageSize(n int) Option {
+	return func(r *apiRepository) {
+		r.pageSize = n
+	}
+}
+
+// WithFieldMapping decodes response rows using the JSON keys in m, for mirrors
+// that rename the First.org fields.
+func WithFieldMapping(m FieldMapping) Option {
+	return func(r *apiRepository) {
+		r.fieldMapping = m
+	}
+}
+
+// WithHTTPClient sends requests through client instead of the default client,
+// which times out after DefaultTimeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *apiRepository) {
+		r.client = client
+	}
+}
+
+// WithRequestTimeout aborts any single request attempt that takes longer than d,
+// such as one stalled by the API, including reading its body. Unlike the context
+// passed to each query, it does not stop retries: a timed-out attempt is retried when
+// WithRetries allows.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(r *apiRepository) {
+		r.requestTimeout = d
+	}
+}
+
+// WithoutMemo fetches every request from the API or cache instead of reusing the
+// responses this repository has already downloaded, which are otherwise kept for its
+// whole lifetime. Use it for repositories that outlive a single command.
+func WithoutMemo() Option {
+	return func(r *apiRepository) {
+		r.memo = nil
+	}
+}
+
+// NewAPIRepository creates a new apiRepository instance whose HTTP client
+// times out requests after DefaultTimeout.
+func NewAPIRepository(baseURL string, opts ...Option) ports.EPSSRepository {
+	return NewAPIRepositoryWithClient(baseURL, &http.Client{Timeout: DefaultTimeout}, opts...)
+}
+
+// NewAPIRepositoryWithClient creates an apiRepository that sends requests
+// through client. The client's Timeout, if any, bounds each request.
+func NewAPIRepositoryWithClient(baseURL string, client *http.Client, opts ...Option) ports.EPSSRepository {
+	r := &apiRepository{baseURL: baseURL, client: client, maxURLLength: DefaultMaxURLLength, pageSize: DefaultPageSize, fieldMapping: DefaultFieldMapping, retryDelay: DefaultRetryDelay, sleeper: realSleeper, memo: make(map[string][]byte)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NewCacheOnlyRepository creates a repository that answers from cache alone, returning
+// apierr.ErrCacheMiss instead of contacting the API. It reads the entries written by an
+// API repository with the same base URL.
+func NewCacheOnlyRepository(baseURL string, cache ResponseCache) ports.EPSSRepository {
+	r := NewAPIRepository(baseURL, WithCache(cache)).(*apiRepository)
+	r.cacheOnly = true
+	return r
+}
+
+// WithPrettyResponses asks the API for indented JSON, which is easier to read when
+// debugging raw responses. Parsing is unaffected.
+func WithPrettyResponses() Option {
+	return func(r *apiRepository) {
+		r.pretty = true
+	}
+}
+
+// WithRawValues keeps the epss and percentile text of each row exactly as the API
+// sent it in CVE.RawEPSS and CVE.RawPercentile, alongside the parsed values.
+// Decoding is slower, so this is off by default.
+func WithRawValues() Option {
+	return func(r *apiRepository) {
+		r.rawValues = true
+	}
+}
+
+// Scopes lists the values the API accepts for its scope parameter.
+var Scopes = []string{"public", "time-series"}
+
+// ValidateScope checks that scope is one of Scopes.
+func ValidateScope(scope string) error {
+	for _, s := range Scopes {
+		if scope == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported scope: %s (expected %s)", scope, strings.Join(Scopes, " or "))
 }
 
-// NewAPIRepository creates a new apiRepository instance.
-func NewAPIRepository(baseURL string) ports.EPSSRepository {
-	return &apiRepository{baseURL: baseURL}
+// WithScope sends scope (see ValidateScope) as the API's scope parameter on
+// every query that does not set its own; time series queries keep time-series.
+func WithScope(scope string) Option {
+	return func(r *apiRepository) {
+		r.scope = scope
+	}
 }
 
 // buildURL constructs the API URL with the given parameters.
@@ -36,48 +214,224 @@ func (r *apiRepository) buildURL(params map[string]string) (string, error) {
 	for k, v := range params {
 		query.Add(k, v)
 	}
+	if r.scope != "" && query.Get("scope") == "" {
+		query.Set("scope", r.scope)
+	}
+	if r.pretty {
+		query.Set("pretty", "true")
+	}
+	if r.observe != nil {
+		r.observe(redactedParams(query))
+	}
 	base.RawQuery = query.Encode()
 	return base.String(), nil
 }
 
-// fetchData fetches data from the specified API URL.
-func (r *apiRepository) fetchData(url string) ([]byte, error) {
+// fetchData fetches data from the specified API URL, consulting the cache first when one is configured.
+// ctx bounds the network request. When the cache keeps validators, an expired entry is revalidated with a
+// conditional request and served again if the API answers 304 Not Modified. memoize is passed to download.
+func (r *apiRepository) fetchData(ctx context.Context, url string, memoize bool) ([]byte, error) {
+	date := queryDate(url)
+	if r.cache != nil {
+		if data, ok := r.cache.Get(url, date); ok {
+			log.Printf("Using cached data for: %s", url)
+			return data, nil
+		}
+	}
+	if r.cacheOnly {
+		return nil, fmt.Errorf("%w for %s", apierr.ErrCacheMiss, url)
+	}
+
+	validatorCache, revalidate := r.cache.(ValidatorCache)
+	var stale []byte
+	var cond validators
+	if revalidate {
+		if body, etag, lastModified, ok := validatorCache.GetStale(url); ok {
+			stale, cond = body, validators{etag: etag, lastModified: lastModified}
+		}
+	}
+
+	resp, err := r.download(ctx, url, cond, memoize)
+	if err != nil {
+		return nil, err
+	}
+	if resp.notModified {
+		log.Printf("Not modified, using cached data for: %s", url)
+		if err := validatorCache.Touch(url); err != nil {
+			log.Printf("Failed to refresh cache entry for %s: %v", url, err)
+		}
+		return stale, nil
+	}
+
+	if r.cache != nil {
+		if err := r.cache.Set(url, date, resp.body); err != nil {
+			log.Printf("Failed to cache response for %s: %v", url, err)
+		} else if revalidate {
+			if err := validatorCache.SetValidators(url, resp.etag, resp.lastModified); err != nil {
+				log.Printf("Failed to cache validators for %s: %v", url, err)
+			}
+		}
+	}
+	return resp.body, nil
+}
+
+// download fetches url from the network, bypassing any cache. When memoize is
+// set, responses already downloaded by this repository are reused and new ones
+// kept, unless WithoutMemo was given; paged queries leave it unset so a day
+// read page by page is not held in memory. cond makes the request conditional
+// when it holds validators.
+func (r *apiRepository) download(ctx context.Context, url string, cond validators, memoize bool) (response, error) {
+	memoize = memoize && r.memo != nil
+	if memoize {
+		r.memoMu.Lock()
+		data, ok := r.memo[url]
+		r.memoMu.Unlock()
+		if ok {
+			return response{body: data}, nil
+		}
+	}
+
+	resp, err := r.fetchWithRetry(ctx, url, cond)
+	if err != nil {
+		return response{}, err
+	}
+	if memoize && !resp.notModified {
+		r.memoMu.Lock()
+		r.memo[url] = resp.body
+		r.memoMu.Unlock()
+	}
+	return resp, nil
+}
+
+// fetchURL performs the HTTP request for url, sending If-None-Match and
+// If-Modified-Since when cond holds validators. An attempt outlasting
+// WithRequestTimeout fails with apierr.ErrRequestTimeout.
+func (r *apiRepository) fetchURL(ctx context.Context, url string, cond validators) (response, error) {
 	log.Printf("Fetching data from: %s", url)
-	resp, err := http.Get(url)
+	if r.requestTimeout > 0 {
+		attemptCtx, cancel := context.WithTimeout(ctx, r.requestTimeout)
+		defer cancel()
+		resp, err := r.doFetch(attemptCtx, url, cond)
+		if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+			return response{}, fmt.Errorf("failed to fetch data from %s: %w after %s", url, apierr.ErrRequestTimeout, r.requestTimeout)
+		}
+		return resp, err
+	}
+	return r.doFetch(ctx, url, cond)
+}
+
+// doFetch performs the request of fetchURL within ctx.
+func (r *apiRepository) doFetch(ctx context.Context, url string, cond validators) (response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data from %s: %w", url, err)
+		return response{}, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	cond.apply(req)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return response{}, fmt.Errorf("failed to fetch data from %s: %w", url, err)
 	}
 	defer resp.Body.Close()
+	if r.debugHeaders {
+		logResponseHeaders(url, resp.Header)
+	}
 
+	if resp.StatusCode == http.StatusNotModified && !cond.empty() {
+		return response{notModified: true}, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+		return response{}, &apierr.StatusError{StatusCode: resp.StatusCode, URL: url}
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return response{}, fmt.Errorf("failed to read response from %s: %w", url, err)
+	}
+	if err := checkStatus(data); err != nil {
+		return response{}, fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	return response{body: data, etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified")}, nil
 }
 
-// GetCVEScore retrieves the EPSS score for a given CVE ID and optional date.
-func (r *apiRepository) GetCVEScore(cveID string, date string) (*models.CVE, error) {
-	params := map[string]string{"cve": cveID}
-	if date != "" {
-		params["date"] = date
+// fetchCVEs fetches url and decodes the CVE rows of the response. When a CVE cache is configured,
+// decoded rows are served from and stored in it instead of the response cache, skipping JSON
+// decoding on hits. Raw values are not kept in the CVE cache, so it is bypassed when they are requested.
+// ctx bounds the network request.
+func (r *apiRepository) fetchCVEs(ctx context.Context, url string) ([]models.CVE, error) {
+	cves, _, err := r.fetchPage(ctx, url, true)
+	return cves, err
+}
+
+// pageInfo holds the pagination fields of a response envelope.
+type pageInfo struct {
+	total  *int
+	offset int
+}
+
+// fetchPage is fetchCVEs, also returning the pagination fields of the response. They are
+// left zero when the rows come from the CVE cache, which keeps no envelope. memoize is
+// passed to download.
+func (r *apiRepository) fetchPage(ctx context.Context, url string, memoize bool) ([]models.CVE, pageInfo, error) {
+	if r.cveCache == nil || r.rawValues {
+		data, err := r.fetchData(ctx, url, memoize)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		return r.decodePage(data)
 	}
-	url, err := r.buildURL(params)
+
+	date := queryDate(url)
+	if cves, ok := r.cveCache.GetCVEs(url, date); ok {
+		log.Printf("Using cached data for: %s", url)
+		return cves, pageInfo{}, nil
+	}
+	resp, err := r.download(ctx, url, validators{}, memoize)
 	if err != nil {
-		return nil, err
+		return nil, pageInfo{}, err
 	}
-	data, err := r.fetchData(url)
+	cves, page, err := r.decodePage(resp.body)
 	if err != nil {
-		return nil, err
+		return nil, pageInfo{}, err
+	}
+	if err := r.cveCache.SetCVEs(url, date, cves); err != nil {
+		log.Printf("Failed to cache response for %s: %v", url, err)
+	}
+	return cves, page, nil
+}
+
+// decodePage decodes the CVE rows and pagination fields of a JSON response body.
+func (r *apiRepository) decodePage(data []byte) ([]models.CVE, pageInfo, error) {
+	envelope, err := decodeEnvelopeRows(data, r.fieldMapping, r.rawValues)
+	if err != nil {
+		return nil, pageInfo{}, err
+	}
+	cves, err := envelope.cves()
+	if err != nil {
+		return nil, pageInfo{}, err
 	}
+	return cves, pageInfo{total: envelope.Total, offset: envelope.Offset}, nil
+}
 
-	var result interface{}
-	err = json.Unmarshal(data, &result)
+// queryDate returns the date query parameter of rawURL, or "" when absent.
+func queryDate(rawURL string) string {
+	u, err := url.Parse(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+		return ""
 	}
+	return u.Query().Get("date")
+}
 
-	cveData, err := convertAPIResponseToCVEData(result)
+// GetCVEScore retrieves the EPSS score for a given CVE ID and optional date.
+func (r *apiRepository) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	params := map[string]string{"cve": cveID}
+	if date != "" {
+		params["date"] = date
+	}
+	url, err := r.buildURL(params)
+	if err != nil {
+		return nil, err
+	}
+	cveData, err := r.fetchCVEs(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -89,251 +443,405 @@ func (r *apiRepository) GetCVEScore(cveID string, date string) (*models.CVE, err
 	return &cveData[0], nil
 }
 
-// GetTopNCVEs retrieves the top N CVEs based on EPSS score.
-func (r *apiRepository) GetTopNCVEs(n int) ([]models.CVE, error) {
-	params := map[string]string{"order": "!epss", "limit": strconv.Itoa(n)}
-	url, err := r.buildURL(params)
+// GetCVEScores retrieves EPSS scores for several CVE IDs, batching them into as few requests as the
+// maximum URL length allows. CVEs missing from the response are omitted from the result.
+func (r *apiRepository) GetCVEScores(ctx context.Context, cveIDs []string, date string) ([]models.CVE, error) {
+	params := map[string]string{}
+	if date != "" {
+		params["date"] = date
+	}
+	chunks, err := r.chunkCVEs(cveIDs, params)
 	if err != nil {
 		return nil, err
 	}
-	data, err := r.fetchData(url)
+
+	var cves []models.CVE
+	for _, chunk := range chunks {
+		params["cve"] = strings.Join(chunk, ",")
+		params["limit"] = strconv.Itoa(len(chunk))
+		url, err := r.buildURL(params)
+		if err != nil {
+			return nil, err
+		}
+		batch, err := r.fetchCVEs(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		cves = append(cves, batch...)
+	}
+	return cves, nil
+}
+
+// chunkCVEs splits cveIDs into groups whose request URL, built from params plus the cve and limit
+// parameters, stays within maxURLLength. Chunk sizes vary with the length of the IDs.
+func (r *apiRepository) chunkCVEs(cveIDs []string, params map[string]string) ([][]string, error) {
+	base := make(map[string]string, len(params)+2)
+	for k, v := range params {
+		base[k] = v
+	}
+	base["cve"] = ""
+	base["limit"] = strconv.Itoa(len(cveIDs))
+	baseURL, err := r.buildURL(base)
 	if err != nil {
 		return nil, err
 	}
+	budget := r.maxURLLength - len(baseURL)
+	separator := len(url.QueryEscape(","))
 
-	var result interface{}
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	var chunks [][]string
+	var chunk []string
+	size := 0
+	for _, id := range cveIDs {
+		n := len(url.QueryEscape(id))
+		if len(chunk) > 0 {
+			n += separator
+		}
+		if size+n > budget && len(chunk) > 0 {
+			chunks = append(chunks, chunk)
+			chunk, size = nil, 0
+			n -= separator
+		}
+		if n > budget {
+			return nil, fmt.Errorf("CVE ID %q does not fit within the maximum URL length of %d", id, r.maxURLLength)
+		}
+		chunk = append(chunk, id)
+		size += n
 	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
 
-	cves, err := convertAPIResponseToCVEDataArray(result)
+// GetTopNCVEs retrieves the top N CVEs based on EPSS score. An n above the page size is
+// fetched page by page.
+func (r *apiRepository) GetTopNCVEs(ctx context.Context, n int) ([]models.CVE, error) {
+	if n > r.pageSize {
+		return r.fetchPages(ctx, map[string]string{"order": "!epss"}, n)
+	}
+	params := map[string]string{"order": "!epss", "limit": strconv.Itoa(n)}
+	url, err := r.buildURL(params)
 	if err != nil {
 		return nil, err
 	}
-	return cves, nil
+	return r.fetchCVEs(ctx, url)
 }
 
-func (r *apiRepository) GetHighestIncreases(days int, limit int) ([]models.ScoreChange, error) {
-    now := time.Now()
-    startDate := now.AddDate(0, 0, -days)
-
-    // Create a map to store the highest score change for each CVE
-    scoreChangesMap := make(map[string]float64)
-
-    // Loop through each day in the past X days and fetch the data
-    for i := 0; i <= days; i++ {
-        date := startDate.AddDate(0, 0, i).Format("2006-01-02")
-        params := map[string]string{"date": date}
-        url, err := r.buildURL(params)
-        if err != nil {
-            return nil, err
-        }
-
-        data, err := r.fetchData(url)
-        if err != nil {
-            return nil, err
-        }
-
-        var result map[string]interface{}
-        err = json.Unmarshal(data, &result)
-        if err != nil {
-            return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
-        }
-
-        cveList, err := convertAPIResponseToCVEDataArray(result)
-        if err != nil {
-            return nil, err
-        }
-
-        // Iterate over the data and calculate the score changes
-        for _, cve := range cveList {
-            initialScore, exists := scoreChangesMap[cve.ID]
-            if exists {
-                // Calculate the score change and update only if the new score change is higher
-                scoreChange := cve.EPSSScore - initialScore
-                if scoreChange > scoreChangesMap[cve.ID] {
-                    scoreChangesMap[cve.ID] = scoreChange
-                }
-            } else {
-                // Initialize the score change with the current EPSS score
-                scoreChangesMap[cve.ID] = cve.EPSSScore
-            }
-        }
-    }
-
-    // Convert the score changes map to a list of ScoreChange structs
-    var scoreChanges []models.ScoreChange
-    for cveID, scoreChange := range scoreChangesMap {
-        scoreChanges = append(scoreChanges, models.ScoreChange{
-            CVE:         cveID,
-            Date:        now,  // Store the current date for the score change entry
-            ScoreChange: scoreChange,
-        })
-    }
-
-    // Sort by the highest score changes
-    sort.Slice(scoreChanges, func(i, j int) bool {
-        return scoreChanges[i].ScoreChange > scoreChanges[j].ScoreChange
-    })
-
-    // Limit the result to the top N CVEs
-    if len(scoreChanges) > limit {
-        scoreChanges = scoreChanges[:limit]
-    }
-
-    return scoreChanges, nil
+// GetHighestIncreases returns the limit CVEs whose EPSS score rose the most over the past days
+// days, from the earliest to the latest day each was scored. CVEs scored on one day only are skipped.
+func (r *apiRepository) GetHighestIncreases(ctx context.Context, days int, limit int) ([]models.ScoreChange, error) {
+	now := time.Now()
+	startDate := now.AddDate(0, 0, -days)
+
+	// Days are fetched oldest first, so the first score seen for a CVE is its
+	// earliest in the range and the last one its latest.
+	type span struct {
+		first, last float64
+		lastDate    time.Time
+		days        int
+	}
+	spans := make(map[string]*span)
+	for i := 0; i <= days; i++ {
+		day := startDate.AddDate(0, 0, i)
+		params := map[string]string{"date": day.Format("2006-01-02")}
+		url, err := r.buildURL(params)
+		if err != nil {
+			return nil, err
+		}
+
+		cveList, err := r.fetchCVEs(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		for _, cve := range cveList {
+			sp, ok := spans[cve.ID]
+			if !ok {
+				sp = &span{first: cve.EPSSScore}
+				spans[cve.ID] = sp
+			}
+			sp.last = cve.EPSSScore
+			sp.lastDate = day
+			sp.days++
+		}
+	}
+
+	// CVEs seen on a single day have no change to report, and only
+	// increases are kept.
+	var scoreChanges []models.ScoreChange
+	for cveID, sp := range spans {
+		change := sp.last - sp.first
+		if sp.days < 2 || change <= 0 {
+			continue
+		}
+		scoreChanges = append(scoreChanges, models.ScoreChange{
+			CVE:         cveID,
+			Date:        sp.lastDate,
+			ScoreChange: change,
+		})
+	}
+
+	sort.Slice(scoreChanges, func(i, j int) bool {
+		if scoreChanges[i].ScoreChange != scoreChanges[j].ScoreChange {
+			return scoreChanges[i].ScoreChange > scoreChanges[j].ScoreChange
+		}
+		return scoreChanges[i].CVE < scoreChanges[j].CVE
+	})
+	if len(scoreChanges) > limit {
+		scoreChanges = scoreChanges[:limit]
+	}
+	return scoreChanges, nil
 }
 
 
 // GetCVEsForDate retrieves CVEs for a specific date.
-func (r *apiRepository) GetCVEsForDate(date string) ([]models.CVE, error) {
+func (r *apiRepository) GetCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	return r.GetCVEsForDateOrdered(ctx, date, models.Unordered)
+}
+
+// GetCVEsForDateOrdered retrieves CVEs for a specific date, asking the API to sort them by order.
+func (r *apiRepository) GetCVEsForDateOrdered(ctx context.Context, date string, order models.Ordering) ([]models.CVE, error) {
 	params := map[string]string{"date": date}
-	url, err := r.buildURL(params)
-	if err != nil {
-		return nil, err
+	if o := orderParam(order); o != "" {
+		params["order"] = o
 	}
-	data, err := r.fetchData(url)
+	url, err := r.buildURL(params)
 	if err != nil {
 		return nil, err
 	}
+	return r.fetchCVEs(ctx, url)
+}
 
-	var result interface{}
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+// GetAllCVEsForDate retrieves every CVE scored on a date (the latest data when date is empty),
+// following pagination. This downloads the full dataset, so pair it with a cache.
+func (r *apiRepository) GetAllCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	params := map[string]string{}
+	if date != "" {
+		params["date"] = date
 	}
+	return r.fetchAllPages(ctx, params)
+}
 
-	cves, err := convertAPIResponseToCVEDataArray(result)
-	if err != nil {
-		return nil, err
+// GetCVEsForDatePaged retrieves the CVEs for date (the latest data when empty) page by page
+// until the total reported by the API is reached, stopping once maxResults rows have been
+// fetched. A maxResults of 0 or less fetches every row.
+func (r *apiRepository) GetCVEsForDatePaged(ctx context.Context, date string, maxResults int) ([]models.CVE, error) {
+	params := map[string]string{}
+	if date != "" {
+		params["date"] = date
 	}
-	return cves, nil
+	return r.fetchPages(ctx, params, maxResults)
 }
 
 // GetTimeSeries retrieves time series data for a given CVE ID.
-func (r *apiRepository) GetTimeSeries(cveID string) ([]models.CVE, error) {
+func (r *apiRepository) GetTimeSeries(ctx context.Context, cveID string) ([]models.CVE, error) {
 	params := map[string]string{"cve": cveID, "scope": "time-series"}
 	url, err := r.buildURL(params)
 	if err != nil {
 		return nil, err
 	}
-	data, err := r.fetchData(url)
+	return r.fetchCVEs(ctx, url)
+}
+
+// GetCVEsAboveThreshold retrieves CVEs above a specified threshold for a given field (epss or percentile).
+func (r *apiRepository) GetCVEsAboveThreshold(ctx context.Context, threshold float64, field string) ([]models.CVE, error) {
+	return r.GetCVEsAboveThresholdOrdered(ctx, threshold, field, models.Unordered)
+}
+
+// GetCVEsAboveThresholdOrdered retrieves CVEs above a threshold for a given field, asking the API
+// to sort them by order.
+func (r *apiRepository) GetCVEsAboveThresholdOrdered(ctx context.Context, threshold float64, field string, order models.Ordering) ([]models.CVE, error) {
+	params := map[string]string{field + "-gt": strconv.FormatFloat(threshold, 'f', -1, 64)}
+	if o := orderParam(order); o != "" {
+		params["order"] = o
+	}
+	url, err := r.buildURL(params)
 	if err != nil {
 		return nil, err
 	}
+	return r.fetchCVEs(ctx, url)
+}
 
-	var result interface{}
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+// GetCVEsAboveThresholdForDate retrieves every CVE for date whose field (epss or percentile)
+// exceeds threshold, highest first, fetching all result pages.
+func (r *apiRepository) GetCVEsAboveThresholdForDate(ctx context.Context, date string, threshold float64, field string) ([]models.CVE, error) {
+	params := map[string]string{
+		field + "-gt": strconv.FormatFloat(threshold, 'f', -1, 64),
+		"order":       "!" + field,
 	}
-
-	cves, err := convertAPIResponseToCVEDataArray(result)
-	if err != nil {
-		return nil, err
+	if date != "" {
+		params["date"] = date
 	}
-	return cves, nil
+	return r.fetchAllPages(ctx, params)
 }
 
-// GetCVEsAboveThreshold retrieves CVEs above a specified threshold for a given field (epss or percentile).
-func (r *apiRepository) GetCVEsAboveThreshold(threshold float64, field string) ([]models.CVE, error) {
-	params := map[string]string{field + "-gt": strconv.FormatFloat(threshold, 'f', 2, 64)}
+// GetCVEsInBand retrieves up to limit CVEs for a date whose field (epss or percentile) lies between min and max.
+// A min of 0 or a max of 1 leaves that side of the band open.
+func (r *apiRepository) GetCVEsInBand(ctx context.Context, date string, field string, min, max float64, limit int) ([]models.CVE, error) {
+	params := map[string]string{"limit": strconv.Itoa(limit)}
+	if date != "" {
+		params["date"] = date
+	}
+	if min > 0 {
+		params[field+"-gt"] = strconv.FormatFloat(min, 'f', -1, 64)
+	}
+	if max < 1 {
+		params[field+"-lt"] = strconv.FormatFloat(max, 'f', -1, 64)
+	}
 	url, err := r.buildURL(params)
 	if err != nil {
 		return nil, err
 	}
-	data, err := r.fetchData(url)
-	if err != nil {
+	return r.fetchCVEs(ctx, url)
+}
+
+// GetCVEsInRange retrieves every CVE for a date (the latest data when empty) whose field (epss or
+// percentile) lies strictly between min and max, requesting both bounds in one query and following
+// pagination.
+func (r *apiRepository) GetCVEsInRange(ctx context.Context, date string, field string, min, max float64) ([]models.CVE, error) {
+	if err := validateBand(field, min, max); err != nil {
 		return nil, err
 	}
-
-	var result interface{}
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	params := map[string]string{
+		field + "-gt": strconv.FormatFloat(min, 'f', -1, 64),
+		field + "-lt": strconv.FormatFloat(max, 'f', -1, 64),
 	}
-
-	cves, err := convertAPIResponseToCVEDataArray(result)
-	if err != nil {
-		return nil, err
+	if date != "" {
+		params["date"] = date
 	}
-	return cves, nil
+	return r.fetchAllPages(ctx, params)
 }
 
-// convertAPIResponseToCVEData converts a JSON response to a slice of CVE structs.
-func convertAPIResponseToCVEData(item interface{}) ([]models.CVE, error) {
-	data, ok := item.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response type: %T", item)
+// GetCVEsAbovePercentile retrieves every CVE for a date whose percentile exceeds min, highest
+// percentile first, following pagination.
+func (r *apiRepository) GetCVEsAbovePercentile(ctx context.Context, date string, min float64) ([]models.CVE, error) {
+	if min < 0 || min >= 1 {
+		return nil, fmt.Errorf("percentile bound must be at least 0 and below 1, got %g", min)
+	}
+	params := map[string]string{
+		"percentile-gt": strconv.FormatFloat(min, 'f', -1, 64),
+		"order":         "!percentile",
 	}
-	apiData, ok := data["data"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response type: %T", data["data"])
+	if date != "" {
+		params["date"] = date
 	}
-	cves := make([]models.CVE, len(apiData))
-	for i, item := range apiData {
-		cveData := item.(map[string]interface{})
-		cve, err := convertSingleAPIResponseToCVE(cveData)
+	return r.fetchAllPages(ctx, params)
+}
+
+// fetchAllPages requests every page of params; see fetchPages.
+func (r *apiRepository) fetchAllPages(ctx context.Context, params map[string]string) ([]models.CVE, error) {
+	return r.fetchPages(ctx, params, 0)
+}
+
+// fetchPages requests params page by page through fetchPage, so pages are served from the CVE
+// cache when fresh, reading the total and offset of each response's envelope. Pages are not
+// memoized. It stops once the total is reached, at a short or empty page, or once max rows (when
+// positive) have been fetched.
+func (r *apiRepository) fetchPages(ctx context.Context, params map[string]string, max int) ([]models.CVE, error) {
+	var all []models.CVE
+	offset := 0
+	for {
+		limit := r.pageSize
+		if max > 0 {
+			limit = min(limit, max-len(all))
+		}
+		params["limit"] = strconv.Itoa(limit)
+		params["offset"] = strconv.Itoa(offset)
+		url, err := r.buildURL(params)
+		if err != nil {
+			return nil, err
+		}
+		page, info, err := r.fetchPage(ctx, url, false)
 		if err != nil {
 			return nil, err
 		}
-		cves[i] = *cve
+		all = append(all, page...)
+
+		// Mirrors and cached pages may lack the offset; the requested one is used then.
+		if info.offset > 0 {
+			offset = info.offset
+		}
+		offset += len(page)
+		switch {
+		case len(page) == 0 || len(page) < limit:
+			return all, nil
+		case max > 0 && len(all) >= max:
+			// Servers ignoring the limit may send more rows than asked for.
+			return all[:max], nil
+		case info.total != nil && offset >= *info.total:
+			return all, nil
+		}
 	}
-	return cves, nil
 }
 
-// convertSingleAPIResponseToCVE converts a single JSON object to a CVE struct.
-func convertSingleAPIResponseToCVE(item map[string]interface{}) (*models.CVE, error) {
-	cveID, ok := item["cve"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing cve field")
-	}
-	epssScore, ok := item["epss"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing epss field")
-	}
-	percentile, ok := item["percentile"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing percentile field")
+// GetCVEPage retrieves the page of CVEs scored on date (the latest data when
+// empty) starting at offset. The page is not memoized, so a day iterated page
+// by page is never held in memory at once. ctx cancels the request.
+func (r *apiRepository) GetCVEPage(ctx context.Context, date string, offset int) ([]models.CVE, bool, error) {
+	params := map[string]string{
+		"limit":  strconv.Itoa(r.pageSize),
+		"offset": strconv.Itoa(offset),
 	}
-	date, ok := item["date"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing date field")
+	if date != "" {
+		params["date"] = date
 	}
-	epssFloat, err := strconv.ParseFloat(epssScore, 64)
+	url, err := r.buildURL(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse epss field: %w", err)
+		return nil, false, err
 	}
-	percentileFloat, err := strconv.ParseFloat(percentile, 64)
+	page, _, err := r.fetchPage(ctx, url, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse percentile field: %w", err)
+		return nil, false, err
 	}
-	return &models.CVE{
-		ID:         cveID,
-		EPSSScore:  epssFloat,
-		Percentile: percentileFloat,
-		Date:       date,
-	}, nil
+	return page, len(page) == r.pageSize, nil
 }
 
-// convertAPIResponseToCVEDataArray converts a JSON response to a slice of CVE structs.  Handles both single object and array responses.
-func convertAPIResponseToCVEDataArray(item interface{}) ([]models.CVE, error) { // Converts API response to an array of CVE structs.
-	data, ok := item.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response type: %T", item)
+// validateBand checks the field and bounds of a GetCVEsInRange band.
+func validateBand(field string, min, max float64) error {
+	if field != "epss" && field != "percentile" {
+		return fmt.Errorf("unsupported field: %s (expected epss or percentile)", field)
 	}
-	apiData, ok := data["data"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response type: %T", data["data"])
+	if min < 0 || min > 1 || max < 0 || max > 1 {
+		return fmt.Errorf("%s band bounds must be between 0 and 1, got %g and %g", field, min, max)
 	}
-	cves := make([]models.CVE, len(apiData))
-	for i, item := range apiData {
-		cve, err := convertSingleAPIResponseToCVE(item.(map[string]interface{}))
-		if err != nil {
-			return nil, err
-		}
-		cves[i] = *cve
+	if min >= max {
+		return fmt.Errorf("%s band minimum %g must be less than maximum %g", field, min, max)
 	}
-	return cves, nil
+	return nil
+}
+
+// GetTotalCVEs retrieves the number of scored CVEs for a date (or the latest data when date is empty).
+func (r *apiRepository) GetTotalCVEs(ctx context.Context, date string) (int, error) {
+	params := map[string]string{}
+	if date != "" {
+		params["date"] = date
+	}
+	return r.Count(ctx, params)
+}
+
+// Count returns the number of records matching the query params. It requests
+// a single row and reads the total from the envelope, so counting is cheap
+// however many records match.
+func (r *apiRepository) Count(ctx context.Context, params map[string]string) (int, error) {
+	query := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		query[k] = v
+	}
+	query["limit"] = "1"
+	url, err := r.buildURL(query)
+	if err != nil {
+		return 0, err
+	}
+	data, err := r.fetchData(ctx, url, true)
+	if err != nil {
+		return 0, err
+	}
+
+	envelope, err := decodeEnvelope(data, r.fieldMapping)
+	if err != nil {
+		return 0, err
+	}
+	if envelope.Total == nil {
+		return 0, fmt.Errorf("missing total field")
+	}
+	return *envelope.Total, nil
 }
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagedQueriesSkipMemo(t *testing.T) {
+	// Three full pages of two rows, then an empty one.
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if offset >= 6 {
+			fmt.Fprintln(w, `{"total":6,"data":[]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"total":6,"offset":%d,"data":[{"cve":"CVE-2023-%04d","epss":"0.1","percentile":"0.5","date":"2024-10-18"},{"cve":"CVE-2023-%04d","epss":"0.1","percentile":"0.5","date":"2024-10-18"}]}`, offset, offset+1, offset+2)
+	}))
+	defer mockServer.Close()
+	newRepo := func() *apiRepository {
+		return NewAPIRepository(mockServer.URL, WithPageSize(2)).(*apiRepository)
+	}
+
+	t.Run("Success - Iterated Pages Are Not Retained", func(t *testing.T) {
+		repo := newRepo()
+
+		var rows int
+		for more := true; more; {
+			var page []models.CVE
+			var err error
+			page, more, err = repo.GetCVEPage(context.Background(), "2024-10-18", rows)
+			require.NoError(t, err)
+			rows += len(page)
+		}
+
+		assert.Equal(t, 6, rows)
+		assert.Empty(t, repo.memo)
+	})
+
+	t.Run("Success - Full Day Pages Are Not Retained", func(t *testing.T) {
+		repo := newRepo()
+
+		cves, err := repo.GetAllCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 6)
+		assert.Empty(t, repo.memo)
+	})
+
+	t.Run("Success - Single Queries Are Still Memoized", func(t *testing.T) {
+		repo := newRepo()
+
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Len(t, repo.memo, 1)
+	})
+}
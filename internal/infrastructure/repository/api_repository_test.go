@@ -1,14 +1,25 @@
 package repository_test
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cache"
 	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/joshbarros/golang-epsstool-api/pkg/epss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockClient struct {
@@ -30,7 +41,7 @@ func TestGetCVEScore(t *testing.T) {
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
-		cve, err := repo.GetCVEScore("CVE-2023-0001", "2024-10-18")
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, cve)
@@ -47,7 +58,7 @@ func TestGetCVEScore(t *testing.T) {
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
-		cve, err := repo.GetCVEScore("CVE-INVALID", "2024-10-18")
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-INVALID", "2024-10-18")
 
 		assert.Error(t, err)
 		assert.Nil(t, cve)
@@ -60,7 +71,7 @@ func TestGetCVEScore(t *testing.T) {
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
-		_, err := repo.GetCVEScore("CVE-2023-0001", "2024-10-18")
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
 
 		assert.Error(t, err)
 	})
@@ -76,7 +87,7 @@ func TestGetTopNCVEs(t *testing.T) {
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
-		cves, err := repo.GetTopNCVEs(2)
+		cves, err := repo.GetTopNCVEs(context.Background(), 2)
 
 		assert.NoError(t, err)
 		assert.Len(t, cves, 2)
@@ -90,41 +101,62 @@ func TestGetTopNCVEs(t *testing.T) {
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
-		_, err := repo.GetTopNCVEs(2)
+		_, err := repo.GetTopNCVEs(context.Background(), 2)
 
 		assert.Error(t, err)
 	})
 }
 
 func TestGetHighestIncreases(t *testing.T) {
-	t.Run("Success - Returns Highest Increases", func(t *testing.T) {
-		// Mock response should include two CVEs with different score increases
-		mockResponse := `{"data":[
-			{"cve":"CVE-2023-0001","epss":"0.00040","percentile":"0.13","date":"2024-09-18"},
-			{"cve":"CVE-2023-0002","epss":"0.00060","percentile":"0.15","date":"2024-09-18"}
-		]}`
+	t.Run("Success - Returns Change From Earliest To Latest Score", func(t *testing.T) {
+		start := time.Now().AddDate(0, 0, -2).Format("2006-01-02")
+		end := time.Now().Format("2006-01-02")
 		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, mockResponse)
+			switch r.URL.Query().Get("date") {
+			case start:
+				fmt.Fprintln(w, `{"data":[
+					{"cve":"CVE-2023-0001","epss":"0.10","percentile":"0.5","date":"2024-10-18"},
+					{"cve":"CVE-2023-0002","epss":"0.50","percentile":"0.5","date":"2024-10-18"},
+					{"cve":"CVE-2023-0003","epss":"0.30","percentile":"0.5","date":"2024-10-18"}
+				]}`)
+			case end:
+				fmt.Fprintln(w, `{"data":[
+					{"cve":"CVE-2023-0001","epss":"0.40","percentile":"0.5","date":"2024-10-18"},
+					{"cve":"CVE-2023-0002","epss":"0.55","percentile":"0.5","date":"2024-10-18"},
+					{"cve":"CVE-2023-0003","epss":"0.20","percentile":"0.5","date":"2024-10-18"},
+					{"cve":"CVE-2023-0004","epss":"0.90","percentile":"0.5","date":"2024-10-18"}
+				]}`)
+			default:
+				fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.95","percentile":"0.5","date":"2024-10-18"}]}`)
+			}
 		}))
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
 
-		// Test for 30 days lookback and limit to 2 CVEs
-		scoreChanges, err := repo.GetHighestIncreases(30, 2)
+		scoreChanges, err := repo.GetHighestIncreases(context.Background(), 2, 10)
 
-		assert.NoError(t, err)
-		assert.Len(t, scoreChanges, 2)
+		require.NoError(t, err)
+		// CVE-2023-0003 fell and CVE-2023-0004 was only scored on one day.
+		require.Len(t, scoreChanges, 2)
+		assert.Equal(t, "CVE-2023-0001", scoreChanges[0].CVE)
+		assert.InDelta(t, 0.30, scoreChanges[0].ScoreChange, 1e-9)
+		assert.Equal(t, end, scoreChanges[0].Date.Format("2006-01-02"))
+		assert.Equal(t, "CVE-2023-0002", scoreChanges[1].CVE)
+		assert.InDelta(t, 0.05, scoreChanges[1].ScoreChange, 1e-9)
+	})
+
+	t.Run("Success - Unchanged Scores Are No Increase", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.00040","percentile":"0.5","date":"2024-10-18"},{"cve":"CVE-2023-0002","epss":"0.00060","percentile":"0.5","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
 
-		// Assert based on score changes
-		// CVE-2023-0002 has the highest increase in score
-		assert.Equal(t, "CVE-2023-0002", scoreChanges[0].CVE)
-		assert.Equal(t, 0.00060, scoreChanges[0].ScoreChange)
+		scoreChanges, err := repository.NewAPIRepository(mockServer.URL).GetHighestIncreases(context.Background(), 30, 2)
 
-		// CVE-2023-0001 should come second
-		assert.Equal(t, "CVE-2023-0001", scoreChanges[1].CVE)
-		assert.Equal(t, 0.00040, scoreChanges[1].ScoreChange)
+		assert.NoError(t, err)
+		assert.Empty(t, scoreChanges)
 	})
 
 	t.Run("Fail - API Error", func(t *testing.T) {
@@ -134,9 +166,686 @@ func TestGetHighestIncreases(t *testing.T) {
 		defer mockServer.Close()
 
 		repo := repository.NewAPIRepository(mockServer.URL)
-		_, err := repo.GetHighestIncreases(30, 2)
+		_, err := repo.GetHighestIncreases(context.Background(), 30, 2)
 
 		assert.Error(t, err)
 	})
 }
 
+func TestGetTotalCVEs(t *testing.T) {
+	t.Run("Success - Returns Envelope Total", func(t *testing.T) {
+		mockResponse := `{"status":"OK","total":250000,"offset":0,"limit":1,"data":[{"cve":"CVE-2023-0001","epss":"0.00044","percentile":"0.13","date":"2024-10-18"}]}`
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			assert.Equal(t, "2024-10-18", r.URL.Query().Get("date"))
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, mockResponse)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		total, err := repo.GetTotalCVEs(context.Background(), "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 250000, total)
+	})
+
+	t.Run("Fail - Missing Total", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"data":[]}`)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		_, err := repo.GetTotalCVEs(context.Background(), "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCount(t *testing.T) {
+	t.Run("Success - Requests One Row And Reads Total", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			assert.Equal(t, "0.5", r.URL.Query().Get("epss-gt"))
+			assert.Equal(t, "log4j", r.URL.Query().Get("q"))
+			fmt.Fprintln(w, `{"status":"OK","total":42,"offset":0,"limit":1,"data":[{"cve":"CVE-2021-44228","epss":"0.97","percentile":"0.99","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		counter := repository.NewAPIRepository(mockServer.URL).(ports.Counter)
+		params := map[string]string{"epss-gt": "0.5", "q": "log4j", "limit": "100"}
+		n, err := counter.Count(context.Background(), params)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 42, n)
+		assert.Equal(t, "100", params["limit"], "params must not be modified")
+	})
+
+	t.Run("Success - Zero Matches", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"status":"OK","total":0,"data":[]}`)
+		}))
+		defer mockServer.Close()
+
+		n, err := repository.NewAPIRepository(mockServer.URL).(ports.Counter).Count(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("Fail - Missing Total", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"data":[]}`)
+		}))
+		defer mockServer.Close()
+
+		_, err := repository.NewAPIRepository(mockServer.URL).(ports.Counter).Count(context.Background(), nil)
+
+		assert.EqualError(t, err, "missing total field")
+	})
+}
+
+func TestFetchErrorsAreClassified(t *testing.T) {
+	t.Run("Retryable - Server Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		var statusErr *epss.StatusError
+		assert.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+		assert.True(t, epss.IsRetryable(err))
+	})
+
+	t.Run("Not Retryable - Client Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.Error(t, err)
+		assert.False(t, epss.IsRetryable(err))
+	})
+
+	t.Run("Not Retryable - Error Reported In A 200 Body", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"status":"Bad Request","status-code":400,"message":"invalid date","data":[]}`)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-13-01")
+
+		var envelopeErr *epss.EnvelopeError
+		assert.ErrorAs(t, err, &envelopeErr)
+		assert.Equal(t, 400, envelopeErr.StatusCode)
+		assert.Equal(t, "invalid date", envelopeErr.Message)
+		assert.False(t, epss.IsRetryable(err))
+	})
+}
+
+func TestGetCVEScores(t *testing.T) {
+	t.Run("Success - Returns Scores For Batch", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "CVE-2021-44228,CVE-2020-1472", r.URL.Query().Get("cve"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			fmt.Fprintln(w, `{"data":[{"cve":"CVE-2021-44228","epss":"0.97","percentile":"0.99","date":"2024-10-18"},{"cve":"CVE-2020-1472","epss":"0.96","percentile":"0.99","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		cves, err := repo.GetCVEScores(context.Background(), []string{"CVE-2021-44228", "CVE-2020-1472"}, "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Len(t, cves, 2)
+	})
+
+	t.Run("Success - Long IDs Are Chunked Under Max URL Length", func(t *testing.T) {
+		const maxLength = 300
+		var requested []string
+		var lengths []int
+		var mockServer *httptest.Server
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lengths = append(lengths, len(mockServer.URL+r.RequestURI))
+			requested = append(requested, strings.Split(r.URL.Query().Get("cve"), ",")...)
+			fmt.Fprintln(w, `{"data":[]}`)
+		}))
+		defer mockServer.Close()
+
+		var ids []string
+		for i := 0; i < 40; i++ {
+			ids = append(ids, fmt.Sprintf("CVE-2024-%0*d", 10+i, i))
+		}
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithMaxURLLength(maxLength))
+		_, err := repo.GetCVEScores(context.Background(), ids, "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Greater(t, len(lengths), 1)
+		for _, n := range lengths {
+			assert.LessOrEqual(t, n, maxLength)
+		}
+		assert.Equal(t, ids, requested)
+	})
+
+	t.Run("Fail - Single ID Longer Than Max URL Length", func(t *testing.T) {
+		repo := repository.NewAPIRepository("http://127.0.0.1:1", repository.WithMaxURLLength(50))
+		_, err := repo.GetCVEScores(context.Background(), []string{"CVE-2024-" + strings.Repeat("1", 60)}, "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetCVEsAboveThreshold(t *testing.T) {
+	var queries []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.00051","percentile":"0.2","date":"2024-10-18"}]}`)
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - Small Threshold Is Sent In Full", func(t *testing.T) {
+		queries = nil
+		repo := repository.NewAPIRepository(mockServer.URL)
+
+		cves, err := repo.GetCVEsAboveThreshold(context.Background(), 0.0005, "epss")
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 1)
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], "epss-gt=0.0005")
+	})
+
+	t.Run("Success - Threshold Is Not Padded", func(t *testing.T) {
+		queries = nil
+		repo := repository.NewAPIRepository(mockServer.URL)
+
+		_, err := repo.GetCVEsAboveThreshold(context.Background(), 0.5, "percentile")
+
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		assert.Equal(t, "percentile-gt=0.5", queries[0])
+	})
+}
+
+func TestGetCVEsInRange(t *testing.T) {
+	t.Run("Success - Sends Both Bounds For The Field And Follows Pages", func(t *testing.T) {
+		var offsets []string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			assert.Equal(t, "0.5", query.Get("epss-gt"))
+			assert.Equal(t, "0.7", query.Get("epss-lt"))
+			assert.Empty(t, query.Get("date"))
+			offsets = append(offsets, query.Get("offset"))
+
+			switch query.Get("offset") {
+			case "0":
+				fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.51","percentile":"0.96","date":"2024-10-18"},{"cve":"CVE-2023-0002","epss":"0.6","percentile":"0.97","date":"2024-10-18"}]}`)
+			default:
+				fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0003","epss":"0.69","percentile":"0.98","date":"2024-10-18"}]}`)
+			}
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2))
+		cves, err := repo.GetCVEsInRange(context.Background(), "", "epss", 0.5, 0.7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0", "2"}, offsets)
+		assert.Len(t, cves, 3)
+	})
+
+	t.Run("Success - Sends The Date", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			assert.Equal(t, "0.5", query.Get("percentile-gt"))
+			assert.Equal(t, "0.9", query.Get("percentile-lt"))
+			assert.Equal(t, "2024-10-18", query.Get("date"))
+			fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		cves, err := repo.GetCVEsInRange(context.Background(), "2024-10-18", "percentile", 0.5, 0.9)
+
+		assert.NoError(t, err)
+		assert.Len(t, cves, 1)
+	})
+
+	t.Run("Fail - Invalid Field Or Bounds", func(t *testing.T) {
+		repo := repository.NewAPIRepository("http://127.0.0.1:1")
+
+		_, err := repo.GetCVEsInRange(context.Background(), "", "cvss", 0.5, 0.7)
+		assert.EqualError(t, err, "unsupported field: cvss (expected epss or percentile)")
+		_, err = repo.GetCVEsInRange(context.Background(), "", "epss", 0.7, 0.5)
+		assert.EqualError(t, err, "epss band minimum 0.7 must be less than maximum 0.5")
+		for _, band := range [][2]float64{{0.9, 0.5}, {0.5, 0.5}, {-0.1, 0.5}, {0.5, 1.1}} {
+			_, err := repo.GetCVEsInRange(context.Background(), "", "percentile", band[0], band[1])
+			assert.Error(t, err, "band %v", band)
+		}
+	})
+}
+
+func TestInProcessMemo(t *testing.T) {
+	t.Run("Success - Repeated Requests Hit Upstream Once", func(t *testing.T) {
+		var calls int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		for i := 0; i < 3; i++ {
+			cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+			assert.NoError(t, err)
+			assert.Len(t, cves, 1)
+		}
+		assert.Equal(t, 1, calls)
+
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-17")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+
+		_, err = repository.NewAPIRepository(mockServer.URL).GetCVEsForDate(context.Background(), "2024-10-18")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Success - WithoutMemo Refetches Repeated Requests", func(t *testing.T) {
+		var calls int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithoutMemo())
+		for i := 0; i < 3; i++ {
+			_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Fail - Errors Are Not Memoized", func(t *testing.T) {
+		var calls int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL)
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		assert.Error(t, err)
+		_, err = repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		assert.Error(t, err)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestWithFieldMapping(t *testing.T) {
+	t.Run("Success - Remapped Mirror Fixture", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","score":0.00044,"percentile":"0.13","score_date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		mapping, err := repository.ParseFieldMapping("epss=score,date=score_date")
+		assert.NoError(t, err)
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithFieldMapping(mapping))
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.00044, cve.EPSSScore)
+		assert.Equal(t, "2024-10-18", cve.Date)
+	})
+}
+
+func TestWithPrettyResponses(t *testing.T) {
+	t.Run("Success - Appends Pretty Param", func(t *testing.T) {
+		var pretty []string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pretty = append(pretty, r.URL.Query().Get("pretty"))
+			fmt.Fprintln(w, "{\n  \"total\": 1,\n  \"data\": [\n    {\"cve\": \"CVE-2023-0001\", \"epss\": \"0.01\", \"percentile\": \"0.6\", \"date\": \"2024-10-18\"}\n  ]\n}")
+		}))
+		defer mockServer.Close()
+
+		cve, err := repository.NewAPIRepository(mockServer.URL, repository.WithPrettyResponses()).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.01, cve.EPSSScore)
+
+		_, err = repository.NewAPIRepository(mockServer.URL).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"true", ""}, pretty)
+	})
+}
+
+func TestWithRawValues(t *testing.T) {
+	t.Run("Success - Keeps Score Text", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.000440000","percentile":"0.130000000","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		cve, err := repository.NewAPIRepository(mockServer.URL, repository.WithRawValues()).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.00044, cve.EPSSScore)
+		assert.Equal(t, "0.000440000", cve.RawEPSS)
+		assert.Equal(t, "0.130000000", cve.RawPercentile)
+
+		cve, err = repository.NewAPIRepository(mockServer.URL).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+		assert.NoError(t, err)
+		assert.Empty(t, cve.RawEPSS)
+	})
+}
+
+func TestWithDebugHeaders(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Api-Key", "abc123")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"data":[{"cve":"CVE-2023-0001","epss":"0.5","percentile":"0.9","date":"2024-10-18"}]}`))
+	}))
+	defer mockServer.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	repo := repository.NewAPIRepository(mockServer.URL, repository.WithDebugHeaders())
+	_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "Cache-Control=max-age=3600")
+	assert.Contains(t, logs.String(), "X-Ratelimit-Remaining=99")
+	assert.Contains(t, logs.String(), "X-Ratelimit-Api-Key=[REDACTED]")
+	assert.NotContains(t, logs.String(), "abc123")
+	assert.NotContains(t, logs.String(), "session=secret")
+}
+
+func TestWithScope(t *testing.T) {
+	var scopes []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes = append(scopes, r.URL.Query().Get("scope"))
+		assert.LessOrEqual(t, len(r.URL.Query()["scope"]), 1)
+		w.Write([]byte(`{"data":[{"cve":"CVE-2023-0001","epss":"0.5","percentile":"0.9","date":"2024-10-18"}]}`))
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - Scope Is Added To Queries", func(t *testing.T) {
+		scopes = nil
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithScope("public"))
+
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		assert.NoError(t, err)
+		_, err = repo.GetTimeSeries(context.Background(), "CVE-2023-0001")
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"public", "time-series"}, scopes)
+	})
+
+	t.Run("Success - No Scope By Default", func(t *testing.T) {
+		scopes = nil
+		repo := repository.NewAPIRepository(mockServer.URL)
+
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{""}, scopes)
+	})
+
+	t.Run("Fail - Unknown Scope", func(t *testing.T) {
+		assert.NoError(t, repository.ValidateScope("time-series"))
+		assert.EqualError(t, repository.ValidateScope("private"), "unsupported scope: private (expected public or time-series)")
+	})
+}
+
+func TestQueryContext(t *testing.T) {
+	t.Run("Fail - Deadline Aborts A Slow Request Mid-Run", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) > 1 {
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+				return
+			}
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		repo := repository.NewAPIRepository(mockServer.URL)
+
+		_, err := repo.GetCVEsForDate(ctx, "2024-10-18")
+		assert.NoError(t, err)
+
+		start := time.Now()
+		_, err = repo.GetCVEsForDate(ctx, "2024-10-17")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	t.Run("Success - Stalled Attempt Times Out And Is Retried", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+				return
+			}
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+		defer close(release)
+
+		repo := repository.NewAPIRepository(mockServer.URL,
+			repository.WithRequestTimeout(50*time.Millisecond),
+			repository.WithRetries(1, time.Millisecond),
+			repository.WithSleeper(repository.SleeperFunc(func(context.Context, time.Duration) error { return nil })))
+
+		cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 1)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Fail - Stalled Request Without Retries", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer mockServer.Close()
+		defer close(release)
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithRequestTimeout(50*time.Millisecond))
+
+		start := time.Now()
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		assert.ErrorIs(t, err, epss.ErrRequestTimeout)
+		assert.NotErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestNewAPIRepositoryWithClient(t *testing.T) {
+	t.Run("Fail - Client Timeout Aborts A Hung Request", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer mockServer.Close()
+		defer close(release)
+
+		repo := repository.NewAPIRepositoryWithClient(mockServer.URL, &http.Client{Timeout: 50 * time.Millisecond})
+
+		start := time.Now()
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		assert.Error(t, err)
+		assert.True(t, epss.IsRetryable(err))
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("Success - Requests Go Through The Client", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "custom", r.Header.Get("X-Client"))
+			fmt.Fprintln(w, `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		client := &http.Client{Transport: headerTransport{"X-Client", "custom"}}
+		cves, err := repository.NewAPIRepositoryWithClient(mockServer.URL, client).GetCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 1)
+	})
+}
+
+// headerTransport sets a header on every request.
+type headerTransport struct {
+	name, value string
+}
+
+func (h headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(h.name, h.value)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// pagedServer serves total rows for any date, honouring limit and offset and
+// reporting the total and offset in the envelope.
+func pagedServer(total int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		var limit, offset int
+		fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+		fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+		var rows []string
+		for i := offset; i < total && i < offset+limit; i++ {
+			rows = append(rows, fmt.Sprintf(`{"cve":"CVE-2023-%04d","epss":"0.1","percentile":"0.5","date":"2024-10-18"}`, i))
+		}
+		fmt.Fprintf(w, `{"total":%d,"offset":%d,"limit":%d,"data":[%s]}`, total, offset, limit, strings.Join(rows, ","))
+	}))
+}
+
+func TestGetCVEsForDatePaged(t *testing.T) {
+	t.Run("Success - Stops At The Reported Total", func(t *testing.T) {
+		var requests []string
+		mockServer := pagedServer(4, &requests)
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2))
+		cves, err := repo.GetCVEsForDatePaged(context.Background(), "2024-10-18", 0)
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 4)
+		assert.Equal(t, "CVE-2023-0003", cves[3].ID)
+		// A full last page ends the loop by the total, without an empty request.
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("Success - Stops At Max Results", func(t *testing.T) {
+		var requests []string
+		mockServer := pagedServer(10, &requests)
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2))
+		cves, err := repo.GetCVEsForDatePaged(context.Background(), "2024-10-18", 3)
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 3)
+		require.Len(t, requests, 2)
+		assert.Contains(t, requests[1], "limit=1")
+		assert.Contains(t, requests[1], "offset=2")
+	})
+
+	t.Run("Fail - API Error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer mockServer.Close()
+
+		_, err := repository.NewAPIRepository(mockServer.URL).GetCVEsForDatePaged(context.Background(), "2024-10-18", 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetAllCVEsForDate(t *testing.T) {
+	t.Run("Success - Stops At The Reported Total", func(t *testing.T) {
+		var requests []string
+		mockServer := pagedServer(4, &requests)
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2))
+		cves, err := repo.GetAllCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 4)
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("Success - Pages Are Served From The CVE Cache", func(t *testing.T) {
+		var requests []string
+		mockServer := pagedServer(3, &requests)
+		defer mockServer.Close()
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		first, err := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2), repository.WithCVECache(c)).GetAllCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+
+		second, err := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2), repository.WithCVECache(c)).GetAllCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.Len(t, requests, 2)
+	})
+}
+
+func TestGetTopNCVEsPaged(t *testing.T) {
+	t.Run("Success - N Above Page Size Is Fetched In Pages", func(t *testing.T) {
+		var requests []string
+		mockServer := pagedServer(10, &requests)
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2))
+		cves, err := repo.GetTopNCVEs(context.Background(), 5)
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 5)
+		assert.Len(t, requests, 3)
+		assert.Contains(t, requests[0], "order=%21epss")
+	})
+}
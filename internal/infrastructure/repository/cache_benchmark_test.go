@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cache"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+)
+
+// fullDayRows approximates the number of CVEs scored on a single day.
+const fullDayRows = 250000
+
+// fullDayResponse builds an API response holding a full day of rows.
+func fullDayResponse() string {
+	var sb strings.Builder
+	sb.WriteString(`{"status":"OK","total":250000,"data":[`)
+	for i := 0; i < fullDayRows; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"cve":"CVE-2023-%06d","epss":"0.%05d","percentile":"0.%05d","date":"2024-10-18"}`, i, i%100000, i%100000)
+	}
+	sb.WriteString(`]}`)
+	return sb.String()
+}
+
+// benchmarkCacheReload measures reloading a cached full day through repositories built by opts.
+func benchmarkCacheReload(b *testing.B, opts func(*cache.FileCache) repository.Option) {
+	body := fullDayResponse()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer mockServer.Close()
+
+	c := cache.NewFileCache(b.TempDir(), time.Hour)
+	repo := repository.NewAPIRepository(mockServer.URL, opts(c))
+	if _, err := repo.GetCVEsForDate(context.Background(), "2024-10-18"); err != nil {
+		b.Fatal(err)
+	}
+	mockServer.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(cves) != fullDayRows {
+			b.Fatalf("got %d rows, want %d", len(cves), fullDayRows)
+		}
+	}
+}
+
+func BenchmarkCacheReloadJSON(b *testing.B) {
+	benchmarkCacheReload(b, func(c *cache.FileCache) repository.Option { return repository.WithCache(c) })
+}
+
+func BenchmarkCacheReloadGob(b *testing.B) {
+	benchmarkCacheReload(b, func(c *cache.FileCache) repository.Option { return repository.WithCVECache(c) })
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// cachedResult is a memoized query result and the time it stops being fresh.
+type cachedResult struct {
+	cves    []models.CVE
+	expires time.Time
+}
+
+// cachingRepository memoizes the single-CVE and per-date lookups of another
+// repository; every other query is passed through.
+type cachingRepository struct {
+	ports.EPSSRepository
+	ttl     time.Duration
+	mu      sync.RWMutex
+	results map[string]cachedResult
+}
+
+// cachingPager, cachingCounter and cachingPagerCounter pass the optional
+// interfaces of the wrapped repository through a cachingRepository, so type
+// assertions on it succeed exactly when they would on the repository itself.
+type cachingPager struct {
+	*cachingRepository
+	ports.CVEPager
+}
+
+type cachingCounter struct {
+	*cachingRepository
+	ports.Counter
+}
+
+type cachingPagerCounter struct {
+	*cachingRepository
+	ports.CVEPager
+	ports.Counter
+}
+
+// NewCachingRepository wraps inner so that GetCVEScore, GetCVEsForDate and
+// GetAllCVEsForDate results are served from memory for ttl after they are
+// fetched. Errors are not cached. The result implements ports.CVEPager and
+// ports.Counter when inner does, passing those calls through uncached.
+func NewCachingRepository(inner ports.EPSSRepository, ttl time.Duration) ports.EPSSRepository {
+	c := &cachingRepository{EPSSRepository: inner, ttl: ttl, results: make(map[string]cachedResult)}
+	pager, isPager := inner.(ports.CVEPager)
+	counter, isCounter := inner.(ports.Counter)
+	switch {
+	case isPager && isCounter:
+		return &cachingPagerCounter{cachingRepository: c, CVEPager: pager, Counter: counter}
+	case isPager:
+		return &cachingPager{cachingRepository: c, CVEPager: pager}
+	case isCounter:
+		return &cachingCounter{cachingRepository: c, Counter: counter}
+	default:
+		return c
+	}
+}
+
+// GetCVEScore returns the score of cveID for date, from memory when fresh.
+func (c *cachingRepository) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	key := cacheKey("score", cveID, date)
+	if cves, ok := c.get(key); ok {
+		return &cves[0], nil
+	}
+	cve, err := c.EPSSRepository.GetCVEScore(ctx, cveID, date)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, []models.CVE{*cve})
+	scored := *cve
+	return &scored, nil
+}
+
+// GetCVEsForDate returns the CVEs scored on date, from memory when fresh.
+func (c *cachingRepository) GetCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	return c.lookup(cacheKey("date", "", date), func() ([]models.CVE, error) {
+		return c.EPSSRepository.GetCVEsForDate(ctx, date)
+	})
+}
+
+// GetAllCVEsForDate returns every CVE scored on date, from memory when fresh.
+func (c *cachingRepository) GetAllCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	return c.lookup(cacheKey("all", "", date), func() ([]models.CVE, error) {
+		return c.EPSSRepository.GetAllCVEsForDate(ctx, date)
+	})
+}
+
+// lookup returns the fresh result stored under key, or calls fetch and
+// stores what it returns.
+func (c *cachingRepository) lookup(key string, fetch func() ([]models.CVE, error)) ([]models.CVE, error) {
+	if cves, ok := c.get(key); ok {
+		return cves, nil
+	}
+	cves, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, cves)
+	return append([]models.CVE(nil), cves...), nil
+}
+
+// get returns a copy of the fresh result stored under key.
+func (c *cachingRepository) get(key string) ([]models.CVE, bool) {
+	c.mu.RLock()
+	result, ok := c.results[key]
+	c.mu.RUnlock()
+	if !ok || !time.Now().Before(result.expires) {
+		return nil, false
+	}
+	return append([]models.CVE(nil), result.cves...), true
+}
+
+// set stores a copy of cves under key, dropping expired entries first.
+func (c *cachingRepository) set(key string, cves []models.CVE) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, result := range c.results {
+		if !now.Before(result.expires) {
+			delete(c.results, k)
+		}
+	}
+	c.results[key] = cachedResult{cves: append([]models.CVE(nil), cves...), expires: now.Add(c.ttl)}
+}
+
+// cacheKey identifies a query by method, CVE and date.
+func cacheKey(method string, cveID string, date string) string {
+	return method + "\x00" + cveID + "\x00" + date
+}
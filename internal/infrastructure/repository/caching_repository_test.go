@@ -0,0 +1,175 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRepository answers score and per-date lookups, counting the calls.
+type countingRepository struct {
+	ports.EPSSRepository
+	calls int32
+	err   error
+}
+
+func (r *countingRepository) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	atomic.AddInt32(&r.calls, 1)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &models.CVE{ID: cveID, EPSSScore: 0.5, Percentile: 0.9, Date: date}, nil
+}
+
+func (r *countingRepository) GetCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	atomic.AddInt32(&r.calls, 1)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return []models.CVE{{ID: "CVE-2023-0001", EPSSScore: 0.5, Percentile: 0.9, Date: date}}, nil
+}
+
+func (r *countingRepository) GetAllCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	return r.GetCVEsForDate(ctx, date)
+}
+
+func TestCachingRepository(t *testing.T) {
+	t.Run("Success - Serves Repeated Lookups From Memory", func(t *testing.T) {
+		inner := &countingRepository{}
+		repo := repository.NewCachingRepository(inner, time.Hour)
+
+		for i := 0; i < 3; i++ {
+			cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+			require.NoError(t, err)
+			assert.Equal(t, 0.5, cve.EPSSScore)
+			cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+			require.NoError(t, err)
+			assert.Len(t, cves, 1)
+			cves, err = repo.GetAllCVEsForDate(context.Background(), "2024-10-18")
+			require.NoError(t, err)
+			assert.Len(t, cves, 1)
+		}
+
+		assert.Equal(t, int32(3), inner.calls)
+	})
+
+	t.Run("Success - Keys By CVE And Date", func(t *testing.T) {
+		inner := &countingRepository{}
+		repo := repository.NewCachingRepository(inner, time.Hour)
+
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		require.NoError(t, err)
+		_, err = repo.GetCVEScore(context.Background(), "CVE-2023-0002", "2024-10-18")
+		require.NoError(t, err)
+		_, err = repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-17")
+		require.NoError(t, err)
+		_, err = repo.GetCVEsForDate(context.Background(), "2024-10-17")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(4), inner.calls)
+	})
+
+	t.Run("Success - Refetches After The TTL", func(t *testing.T) {
+		inner := &countingRepository{}
+		repo := repository.NewCachingRepository(inner, 10*time.Millisecond)
+
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		_, err = repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), inner.calls)
+	})
+
+	t.Run("Success - Cached Results Are Not Shared With Callers", func(t *testing.T) {
+		repo := repository.NewCachingRepository(&countingRepository{}, time.Hour)
+
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		require.NoError(t, err)
+		cve.EPSSScore = 1
+		cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+		cves[0].EPSSScore = 1
+
+		cve, err = repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, cve.EPSSScore)
+		cves, err = repo.GetCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, cves[0].EPSSScore)
+	})
+
+	t.Run("Success - Safe For Concurrent Use", func(t *testing.T) {
+		repo := repository.NewCachingRepository(&countingRepository{}, time.Hour)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+				assert.NoError(t, err)
+				_, err = repo.GetCVEsForDate(context.Background(), "2024-10-18")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Success - Forwards Optional Interfaces", func(t *testing.T) {
+		api := repository.NewCachingRepository(repository.NewAPIRepository("http://127.0.0.1:0"), time.Hour)
+		_, isPager := api.(ports.CVEPager)
+		_, isCounter := api.(ports.Counter)
+		assert.True(t, isPager)
+		assert.True(t, isCounter)
+
+		csv := repository.NewCachingRepository(repository.NewCSVDirRepository(t.TempDir()), time.Hour)
+		_, isPager = csv.(ports.CVEPager)
+		_, isCounter = csv.(ports.Counter)
+		assert.True(t, isPager)
+		assert.False(t, isCounter)
+
+		plain := repository.NewCachingRepository(&countingRepository{}, time.Hour)
+		_, isPager = plain.(ports.CVEPager)
+		_, isCounter = plain.(ports.Counter)
+		assert.False(t, isPager)
+		assert.False(t, isCounter)
+	})
+
+	t.Run("Success - Forwarded Calls Reach The Wrapped Repository", func(t *testing.T) {
+		var requests []string
+		mockServer := pagedServer(3, &requests)
+		defer mockServer.Close()
+		repo := repository.NewCachingRepository(repository.NewAPIRepository(mockServer.URL, repository.WithPageSize(2)), time.Hour)
+
+		page, more, err := repo.(ports.CVEPager).GetCVEPage(context.Background(), "2024-10-18", 0)
+		require.NoError(t, err)
+		assert.Len(t, page, 2)
+		assert.True(t, more)
+		n, err := repo.(ports.Counter).Count(context.Background(), map[string]string{"date": "2024-10-18"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+
+	t.Run("Fail - Errors Are Not Cached", func(t *testing.T) {
+		inner := &countingRepository{err: errors.New("unavailable")}
+		repo := repository.NewCachingRepository(inner, time.Hour)
+
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		assert.Error(t, err)
+		_, err = repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		assert.Error(t, err)
+
+		assert.Equal(t, int32(2), inner.calls)
+	})
+}
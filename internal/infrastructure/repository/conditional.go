@@ -0,0 +1,47 @@
+package repository
+
+import "net/http"
+
+// ValidatorCache is a ResponseCache that also keeps the ETag and Last-Modified
+// validators of cached responses, so that expired entries can be revalidated
+// with a conditional request instead of downloaded again.
+type ValidatorCache interface {
+	ResponseCache
+	// GetStale returns the cached body for key whatever its age, with its validators.
+	GetStale(key string) (body []byte, etag string, lastModified string, ok bool)
+	// SetValidators stores the validators of the body cached under key; empty
+	// validators remove any stored ones.
+	SetValidators(key string, etag string, lastModified string) error
+	// Touch marks the entry under key as fresh after a successful revalidation.
+	Touch(key string) error
+}
+
+// validators are the conditional request headers for a cached response.
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+// empty reports whether there is nothing to revalidate with.
+func (v validators) empty() bool {
+	return v.etag == "" && v.lastModified == ""
+}
+
+// apply sets the conditional request headers on req.
+func (v validators) apply(req *http.Request) {
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+}
+
+// response is a downloaded response body with its validators. notModified is
+// set, and body empty, when a conditional request was answered with 304.
+type response struct {
+	body         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cache"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	const body = `{"total":1,"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`
+
+	t.Run("Success - Expired Entry Is Revalidated With Its ETag", func(t *testing.T) {
+		var conditional, full int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				conditional++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			full++
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprintln(w, body)
+		}))
+		defer mockServer.Close()
+
+		// A zero TTL expires undated entries immediately.
+		c := cache.NewFileCache(t.TempDir(), 0)
+		_, err := repository.NewAPIRepository(mockServer.URL, repository.WithCache(c)).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+		require.NoError(t, err)
+
+		cve, err := repository.NewAPIRepository(mockServer.URL, repository.WithCache(c)).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.01, cve.EPSSScore)
+		assert.Equal(t, 1, full)
+		assert.Equal(t, 1, conditional)
+	})
+
+	t.Run("Success - Last-Modified Is Sent Back", func(t *testing.T) {
+		const lastModified = "Fri, 18 Oct 2024 00:00:00 GMT"
+		var conditional int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-Modified-Since") == lastModified {
+				conditional++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Last-Modified", lastModified)
+			fmt.Fprintln(w, body)
+		}))
+		defer mockServer.Close()
+
+		c := cache.NewFileCache(t.TempDir(), 0)
+		for i := 0; i < 2; i++ {
+			_, err := repository.NewAPIRepository(mockServer.URL, repository.WithCache(c)).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, conditional)
+	})
+
+	t.Run("Success - Server Without Validators Gets Plain Requests", func(t *testing.T) {
+		var full int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("If-None-Match"))
+			assert.Empty(t, r.Header.Get("If-Modified-Since"))
+			full++
+			fmt.Fprintln(w, body)
+		}))
+		defer mockServer.Close()
+
+		c := cache.NewFileCache(t.TempDir(), 0)
+		for i := 0; i < 2; i++ {
+			_, err := repository.NewAPIRepository(mockServer.URL, repository.WithCache(c)).GetCVEScore(context.Background(), "CVE-2023-0001", "")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, full)
+	})
+
+	t.Run("Fail - Unexpected Not Modified", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotModified)
+		}))
+		defer mockServer.Close()
+
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithCache(cache.NewFileCache(t.TempDir(), 0)))
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,530 @@
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// DefaultCSVMirrorURL hosts the daily epss_scores-YYYY-MM-DD.csv.gz files.
+const DefaultCSVMirrorURL = "https://epss.cyentia.com"
+
+// csvFilePattern matches the names of the daily EPSS CSV datasets.
+var csvFilePattern = regexp.MustCompile(`^epss_scores-(\d{4}-\d{2}-\d{2})\.csv\.gz$`)
+
+// CSVFileName returns the name of the dataset for date.
+func CSVFileName(date string) string {
+	return fmt.Sprintf("epss_scores-%s.csv.gz", date)
+}
+
+// csvRepository answers queries from First.org's daily gzipped CSV datasets.
+type csvRepository struct {
+	// open returns the gzipped dataset for date and a description of where it came from.
+	open func(date string) (io.ReadCloser, string, error)
+	// latest returns the date used by queries that do not name one.
+	latest func() (string, error)
+
+	// computePercentile derives missing percentiles from the day's scores.
+	computePercentile bool
+
+	mu   sync.Mutex
+	days map[string][]models.CVE
+}
+
+// CSVOption configures a CSV repository.
+type CSVOption func(*csvRepository)
+
+// WithComputedPercentile accepts datasets without a percentile column, or with
+// empty percentile values, deriving each missing percentile as the fraction of
+// the day's CVEs scoring at or below the CVE. This approximates the official
+// percentile, which First.org computes from its own full ranking.
+func WithComputedPercentile() CSVOption {
+	return func(r *csvRepository) {
+		r.computePercentile = true
+	}
+}
+
+// NewCSVRepository creates a repository reading daily CSV datasets from mirrorURL.
+// Queries without a date use today's dataset.
+func NewCSVRepository(mirrorURL string, opts ...CSVOption) ports.EPSSRepository {
+	mirrorURL = strings.TrimRight(mirrorURL, "/")
+	r := &csvRepository{
+		open: func(date string) (io.ReadCloser, string, error) {
+			url := fmt.Sprintf("%s/%s", mirrorURL, CSVFileName(date))
+			log.Printf("Fetching CSV data from: %s", url)
+			resp, err := http.Get(url)
+			if err != nil {
+				return nil, url, fmt.Errorf("failed to fetch data from %s: %w", url, err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				resp.Body.Close()
+				return nil, url, &apierr.StatusError{StatusCode: resp.StatusCode, URL: url}
+			}
+			return resp.Body, url, nil
+		},
+		latest: func() (string, error) {
+			return time.Now().UTC().Format("2006-01-02"), nil
+		},
+		days: make(map[string][]models.CVE),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NewCSVDirRepository creates a repository reading daily epss_scores-YYYY-MM-DD.csv.gz
+// datasets from a local directory, for offline use. Queries without a date use the
+// most recent dataset in the directory.
+func NewCSVDirRepository(dir string, opts ...CSVOption) ports.EPSSRepository {
+	r := &csvRepository{
+		open: func(date string) (io.ReadCloser, string, error) {
+			path := filepath.Join(dir, CSVFileName(date))
+			f, err := os.Open(path)
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, path, fmt.Errorf("no CSV dataset for %s in %s", date, dir)
+			}
+			if err != nil {
+				return nil, path, fmt.Errorf("failed to open %s: %w", path, err)
+			}
+			return f, path, nil
+		},
+		latest: func() (string, error) {
+			return latestCSVDate(dir)
+		},
+		days: make(map[string][]models.CVE),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NewCSVFileRepository creates a repository answering queries from a single local
+// epss_scores-YYYY-MM-DD.csv.gz dataset, for offline use without a whole archive.
+// The data date is taken from the file name, and queries for any other date fail.
+func NewCSVFileRepository(path string, opts ...CSVOption) ports.EPSSRepository {
+	var date string
+	var dateErr error
+	if m := csvFilePattern.FindStringSubmatch(filepath.Base(path)); m != nil {
+		date = m[1]
+	} else {
+		dateErr = fmt.Errorf("cannot tell the date of %s: name it like %s", path, CSVFileName("YYYY-MM-DD"))
+	}
+	r := &csvRepository{
+		open: func(d string) (io.ReadCloser, string, error) {
+			if dateErr != nil {
+				return nil, path, dateErr
+			}
+			if d != date {
+				return nil, path, fmt.Errorf("%s only holds data for %s, not %s", path, date, d)
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, path, fmt.Errorf("failed to open %s: %w", path, err)
+			}
+			return f, path, nil
+		},
+		latest: func() (string, error) {
+			return date, dateErr
+		},
+		days: make(map[string][]models.CVE),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// latestCSVDate returns the date of the most recent dataset in dir.
+func latestCSVDate(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read CSV directory: %w", err)
+	}
+	latest := ""
+	for _, entry := range entries {
+		if m := csvFilePattern.FindStringSubmatch(entry.Name()); m != nil && m[1] > latest {
+			latest = m[1]
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no CSV datasets found in %s", dir)
+	}
+	return latest, nil
+}
+
+// GetCVEScore retrieves the EPSS score for a CVE from the dataset for date (the latest when empty).
+func (r *csvRepository) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, cve := range cves {
+		if strings.EqualFold(cve.ID, cveID) {
+			found := cve
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("no CVE found for ID: %s", cveID)
+}
+
+// GetCVEScores retrieves EPSS scores for several CVEs from the dataset for date.
+// CVEs missing from the dataset are omitted from the result.
+func (r *csvRepository) GetCVEScores(ctx context.Context, cveIDs []string, date string) ([]models.CVE, error) {
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(cveIDs))
+	for _, id := range cveIDs {
+		wanted[strings.ToUpper(id)] = true
+	}
+	var found []models.CVE
+	for _, cve := range cves {
+		if wanted[strings.ToUpper(cve.ID)] {
+			found = append(found, cve)
+		}
+	}
+	return found, nil
+}
+
+// GetTopNCVEs retrieves the top N CVEs by EPSS score from the latest dataset.
+func (r *csvRepository) GetTopNCVEs(ctx context.Context, n int) ([]models.CVE, error) {
+	cves, err := r.day(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	top := append([]models.CVE(nil), cves...)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].EPSSScore > top[j].EPSSScore
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top, nil
+}
+
+// GetHighestIncreases compares the latest dataset with the one from days earlier and returns
+// the limit CVEs whose EPSS score rose the most. CVEs absent from the earlier dataset are skipped.
+func (r *csvRepository) GetHighestIncreases(ctx context.Context, days int, limit int) ([]models.ScoreChange, error) {
+	latest, err := r.latest()
+	if err != nil {
+		return nil, err
+	}
+	now, err := time.Parse("2006-01-02", latest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latest date: %w", err)
+	}
+	start, err := r.day(ctx, now.AddDate(0, 0, -days).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	end, err := r.day(ctx, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	initial := make(map[string]float64, len(start))
+	for _, cve := range start {
+		initial[cve.ID] = cve.EPSSScore
+	}
+	var changes []models.ScoreChange
+	for _, cve := range end {
+		before, ok := initial[cve.ID]
+		if !ok || cve.EPSSScore <= before {
+			continue
+		}
+		changes = append(changes, models.ScoreChange{CVE: cve.ID, Date: now, ScoreChange: cve.EPSSScore - before})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ScoreChange > changes[j].ScoreChange
+	})
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// GetCVEsForDate retrieves every CVE in the dataset for date.
+func (r *csvRepository) GetCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	return r.day(ctx, date)
+}
+
+// GetCVEsForDateOrdered retrieves every CVE in the dataset for date, sorted by order.
+func (r *csvRepository) GetCVEsForDateOrdered(ctx context.Context, date string, order models.Ordering) ([]models.CVE, error) {
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	return sortCVEs(cves, order), nil
+}
+
+// GetCVEsForDatePaged retrieves up to maxResults CVEs in the dataset for date, or all of them
+// when maxResults is 0 or less.
+func (r *csvRepository) GetCVEsForDatePaged(ctx context.Context, date string, maxResults int) ([]models.CVE, error) {
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if maxResults > 0 && len(cves) > maxResults {
+		cves = cves[:maxResults]
+	}
+	return cves, nil
+}
+
+// GetAllCVEsForDate retrieves every CVE in the dataset for date.
+func (r *csvRepository) GetAllCVEsForDate(ctx context.Context, date string) ([]models.CVE, error) {
+	return r.day(ctx, date)
+}
+
+// GetTimeSeries is not supported: each CSV file holds a single day.
+func (r *csvRepository) GetTimeSeries(ctx context.Context, cveID string) ([]models.CVE, error) {
+	return nil, fmt.Errorf("time series queries are not supported by the CSV source")
+}
+
+// GetCVEsAboveThreshold retrieves CVEs from the latest dataset whose field (epss or percentile) exceeds threshold.
+func (r *csvRepository) GetCVEsAboveThreshold(ctx context.Context, threshold float64, field string) ([]models.CVE, error) {
+	return r.filter(ctx, "", field, func(v float64) bool { return v > threshold })
+}
+
+// GetCVEsAboveThresholdOrdered retrieves the CVEs above threshold from the latest dataset, sorted by order.
+func (r *csvRepository) GetCVEsAboveThresholdOrdered(ctx context.Context, threshold float64, field string, order models.Ordering) ([]models.CVE, error) {
+	cves, err := r.GetCVEsAboveThreshold(ctx, threshold, field)
+	if err != nil {
+		return nil, err
+	}
+	return sortCVEs(cves, order), nil
+}
+
+// GetCVEsAboveThresholdForDate retrieves the CVEs for date whose field exceeds threshold.
+func (r *csvRepository) GetCVEsAboveThresholdForDate(ctx context.Context, date string, threshold float64, field string) ([]models.CVE, error) {
+	return r.filter(ctx, date, field, func(v float64) bool { return v > threshold })
+}
+
+// GetCVEsInBand retrieves up to limit CVEs for date whose field lies between min and max.
+// A min of 0 or a max of 1 leaves that side of the band open.
+func (r *csvRepository) GetCVEsInBand(ctx context.Context, date string, field string, min, max float64, limit int) ([]models.CVE, error) {
+	cves, err := r.filter(ctx, date, field, func(v float64) bool {
+		return (min <= 0 || v > min) && (max >= 1 || v < max)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(cves) > limit {
+		cves = cves[:limit]
+	}
+	return cves, nil
+}
+
+// GetCVEsInRange retrieves the CVEs of the dataset for date (the latest when empty) whose field
+// lies strictly between min and max.
+func (r *csvRepository) GetCVEsInRange(ctx context.Context, date string, field string, min, max float64) ([]models.CVE, error) {
+	if err := validateBand(field, min, max); err != nil {
+		return nil, err
+	}
+	return r.filter(ctx, date, field, func(v float64) bool { return v > min && v < max })
+}
+
+// GetCVEsAbovePercentile returns the CVEs of the dataset for date whose percentile exceeds min,
+// highest percentile first.
+func (r *csvRepository) GetCVEsAbovePercentile(ctx context.Context, date string, min float64) ([]models.CVE, error) {
+	if min < 0 || min >= 1 {
+		return nil, fmt.Errorf("percentile bound must be at least 0 and below 1, got %g", min)
+	}
+	cves, err := r.filter(ctx, date, "percentile", func(v float64) bool { return v > min })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(cves, func(i, j int) bool { return cves[i].Percentile > cves[j].Percentile })
+	return cves, nil
+}
+
+// GetTotalCVEs returns the number of CVEs in the dataset for date.
+func (r *csvRepository) GetTotalCVEs(ctx context.Context, date string) (int, error) {
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return 0, err
+	}
+	return len(cves), nil
+}
+
+// GetCVEPage returns the CVEs of the dataset for date from offset onwards in
+// a single page, since the whole file is loaded anyway.
+func (r *csvRepository) GetCVEPage(ctx context.Context, date string, offset int) ([]models.CVE, bool, error) {
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return nil, false, err
+	}
+	if offset >= len(cves) {
+		return nil, false, nil
+	}
+	return cves[offset:], false, nil
+}
+
+// filter returns the CVEs for date whose field value satisfies keep.
+func (r *csvRepository) filter(ctx context.Context, date string, field string, keep func(float64) bool) ([]models.CVE, error) {
+	value, err := fieldValue(field)
+	if err != nil {
+		return nil, err
+	}
+	cves, err := r.day(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	var matched []models.CVE
+	for _, cve := range cves {
+		if keep(value(cve)) {
+			matched = append(matched, cve)
+		}
+	}
+	return matched, nil
+}
+
+// fieldValue returns an accessor for the epss or percentile field.
+func fieldValue(field string) (func(models.CVE) float64, error) {
+	switch field {
+	case "epss":
+		return func(c models.CVE) float64 { return c.EPSSScore }, nil
+	case "percentile":
+		return func(c models.CVE) float64 { return c.Percentile }, nil
+	default:
+		return nil, fmt.Errorf("unsupported field: %s (expected epss or percentile)", field)
+	}
+}
+
+// day returns the parsed dataset for date (the latest when empty), loading it on first use.
+// Loading is not interruptible, so ctx is only checked beforehand.
+func (r *csvRepository) day(ctx context.Context, date string) ([]models.CVE, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if date == "" {
+		latest, err := r.latest()
+		if err != nil {
+			return nil, err
+		}
+		date = latest
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cves, ok := r.days[date]; ok {
+		return cves, nil
+	}
+
+	body, source, err := r.open(date)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	cves, err := parseEPSSCSV(body, date, r.computePercentile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+	r.days[date] = cves
+	return cves, nil
+}
+
+// parseEPSSCSV parses a gzipped EPSS CSV dataset. The file starts with a
+// "#model_version:...,score_date:..." comment line followed by a
+// cve,epss,percentile header. Rows are stamped with date. With computePercentile,
+// the percentile column may be missing or empty and is derived from the scores.
+func parseEPSSCSV(gz io.Reader, date string, computePercentile bool) ([]models.CVE, error) {
+	zr, err := gzip.NewReader(gz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip data: %w", err)
+	}
+	defer zr.Close()
+
+	reader := csv.NewReader(zr)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	required := []string{"cve", "epss", "percentile"}
+	if computePercentile {
+		required = required[:2]
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing %s column", name)
+		}
+	}
+	percentileColumn, hasPercentile := columns["percentile"]
+
+	var cves []models.CVE
+	var missing []int
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < len(header) {
+			return nil, fmt.Errorf("short record: %v", record)
+		}
+		epss, err := strconv.ParseFloat(record[columns["epss"]], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse epss field: %w", err)
+		}
+		var percentile float64
+		if computePercentile && (!hasPercentile || strings.TrimSpace(record[percentileColumn]) == "") {
+			missing = append(missing, len(cves))
+		} else if percentile, err = strconv.ParseFloat(record[percentileColumn], 64); err != nil {
+			return nil, fmt.Errorf("failed to parse percentile field: %w", err)
+		}
+		cves = append(cves, models.CVE{
+			ID:         record[columns["cve"]],
+			EPSSScore:  epss,
+			Percentile: percentile,
+			Date:       date,
+		})
+	}
+	if len(missing) > 0 {
+		computePercentiles(cves, missing)
+	}
+	return cves, nil
+}
+
+// computePercentiles sets the percentile of each CVE at the given indexes to
+// the fraction of cves scoring at or below it, sorting the scores once.
+func computePercentiles(cves []models.CVE, indexes []int) {
+	scores := make([]float64, len(cves))
+	for i, cve := range cves {
+		scores[i] = cve.EPSSScore
+	}
+	sort.Float64s(scores)
+	n := float64(len(scores))
+	for _, i := range indexes {
+		v := cves[i].EPSSScore
+		atOrBelow := sort.Search(len(scores), func(j int) bool { return scores[j] > v })
+		cves[i].Percentile = float64(atOrBelow) / n
+	}
+}
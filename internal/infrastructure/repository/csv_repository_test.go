@@ -0,0 +1,192 @@
+package repository_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCSVFixtureDir writes a small archive of daily datasets to a temporary directory.
+func newCSVFixtureDir(t *testing.T) string {
+	dir := t.TempDir()
+	days := map[string]string{
+		"2024-10-16": "CVE-2023-0001,0.10000,0.50000\nCVE-2023-0002,0.30000,0.80000\n",
+		"2024-10-17": "CVE-2023-0001,0.20000,0.60000\nCVE-2023-0002,0.30000,0.80000\n",
+		"2024-10-18": "CVE-2023-0001,0.60000,0.95000\nCVE-2023-0002,0.35000,0.85000\nCVE-2024-0003,0.01000,0.20000\n",
+	}
+	for date, rows := range days {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "epss_scores-"+date+".csv.gz"), gzipCSV(t, rows), 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("not a dataset"), 0o644))
+	return dir
+}
+
+func TestCSVDirRepository(t *testing.T) {
+	t.Run("Success - Reads The Requested Date", func(t *testing.T) {
+		repo := repository.NewCSVDirRepository(newCSVFixtureDir(t))
+
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-17")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.2, cve.EPSSScore)
+		assert.Equal(t, "2024-10-17", cve.Date)
+	})
+
+	t.Run("Success - Undated Queries Use The Latest File", func(t *testing.T) {
+		repo := repository.NewCSVDirRepository(newCSVFixtureDir(t))
+
+		cves, err := repo.GetCVEsForDate(context.Background(), "")
+
+		assert.NoError(t, err)
+		assert.Len(t, cves, 3)
+		assert.Equal(t, "2024-10-18", cves[0].Date)
+	})
+
+	t.Run("Success - Highest Increases Over The Archive", func(t *testing.T) {
+		repo := repository.NewCSVDirRepository(newCSVFixtureDir(t))
+
+		changes, err := repo.GetHighestIncreases(context.Background(), 2, 10)
+
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, "CVE-2023-0001", changes[0].CVE)
+		assert.InDelta(t, 0.5, changes[0].ScoreChange, 1e-9)
+		assert.Equal(t, "CVE-2023-0002", changes[1].CVE)
+	})
+
+	t.Run("Success - Scores In Scientific Notation", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "epss_scores-2024-10-18.csv.gz"), gzipCSV(t, "CVE-2023-0001,1.2e-05,3.4E-3\n"), 0o644))
+		repo := repository.NewCSVDirRepository(dir)
+
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.000012, cve.EPSSScore)
+		assert.Equal(t, 0.0034, cve.Percentile)
+	})
+
+	t.Run("Success - Band Of The Latest Dataset", func(t *testing.T) {
+		repo := repository.NewCSVDirRepository(newCSVFixtureDir(t))
+
+		cves, err := repo.GetCVEsInRange(context.Background(), "", "epss", 0.3, 0.6)
+
+		require.NoError(t, err)
+		require.Len(t, cves, 1)
+		assert.Equal(t, "CVE-2023-0002", cves[0].ID)
+		assert.Equal(t, "2024-10-18", cves[0].Date)
+	})
+
+	t.Run("Fail - Missing Date", func(t *testing.T) {
+		dir := newCSVFixtureDir(t)
+		repo := repository.NewCSVDirRepository(dir)
+
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-01")
+
+		assert.EqualError(t, err, "no CSV dataset for 2024-10-01 in "+dir)
+	})
+
+	t.Run("Fail - Empty Directory", func(t *testing.T) {
+		repo := repository.NewCSVDirRepository(t.TempDir())
+
+		_, err := repo.GetTopNCVEs(context.Background(), 10)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCSVFileRepository(t *testing.T) {
+	path := filepath.Join(newCSVFixtureDir(t), "epss_scores-2024-10-18.csv.gz")
+
+	t.Run("Success - Answers Undated And Dated Queries From The File", func(t *testing.T) {
+		repo := repository.NewCSVFileRepository(path)
+
+		top, err := repo.GetTopNCVEs(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, top, 1)
+		assert.Equal(t, "CVE-2023-0001", top[0].ID)
+		assert.Equal(t, "2024-10-18", top[0].Date)
+
+		above, err := repo.GetCVEsAboveThreshold(context.Background(), 0.3, "epss")
+		require.NoError(t, err)
+		assert.Len(t, above, 2)
+
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2024-0003", "2024-10-18")
+		require.NoError(t, err)
+		assert.Equal(t, 0.01, cve.EPSSScore)
+	})
+
+	t.Run("Fail - Other Date", func(t *testing.T) {
+		_, err := repository.NewCSVFileRepository(path).GetCVEsForDate(context.Background(), "2024-10-17")
+
+		assert.EqualError(t, err, path+" only holds data for 2024-10-18, not 2024-10-17")
+	})
+
+	t.Run("Fail - Undated File Name", func(t *testing.T) {
+		renamed := filepath.Join(t.TempDir(), "scores.csv.gz")
+		require.NoError(t, os.WriteFile(renamed, gzipCSV(t, "CVE-2023-0001,0.1,0.5\n"), 0o644))
+
+		_, err := repository.NewCSVFileRepository(renamed).GetTopNCVEs(context.Background(), 1)
+
+		assert.ErrorContains(t, err, "cannot tell the date of "+renamed)
+	})
+}
+
+func TestWithComputedPercentile(t *testing.T) {
+	writeDataset := func(t *testing.T, content string) string {
+		dir := t.TempDir()
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "epss_scores-2024-10-18.csv.gz"), buf.Bytes(), 0o644))
+		return dir
+	}
+
+	t.Run("Success - Missing Column", func(t *testing.T) {
+		dir := writeDataset(t, "cve,epss\nCVE-2023-0001,0.5\nCVE-2023-0002,0.1\nCVE-2023-0003,0.5\nCVE-2023-0004,0.9\n")
+		repo := repository.NewCSVDirRepository(dir, repository.WithComputedPercentile())
+
+		cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		percentiles := map[string]float64{}
+		for _, cve := range cves {
+			percentiles[cve.ID] = cve.Percentile
+		}
+		assert.Equal(t, map[string]float64{
+			"CVE-2023-0001": 0.75,
+			"CVE-2023-0002": 0.25,
+			"CVE-2023-0003": 0.75,
+			"CVE-2023-0004": 1,
+		}, percentiles)
+	})
+
+	t.Run("Success - Only Empty Values Are Derived", func(t *testing.T) {
+		dir := writeDataset(t, "cve,epss,percentile\nCVE-2023-0001,0.5,0.42\nCVE-2023-0002,0.1,\n")
+		repo := repository.NewCSVDirRepository(dir, repository.WithComputedPercentile())
+
+		cves, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.42, cves[0].Percentile)
+		assert.Equal(t, 0.5, cves[1].Percentile)
+	})
+
+	t.Run("Fail - Missing Column Without The Option", func(t *testing.T) {
+		dir := writeDataset(t, "cve,epss\nCVE-2023-0001,0.5\n")
+		repo := repository.NewCSVDirRepository(dir)
+
+		_, err := repo.GetCVEsForDate(context.Background(), "2024-10-18")
+
+		assert.ErrorContains(t, err, "missing percentile column")
+	})
+}
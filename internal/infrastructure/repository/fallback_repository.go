@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+)
+
+// Source is a named score source in a fallback chain.
+type Source struct {
+	Name   string
+	Source ports.ScoreSource
+}
+
+// fallbackChain tries each source in order until one answers.
+type fallbackChain struct {
+	sources []Source
+}
+
+// NewFallbackChain creates a ScoreSource that queries sources in order and returns the first success.
+func NewFallbackChain(sources ...Source) ports.ScoreSource {
+	return &fallbackChain{sources: sources}
+}
+
+// GetCVEScore retrieves the EPSS score from the first source that has it.
+func (f *fallbackChain) GetCVEScore(ctx context.Context, cveID string, date string) (*models.CVE, error) {
+	return GetScoreWithFallbackChain(ctx, f.sources, cveID, date)
+}
+
+// GetScoreWithFallbackChain queries sources in order and returns the first successful result,
+// with its Source set to the name of the source that answered. If every source fails, the returned error joins each source's error, labelled with its name.
+func GetScoreWithFallbackChain(ctx context.Context, sources []Source, cveID string, date string) (*models.CVE, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no score sources configured")
+	}
+	var errs []error
+	for _, s := range sources {
+		cve, err := s.Source.GetCVEScore(ctx, cveID, date)
+		if err == nil {
+			answered := *cve
+			answered.Source = s.Name
+			return &answered, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", s.Name, err))
+	}
+	return nil, fmt.Errorf("all score sources failed: %w", errors.Join(errs...))
+}
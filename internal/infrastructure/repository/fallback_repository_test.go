@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/cache"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipCSV returns a gzipped EPSS CSV dataset holding rows.
+func gzipCSV(t *testing.T, rows string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := fmt.Fprintf(zw, "#model_version:v2023.03.01,score_date:2024-10-18T00:00:00+0000\ncve,epss,percentile\n%s", rows)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// newChainServers starts an API server and a CSV mirror, counting API calls.
+func newChainServers(t *testing.T, apiStatus int) (api *httptest.Server, mirror *httptest.Server, apiCalls *int32) {
+	apiCalls = new(int32)
+	api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(apiCalls, 1)
+		if apiStatus != http.StatusOK {
+			http.Error(w, "unavailable", apiStatus)
+			return
+		}
+		fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.00044","percentile":"0.13","date":"2024-10-18"}]}`)
+	}))
+	t.Cleanup(api.Close)
+
+	data := gzipCSV(t, "CVE-2023-0001,0.00050,0.14000\n")
+	mirror = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/epss_scores-2024-10-18.csv.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(mirror.Close)
+	return api, mirror, apiCalls
+}
+
+func TestGetScoreWithFallbackChain(t *testing.T) {
+	t.Run("Success - Served From Cache", func(t *testing.T) {
+		api, mirror, apiCalls := newChainServers(t, http.StatusOK)
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+		_, err := repository.NewAPIRepository(api.URL, repository.WithCache(c)).GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+		require.NoError(t, err)
+
+		chain := repository.NewFallbackChain(
+			repository.Source{Name: "cache", Source: repository.NewCacheOnlyRepository(api.URL, c)},
+			repository.Source{Name: "api", Source: repository.NewAPIRepository(api.URL)},
+			repository.Source{Name: "csv", Source: repository.NewCSVRepository(mirror.URL)},
+		)
+		cve, err := chain.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.00044, cve.EPSSScore)
+		assert.Equal(t, "cache", cve.Source)
+		assert.Equal(t, int32(1), atomic.LoadInt32(apiCalls))
+	})
+
+	t.Run("Success - Falls Back To API On Cache Miss", func(t *testing.T) {
+		api, mirror, apiCalls := newChainServers(t, http.StatusOK)
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		chain := repository.NewFallbackChain(
+			repository.Source{Name: "cache", Source: repository.NewCacheOnlyRepository(api.URL, c)},
+			repository.Source{Name: "api", Source: repository.NewAPIRepository(api.URL)},
+			repository.Source{Name: "csv", Source: repository.NewCSVRepository(mirror.URL)},
+		)
+		cve, err := chain.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.00044, cve.EPSSScore)
+		assert.Equal(t, "api", cve.Source)
+		assert.Equal(t, int32(1), atomic.LoadInt32(apiCalls))
+	})
+
+	t.Run("Success - Falls Back To CSV Mirror On API Error", func(t *testing.T) {
+		api, mirror, _ := newChainServers(t, http.StatusServiceUnavailable)
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		chain := repository.NewFallbackChain(
+			repository.Source{Name: "cache", Source: repository.NewCacheOnlyRepository(api.URL, c)},
+			repository.Source{Name: "api", Source: repository.NewAPIRepository(api.URL)},
+			repository.Source{Name: "csv", Source: repository.NewCSVRepository(mirror.URL)},
+		)
+		cve, err := chain.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CVE-2023-0001", cve.ID)
+		assert.Equal(t, 0.0005, cve.EPSSScore)
+		assert.Equal(t, "2024-10-18", cve.Date)
+		assert.Equal(t, "csv", cve.Source)
+	})
+
+	t.Run("Fail - Aggregates Errors From Every Source", func(t *testing.T) {
+		api, mirror, _ := newChainServers(t, http.StatusServiceUnavailable)
+		c := cache.NewFileCache(t.TempDir(), time.Hour)
+
+		chain := repository.NewFallbackChain(
+			repository.Source{Name: "cache", Source: repository.NewCacheOnlyRepository(api.URL, c)},
+			repository.Source{Name: "api", Source: repository.NewAPIRepository(api.URL)},
+			repository.Source{Name: "csv", Source: repository.NewCSVRepository(mirror.URL)},
+		)
+		_, err := chain.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-01-01")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache:")
+		assert.Contains(t, err.Error(), "api:")
+		assert.Contains(t, err.Error(), "csv:")
+	})
+
+	t.Run("Fail - No Sources", func(t *testing.T) {
+		_, err := repository.GetScoreWithFallbackChain(context.Background(), nil, "CVE-2023-0001", "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// debugHeaders are the response headers logged by WithDebugHeaders, besides
+// any rate-limit headers.
+var debugHeaders = []string{"Cache-Control", "Age", "Date", "Content-Length", "Retry-After"}
+
+// sensitiveHeaderParts mark header names whose values are never logged.
+var sensitiveHeaderParts = []string{"authorization", "cookie", "token", "secret", "key"}
+
+// WithDebugHeaders logs the upstream response headers relevant to caching and
+// rate limiting for every request sent over the network.
+func WithDebugHeaders() Option {
+	return func(r *apiRepository) {
+		r.debugHeaders = true
+	}
+}
+
+// logResponseHeaders logs the caching and rate-limit headers of a response to url.
+func logResponseHeaders(url string, header http.Header) {
+	if fields := responseHeaderFields(header); len(fields) > 0 {
+		log.Printf("Response headers for %s: %s", url, strings.Join(fields, " "))
+	}
+}
+
+// responseHeaderFields returns the headers of interest as sorted name=value
+// pairs, redacting the value of any header that looks sensitive.
+func responseHeaderFields(header http.Header) []string {
+	var fields []string
+	for name, values := range header {
+		if !debugHeader(name) {
+			continue
+		}
+		value := strings.Join(values, ", ")
+		if sensitiveHeader(name) {
+			value = "[REDACTED]"
+		}
+		fields = append(fields, name+"="+value)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// debugHeader reports whether the canonical header name is logged.
+func debugHeader(name string) bool {
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "x-ratelimit-") || strings.HasPrefix(lower, "ratelimit") {
+		return true
+	}
+	for _, h := range debugHeaders {
+		if name == h {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveHeader reports whether a header's value must not be logged.
+func sensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveHeaderParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redacted replaces the values of credential parameters.
+const redacted = "REDACTED"
+
+// sensitiveParams are substrings of query parameter names whose values are
+// credentials, such as an API key embedded in a mirror's base URL.
+var sensitiveParams = []string{"key", "token", "secret", "password", "auth"}
+
+// WithQueryObserver calls observe with the query parameters of every request
+// the repository builds, including those later served from a cache, so
+// callers can report exactly what was queried. Credential values are
+// redacted.
+func WithQueryObserver(observe func(params map[string]string)) Option {
+	return func(r *apiRepository) {
+		r.observe = observe
+	}
+}
+
+// redactedParams flattens query to one comma-joined value per parameter,
+// redacting credentials.
+func redactedParams(query url.Values) map[string]string {
+	params := make(map[string]string, len(query))
+	for name, values := range query {
+		value := strings.Join(values, ",")
+		lower := strings.ToLower(name)
+		for _, s := range sensitiveParams {
+			if strings.Contains(lower, s) {
+				value = redacted
+				break
+			}
+		}
+		params[name] = value
+	}
+	return params
+}
+
+// RedactURL hides the password and credential query parameters of rawURL,
+// such as a mirror's base URL.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if query := u.Query(); len(query) > 0 {
+		for name, value := range redactedParams(query) {
+			if value == redacted {
+				query.Set(name, redacted)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// ParseOrdering returns the ordering by field (epss or percentile), highest
+// first when desc is set. An empty field leaves results unordered.
+func ParseOrdering(field string, desc bool) (models.Ordering, error) {
+	switch {
+	case field == "" && desc:
+		return models.Unordered, fmt.Errorf("descending order needs a sort field (epss or percentile)")
+	case field == "":
+		return models.Unordered, nil
+	case field == "epss" && desc:
+		return models.EPSSDesc, nil
+	case field == "epss":
+		return models.EPSSAsc, nil
+	case field == "percentile" && desc:
+		return models.PercentileDesc, nil
+	case field == "percentile":
+		return models.PercentileAsc, nil
+	default:
+		return models.Unordered, fmt.Errorf("unsupported sort field: %s (expected epss or percentile)", field)
+	}
+}
+
+// orderParam returns the API's order parameter for o, or "" when unordered.
+func orderParam(o models.Ordering) string {
+	switch o {
+	case models.EPSSAsc:
+		return "epss"
+	case models.EPSSDesc:
+		return "!epss"
+	case models.PercentileAsc:
+		return "percentile"
+	case models.PercentileDesc:
+		return "!percentile"
+	default:
+		return ""
+	}
+}
+
+// sortCVEs returns a copy of cves sorted by o, keeping the order of ties.
+func sortCVEs(cves []models.CVE, o models.Ordering) []models.CVE {
+	if o == models.Unordered {
+		return cves
+	}
+	sorted := append([]models.CVE(nil), cves...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return o.Less(sorted[i], sorted[j])
+	})
+	return sorted
+}
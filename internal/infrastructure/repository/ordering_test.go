@@ -0,0 +1,85 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrdering(t *testing.T) {
+	t.Run("Success - Field And Direction", func(t *testing.T) {
+		for _, tc := range []struct {
+			field string
+			desc  bool
+			want  models.Ordering
+		}{
+			{"", false, models.Unordered},
+			{"epss", false, models.EPSSAsc},
+			{"epss", true, models.EPSSDesc},
+			{"percentile", false, models.PercentileAsc},
+			{"percentile", true, models.PercentileDesc},
+		} {
+			got, err := repository.ParseOrdering(tc.field, tc.desc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got, "%s desc=%v", tc.field, tc.desc)
+		}
+	})
+
+	t.Run("Fail - Unknown Field Or Direction Without Field", func(t *testing.T) {
+		_, err := repository.ParseOrdering("cvss", false)
+		assert.EqualError(t, err, "unsupported sort field: cvss (expected epss or percentile)")
+		_, err = repository.ParseOrdering("", true)
+		assert.Error(t, err)
+	})
+}
+
+func TestOrderedQueries(t *testing.T) {
+	var orders []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orders = append(orders, r.URL.Query().Get("order"))
+		fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.5","percentile":"0.9","date":"2024-10-18"}]}`)
+	}))
+	defer mockServer.Close()
+
+	t.Run("Success - API Receives The Order Parameter", func(t *testing.T) {
+		orders = nil
+		repo := repository.NewAPIRepository(mockServer.URL)
+
+		_, err := repo.GetCVEsForDateOrdered(context.Background(), "2024-10-18", models.EPSSDesc)
+		require.NoError(t, err)
+		_, err = repo.GetCVEsAboveThresholdOrdered(context.Background(), 0.1, "epss", models.PercentileAsc)
+		require.NoError(t, err)
+		_, err = repo.GetCVEsForDate(context.Background(), "2024-10-17")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"!epss", "percentile", ""}, orders)
+	})
+
+	t.Run("Success - CSV Datasets Are Sorted In Memory", func(t *testing.T) {
+		repo := repository.NewCSVDirRepository(newCSVFixtureDir(t))
+
+		asc, err := repo.GetCVEsForDateOrdered(context.Background(), "2024-10-18", models.EPSSAsc)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CVE-2024-0003", "CVE-2023-0002", "CVE-2023-0001"}, ids(asc))
+
+		desc, err := repo.GetCVEsAboveThresholdOrdered(context.Background(), 0.1, "percentile", models.PercentileDesc)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CVE-2023-0001", "CVE-2023-0002", "CVE-2024-0003"}, ids(desc))
+	})
+}
+
+// ids returns the CVE IDs of cves in order.
+func ids(cves []models.CVE) []string {
+	out := make([]string, len(cves))
+	for i, cve := range cves {
+		out[i] = cve.ID
+	}
+	return out
+}
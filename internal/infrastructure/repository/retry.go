@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+)
+
+// DefaultRetryDelay is the wait before the first retry; each further retry doubles it.
+const DefaultRetryDelay = 500 * time.Millisecond
+
+// maxRetryDelay caps the exponential backoff between retries.
+const maxRetryDelay = 30 * time.Second
+
+// Sleeper waits between retry attempts, returning ctx's error if ctx is done
+// first. Tests substitute one that records the requested delays instead of
+// waiting.
+type Sleeper interface {
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SleeperFunc adapts a function to the Sleeper interface.
+type SleeperFunc func(ctx context.Context, d time.Duration) error
+
+// Sleep calls f(ctx, d).
+func (f SleeperFunc) Sleep(ctx context.Context, d time.Duration) error {
+	return f(ctx, d)
+}
+
+// realSleeper is the default Sleeper.
+var realSleeper Sleeper = SleeperFunc(sleepContext)
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WithRetries retries requests failing with a transient error (see apierr.IsRetryable)
+// up to n more times, waiting delay before the first retry and doubling it after each.
+// There is no jitter, so the delay sequence is deterministic.
+func WithRetries(n int, delay time.Duration) Option {
+	return func(r *apiRepository) {
+		r.retries = n
+		r.retryDelay = delay
+	}
+}
+
+// WithSleeper waits between retries with s instead of a timer.
+func WithSleeper(s Sleeper) Option {
+	return func(r *apiRepository) {
+		r.sleeper = s
+	}
+}
+
+// backoffDelay returns the wait before retry number attempt (starting at 0).
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 0; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// fetchWithRetry calls fetchURL, retrying transient failures as configured by WithRetries.
+// It stops with ctx's error when ctx is done during the wait before a retry.
+func (r *apiRepository) fetchWithRetry(ctx context.Context, url string, cond validators) (response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := r.fetchURL(ctx, url, cond)
+		if err == nil || attempt >= r.retries || !apierr.IsRetryable(err) || ctx.Err() != nil {
+			return resp, err
+		}
+		delay := backoffDelay(r.retryDelay, attempt)
+		log.Printf("Retrying %s in %s after error: %v", url, delay, err)
+		if err := r.sleeper.Sleep(ctx, delay); err != nil {
+			return response{}, err
+		}
+	}
+}
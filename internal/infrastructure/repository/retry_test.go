@@ -0,0 +1,119 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSleeper captures requested delays without waiting.
+type recordingSleeper struct {
+	delays []time.Duration
+}
+
+func (s *recordingSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	s.delays = append(s.delays, d)
+	return nil
+}
+
+func TestWithRetries(t *testing.T) {
+	t.Run("Success - Retries Transient Errors With Doubling Delays", func(t *testing.T) {
+		var calls int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer mockServer.Close()
+
+		sleeper := &recordingSleeper{}
+		repo := repository.NewAPIRepository(mockServer.URL,
+			repository.WithRetries(3, 100*time.Millisecond), repository.WithSleeper(sleeper))
+		cve, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CVE-2023-0001", cve.ID)
+		assert.Equal(t, 4, calls)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}, sleeper.delays)
+	})
+
+	t.Run("Success - Delays Are Capped", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer mockServer.Close()
+
+		sleeper := &recordingSleeper{}
+		repo := repository.NewAPIRepository(mockServer.URL,
+			repository.WithRetries(4, 10*time.Second), repository.WithSleeper(sleeper))
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.Error(t, err)
+		assert.Equal(t, []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}, sleeper.delays)
+	})
+
+	t.Run("Fail - Gives Up After Retries", func(t *testing.T) {
+		var calls int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer mockServer.Close()
+
+		sleeper := &recordingSleeper{}
+		repo := repository.NewAPIRepository(mockServer.URL,
+			repository.WithRetries(2, time.Second), repository.WithSleeper(sleeper))
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.Error(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, sleeper.delays)
+	})
+
+	t.Run("Fail - Client Errors Are Not Retried", func(t *testing.T) {
+		var calls int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer mockServer.Close()
+
+		sleeper := &recordingSleeper{}
+		repo := repository.NewAPIRepository(mockServer.URL,
+			repository.WithRetries(3, time.Second), repository.WithSleeper(sleeper))
+		_, err := repo.GetCVEScore(context.Background(), "CVE-2023-0001", "2024-10-18")
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, sleeper.delays)
+	})
+
+	t.Run("Fail - Cancelled While Waiting To Retry", func(t *testing.T) {
+		var calls int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer mockServer.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		repo := repository.NewAPIRepository(mockServer.URL, repository.WithRetries(3, time.Hour))
+		start := time.Now()
+		_, err := repo.GetCVEScore(ctx, "CVE-2023-0001", "2024-10-18")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,152 @@
+// Package scanners extracts CVE findings from vulnerability scanner reports.
+package scanners
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// Formats lists the supported report formats.
+var Formats = []string{"trivy", "grype"}
+
+// Parse reads a report in format and returns one finding per CVE and package.
+// Vulnerabilities without a CVE ID are skipped.
+func Parse(format string, r io.Reader) ([]models.Finding, error) {
+	switch format {
+	case "trivy":
+		return ParseTrivy(r)
+	case "grype":
+		return ParseGrype(r)
+	default:
+		return nil, fmt.Errorf("unsupported input format: %s (expected %s)", format, strings.Join(Formats, " or "))
+	}
+}
+
+// trivyReport mirrors the fields of a Trivy JSON report the tool uses.
+type trivyReport struct {
+	ArtifactName string `json:"ArtifactName"`
+	Results      []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// ParseTrivy reads a Trivy JSON report (trivy ... --format json). Each finding's
+// artifact is the result target, such as an image layer or lock file.
+func ParseTrivy(r io.Reader) ([]models.Finding, error) {
+	var report trivyReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode Trivy report: %w", err)
+	}
+	var findings []models.Finding
+	for _, result := range report.Results {
+		artifact := result.Target
+		if artifact == "" {
+			artifact = report.ArtifactName
+		}
+		for _, v := range result.Vulnerabilities {
+			if !isCVE(v.VulnerabilityID) {
+				continue
+			}
+			findings = append(findings, models.Finding{
+				CVE:      strings.ToUpper(v.VulnerabilityID),
+				Package:  v.PkgName,
+				Version:  v.InstalledVersion,
+				Artifact: artifact,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// grypeVulnerability is the identifying part of a Grype vulnerability record.
+type grypeVulnerability struct {
+	ID string `json:"id"`
+}
+
+// grypeReport mirrors the fields of a Grype JSON report the tool uses.
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability          grypeVulnerability   `json:"vulnerability"`
+		RelatedVulnerabilities []grypeVulnerability `json:"relatedVulnerabilities"`
+		Artifact               struct {
+			Name      string `json:"name"`
+			Version   string `json:"version"`
+			Locations []struct {
+				Path string `json:"path"`
+			} `json:"locations"`
+		} `json:"artifact"`
+	} `json:"matches"`
+	Source struct {
+		Target json.RawMessage `json:"target"`
+	} `json:"source"`
+}
+
+// ParseGrype reads a Grype JSON report (grype ... -o json). Matches reported under
+// a non-CVE ID, such as a GHSA advisory, use the CVE among their related
+// vulnerabilities. Each finding's artifact is the package's first location,
+// falling back to the scanned source.
+func ParseGrype(r io.Reader) ([]models.Finding, error) {
+	var report grypeReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode Grype report: %w", err)
+	}
+	source := grypeSource(report.Source.Target)
+
+	var findings []models.Finding
+	for _, match := range report.Matches {
+		id := match.Vulnerability.ID
+		if !isCVE(id) {
+			id = ""
+			for _, related := range match.RelatedVulnerabilities {
+				if isCVE(related.ID) {
+					id = related.ID
+					break
+				}
+			}
+		}
+		if id == "" {
+			continue
+		}
+		artifact := source
+		if len(match.Artifact.Locations) > 0 && match.Artifact.Locations[0].Path != "" {
+			artifact = match.Artifact.Locations[0].Path
+		}
+		findings = append(findings, models.Finding{
+			CVE:      strings.ToUpper(id),
+			Package:  match.Artifact.Name,
+			Version:  match.Artifact.Version,
+			Artifact: artifact,
+		})
+	}
+	return findings, nil
+}
+
+// grypeSource names the scanned source. Image targets are objects holding the
+// user's input; directory and file targets are plain paths.
+func grypeSource(target json.RawMessage) string {
+	var path string
+	if json.Unmarshal(target, &path) == nil {
+		return path
+	}
+	var image struct {
+		UserInput string `json:"userInput"`
+	}
+	if json.Unmarshal(target, &image) == nil {
+		return image.UserInput
+	}
+	return ""
+}
+
+// isCVE reports whether id is a CVE identifier.
+func isCVE(id string) bool {
+	return strings.HasPrefix(strings.ToUpper(id), "CVE-")
+}
@@ -0,0 +1,73 @@
+package scanners_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/scanners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseFixture(t *testing.T, format string) ([]models.Finding, error) {
+	t.Helper()
+	f, err := os.Open("testdata/" + format + ".json")
+	require.NoError(t, err)
+	defer f.Close()
+	return scanners.Parse(format, f)
+}
+
+func TestParseTrivy(t *testing.T) {
+	t.Run("Success - Extracts CVEs Per Package", func(t *testing.T) {
+		findings, err := parseFixture(t, "trivy")
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Finding{
+			{CVE: "CVE-2019-1549", Package: "libcrypto1.1", Version: "1.1.1c-r0", Artifact: "alpine:3.10 (alpine 3.10.2)"},
+			{CVE: "CVE-2019-1549", Package: "libssl1.1", Version: "1.1.1c-r0", Artifact: "alpine:3.10 (alpine 3.10.2)"},
+			{CVE: "CVE-2021-23337", Package: "lodash", Version: "4.17.20", Artifact: "app/package-lock.json"},
+		}, findings)
+	})
+
+	t.Run("Fail - Malformed Report", func(t *testing.T) {
+		_, err := scanners.ParseTrivy(strings.NewReader(`{"Results":`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParseGrype(t *testing.T) {
+	t.Run("Success - Extracts CVEs Per Package", func(t *testing.T) {
+		findings, err := parseFixture(t, "grype")
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Finding{
+			{CVE: "CVE-2019-1549", Package: "libcrypto1.1", Version: "1.1.1c-r0", Artifact: "/lib/apk/db/installed"},
+			{CVE: "CVE-2021-23337", Package: "lodash", Version: "4.17.20", Artifact: "alpine:3.10"},
+		}, findings)
+	})
+
+	t.Run("Success - Directory Source", func(t *testing.T) {
+		report := `{"matches":[{"vulnerability":{"id":"CVE-2023-0001"},"artifact":{"name":"pkg","version":"1.0"}}],"source":{"type":"directory","target":"./src"}}`
+		findings, err := scanners.ParseGrype(strings.NewReader(report))
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Finding{{CVE: "CVE-2023-0001", Package: "pkg", Version: "1.0", Artifact: "./src"}}, findings)
+	})
+
+	t.Run("Fail - Malformed Report", func(t *testing.T) {
+		_, err := scanners.ParseGrype(strings.NewReader(`not json`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Fail - Unsupported Format", func(t *testing.T) {
+		_, err := scanners.Parse("snyk", strings.NewReader(`{}`))
+
+		assert.ErrorContains(t, err, "unsupported input format")
+	})
+}
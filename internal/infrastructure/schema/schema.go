@@ -0,0 +1,141 @@
+// Package schema derives JSON Schemas of the tool's result types from their
+// Go definitions, so downstream tooling can validate its JSON output.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema used to describe result types.
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// ResultType names a type printed as JSON by one or more commands.
+type ResultType struct {
+	Name  string
+	Value interface{}
+}
+
+// Results lists the result types whose schemas the schema command prints.
+var Results = []ResultType{
+	{"cve", models.CVE{}},
+	{"score-change", models.ScoreChange{}},
+	{"finding", models.Finding{}},
+	{"component", models.ComponentSummary{}},
+	{"report-diff", models.ReportDiff{}},
+	{"batch-result", models.BatchResult{}},
+}
+
+// Names returns the names of Results.
+func Names() []string {
+	names := make([]string, len(Results))
+	for i, r := range Results {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// Document returns a schema of the result type called name, or of every
+// result type under $defs when name is empty.
+func Document(name string) (*Schema, error) {
+	g := generator{defs: make(map[string]*Schema)}
+	doc := &Schema{SchemaURI: Draft}
+	for _, r := range Results {
+		if name == "" {
+			g.schema(reflect.TypeOf(r.Value))
+		} else if r.Name == name {
+			doc.Ref = g.schema(reflect.TypeOf(r.Value)).Ref
+		}
+	}
+	if name != "" && doc.Ref == "" {
+		return nil, fmt.Errorf("unknown result type: %s (expected one of %s)", name, strings.Join(Names(), ", "))
+	}
+	doc.Defs = g.defs
+	return doc, nil
+}
+
+// timeType is marshaled as an RFC 3339 string rather than an object.
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator builds schemas, collecting named struct types under $defs.
+type generator struct {
+	defs map[string]*Schema
+}
+
+// schema returns the schema of values of type t as encoding/json marshals them.
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		s := g.schema(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		s.Type = []string{s.Type.(string), "null"}
+		return s
+	case t.Kind() == reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			// Register first so recursive types terminate.
+			g.defs[t.Name()] = nil
+			g.defs[t.Name()] = g.object(t)
+		}
+		return &Schema{Ref: "#/$defs/" + t.Name()}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+// object returns the schema of struct type t. Fields tagged omitempty are
+// optional; every other exported field is required.
+func (g *generator) object(t reflect.Type) *Schema {
+	closed := false
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: &closed}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = g.schema(f.Type)
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+	return s
+}
@@ -0,0 +1,127 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// populate sets every field reachable from v to a non-zero value.
+func populate(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(v.Elem())
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				populate(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		populate(v.Index(0))
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float64:
+		v.SetFloat(0.5)
+	}
+}
+
+// marshaledKeys returns the sorted keys of v marshaled as a JSON object.
+func marshaledKeys(t *testing.T, v interface{}) []string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var obj map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &obj))
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestDocument(t *testing.T) {
+	t.Run("Success - CVE Schema", func(t *testing.T) {
+		doc, err := schema.Document("cve")
+		require.NoError(t, err)
+
+		data, err := json.Marshal(doc)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$ref": "#/$defs/CVE",
+			"$defs": {"CVE": {
+				"type": "object",
+				"properties": {
+					"cve": {"type": "string"},
+					"epss": {"type": "number"},
+					"percentile": {"type": "number"},
+					"date": {"type": "string"},
+					"raw_epss": {"type": "string"},
+					"raw_percentile": {"type": "string"},
+					"rank": {"type": "integer"},
+					"total": {"type": "integer"},
+					"normalized": {"type": ["number", "null"]},
+					"source": {"type": "string"},
+					"age_days": {"type": ["integer", "null"]},
+					"stale": {"type": ["boolean", "null"]}
+				},
+				"required": ["cve", "date", "epss", "percentile"],
+				"additionalProperties": false
+			}}
+		}`, string(data))
+	})
+
+	t.Run("Success - Nested Types Are Shared Definitions", func(t *testing.T) {
+		doc, err := schema.Document("report-diff")
+		require.NoError(t, err)
+
+		assert.Equal(t, "#/$defs/ReportDiff", doc.Ref)
+		assert.Equal(t, "#/$defs/CVE", doc.Defs["ReportDiff"].Properties["added"].Items.Ref)
+		assert.Contains(t, doc.Defs, "CVEDelta")
+	})
+
+	t.Run("Success - Schemas Match The Marshaled Shape", func(t *testing.T) {
+		doc, err := schema.Document("")
+		require.NoError(t, err)
+
+		for _, r := range schema.Results {
+			def := doc.Defs[reflect.TypeOf(r.Value).Name()]
+			require.NotNil(t, def, r.Name)
+
+			full := reflect.New(reflect.TypeOf(r.Value))
+			populate(full.Elem())
+			var properties []string
+			for name := range def.Properties {
+				properties = append(properties, name)
+			}
+			sort.Strings(properties)
+
+			assert.Equal(t, properties, marshaledKeys(t, full.Interface()), r.Name)
+			assert.Equal(t, def.Required, marshaledKeys(t, r.Value), r.Name)
+		}
+	})
+
+	t.Run("Fail - Unknown Type", func(t *testing.T) {
+		_, err := schema.Document("alert")
+
+		assert.EqualError(t, err, "unknown result type: alert (expected one of cve, score-change, finding, component, report-diff, batch-result)")
+	})
+}
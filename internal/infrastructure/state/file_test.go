@@ -0,0 +1,32 @@
+package state_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSave(t *testing.T) {
+	t.Run("Success - Missing File Is Not An Error", func(t *testing.T) {
+		var v map[string]string
+		found, err := state.Load(filepath.Join(t.TempDir(), "missing.json"), &v)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Success - Round Trip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nested", "state.json")
+		require.NoError(t, state.Save(path, map[string]string{"last": "2024-10-18"}))
+
+		var v map[string]string
+		found, err := state.Load(path, &v)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "2024-10-18", v["last"])
+	})
+}
@@ -0,0 +1,163 @@
+// Package timing measures where HTTP request time is spent.
+package timing
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing breaks down the wall-clock time of one request. Phases that did not
+// happen, such as DNS for a reused connection, are zero.
+type Timing struct {
+	URL       string
+	DNS       time.Duration
+	Connect   time.Duration
+	TLS       time.Duration
+	FirstByte time.Duration
+	Total     time.Duration
+
+	// RateLimitRemaining and RateLimitReset hold the response's
+	// X-RateLimit-Remaining and X-RateLimit-Reset headers, if sent.
+	RateLimitRemaining string
+	RateLimitReset     string
+}
+
+// Transport is an http.RoundTripper that traces each request, writing its
+// timing to w once the response body is closed.
+type Transport struct {
+	base http.RoundTripper
+	w    io.Writer
+
+	mu      sync.Mutex
+	timings []Timing
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) with request tracing.
+func NewTransport(base http.RoundTripper, w io.Writer) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base, w: w}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The trace callbacks run on net/http's dial goroutines, so every access
+	// to timing and the phase start times holds mu.
+	var mu sync.Mutex
+	timing := Timing{URL: req.URL.Redacted()}
+	var dnsStart, connectStart, tlsStart time.Time
+	locked := func(f func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		f()
+	}
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { locked(func() { dnsStart = time.Now() }) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { locked(func() { timing.DNS = time.Since(dnsStart) }) },
+		ConnectStart: func(string, string) {
+			locked(func() { connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			locked(func() { timing.Connect = time.Since(connectStart) })
+		},
+		TLSHandshakeStart: func() { locked(func() { tlsStart = time.Now() }) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			locked(func() { timing.TLS = time.Since(tlsStart) })
+		},
+		GotFirstResponseByte: func() {
+			locked(func() { timing.FirstByte = time.Since(start) })
+		},
+	}
+	// finish stamps the total time and records a snapshot of timing.
+	finish := func() {
+		mu.Lock()
+		timing.Total = time.Since(start)
+		snapshot := timing
+		mu.Unlock()
+		t.record(snapshot)
+	}
+
+	resp, err := t.base.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err != nil {
+		finish()
+		return nil, err
+	}
+	locked(func() {
+		timing.RateLimitRemaining = resp.Header.Get("X-RateLimit-Remaining")
+		timing.RateLimitReset = resp.Header.Get("X-RateLimit-Reset")
+	})
+	resp.Body = &timedBody{ReadCloser: resp.Body, done: finish}
+	return resp, nil
+}
+
+// record stores a completed timing and reports it.
+func (t *Transport) record(timing Timing) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timings = append(t.timings, timing)
+	fmt.Fprintf(t.w, "timing: dns=%s connect=%s tls=%s first-byte=%s total=%s %s\n",
+		round(timing.DNS), round(timing.Connect), round(timing.TLS), round(timing.FirstByte), round(timing.Total), timing.URL)
+}
+
+// Timings returns the timings recorded so far.
+func (t *Transport) Timings() []Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Timing(nil), t.timings...)
+}
+
+// WriteSummary writes the request count, summed phase times and the slowest
+// request, followed by the rate-limit state of the last response that reported one.
+func (t *Transport) WriteSummary(w io.Writer) {
+	timings := t.Timings()
+	if len(timings) == 0 {
+		fmt.Fprintln(w, "timing summary: no requests")
+		return
+	}
+	var sum, rateLimited Timing
+	slowest := timings[0]
+	for _, timing := range timings {
+		if timing.RateLimitRemaining != "" || timing.RateLimitReset != "" {
+			rateLimited = timing
+		}
+		sum.DNS += timing.DNS
+		sum.Connect += timing.Connect
+		sum.TLS += timing.TLS
+		sum.FirstByte += timing.FirstByte
+		sum.Total += timing.Total
+		if timing.Total > slowest.Total {
+			slowest = timing
+		}
+	}
+	fmt.Fprintf(w, "timing summary: %d request(s), dns=%s connect=%s tls=%s first-byte=%s total=%s, slowest %s %s\n",
+		len(timings), round(sum.DNS), round(sum.Connect), round(sum.TLS), round(sum.FirstByte), round(sum.Total), round(slowest.Total), slowest.URL)
+	if rateLimited.URL != "" {
+		fmt.Fprintf(w, "rate limit: remaining=%s reset=%s\n", rateLimited.RateLimitRemaining, rateLimited.RateLimitReset)
+	}
+}
+
+// round trims durations to a readable precision.
+func round(d time.Duration) time.Duration {
+	return d.Round(100 * time.Microsecond)
+}
+
+// timedBody calls done once when the response body is closed.
+type timedBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+// Close implements io.Closer.
+func (b *timedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
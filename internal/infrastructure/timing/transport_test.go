@@ -0,0 +1,81 @@
+package timing_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/timing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	t.Run("Success - Records Each Request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+			w.Write([]byte(`{"data":[]}`))
+		}))
+		defer server.Close()
+
+		var log bytes.Buffer
+		transport := timing.NewTransport(nil, &log)
+		client := &http.Client{Transport: transport}
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+
+		timings := transport.Timings()
+		require.Len(t, timings, 2)
+		assert.GreaterOrEqual(t, timings[0].FirstByte, 5*time.Millisecond)
+		assert.GreaterOrEqual(t, timings[0].Total, timings[0].FirstByte)
+		assert.Greater(t, timings[0].Connect, time.Duration(0))
+		assert.Equal(t, time.Duration(0), timings[1].Connect, "second request reuses the connection")
+		assert.Contains(t, log.String(), "first-byte=")
+
+		var summary bytes.Buffer
+		transport.WriteSummary(&summary)
+		assert.Contains(t, summary.String(), "2 request(s)")
+		assert.NotContains(t, summary.String(), "rate limit")
+	})
+
+	t.Run("Success - Summary Reports Rate Limit", func(t *testing.T) {
+		remaining := 10
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remaining--
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("X-RateLimit-Reset", "1729238400")
+			w.Write([]byte(`{"data":[]}`))
+		}))
+		defer server.Close()
+
+		transport := timing.NewTransport(nil, io.Discard)
+		client := &http.Client{Transport: transport}
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		var summary bytes.Buffer
+		transport.WriteSummary(&summary)
+		assert.Contains(t, summary.String(), "rate limit: remaining=8 reset=1729238400\n")
+	})
+
+	t.Run("Fail - Failed Requests Are Recorded", func(t *testing.T) {
+		var log bytes.Buffer
+		transport := timing.NewTransport(nil, &log)
+
+		_, err := (&http.Client{Transport: transport}).Get("http://127.0.0.1:1")
+
+		assert.Error(t, err)
+		assert.Len(t, transport.Timings(), 1)
+	})
+}
@@ -0,0 +1,180 @@
+package epss
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/internal/application/service"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/models"
+	"github.com/joshbarros/golang-epsstool-api/internal/domain/ports"
+	"github.com/joshbarros/golang-epsstool-api/internal/infrastructure/repository"
+)
+
+// DefaultBaseURL is the First.org EPSS API endpoint used by NewClient.
+const DefaultBaseURL = repository.DefaultBaseURL
+
+// CVE is the EPSS score of a CVE on a date.
+type CVE = models.CVE
+
+// CVEIterator yields CVEs one page at a time; see Client.IterateCVEsForDate.
+type CVEIterator = service.CVEIterator
+
+// ResultTransformer mutates or annotates query results before they are
+// returned, for example to attach internal asset tags. It may return a new
+// slice, such as a filtered one.
+type ResultTransformer interface {
+	Transform(cves []CVE) ([]CVE, error)
+}
+
+// TransformerFunc adapts a function to the ResultTransformer interface.
+type TransformerFunc func(cves []CVE) ([]CVE, error)
+
+// Transform calls f(cves).
+func (f TransformerFunc) Transform(cves []CVE) ([]CVE, error) {
+	return f(cves)
+}
+
+// Client queries the EPSS API and passes every result through its transformers.
+type Client struct {
+	repo         ports.EPSSRepository
+	transformers []ResultTransformer
+}
+
+// clientConfig collects the options of NewClient.
+type clientConfig struct {
+	baseURL      string
+	repoOpts     []repository.Option
+	transformers []ResultTransformer
+	memoryTTL    time.Duration
+}
+
+// Option configures a Client.
+type Option func(*clientConfig)
+
+// WithBaseURL queries an EPSS-compatible endpoint instead of DefaultBaseURL.
+func WithBaseURL(url string) Option {
+	return func(c *clientConfig) {
+		c.baseURL = url
+	}
+}
+
+// WithHTTPClient sends requests through client instead of the default client,
+// which times out after 30 seconds.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *clientConfig) {
+		c.repoOpts = append(c.repoOpts, repository.WithHTTPClient(client))
+	}
+}
+
+// WithMemoryCache keeps the results of Score and ForDate in memory for ttl,
+// so a long-running program asking for the same CVE or day again is answered
+// without a request. Other queries are not cached.
+func WithMemoryCache(ttl time.Duration) Option {
+	return func(c *clientConfig) {
+		c.memoryTTL = ttl
+	}
+}
+
+// WithTransformer registers t; see Client.Use.
+func WithTransformer(t ResultTransformer) Option {
+	return func(c *clientConfig) {
+		c.transformers = append(c.transformers, t)
+	}
+}
+
+// NewClient creates a Client for the First.org EPSS API. Every query is sent
+// to the API unless WithMemoryCache is given.
+func NewClient(opts ...Option) *Client {
+	cfg := clientConfig{baseURL: DefaultBaseURL}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	repo := repository.NewAPIRepository(cfg.baseURL, append(cfg.repoOpts, repository.WithoutMemo())...)
+	if cfg.memoryTTL > 0 {
+		repo = repository.NewCachingRepository(repo, cfg.memoryTTL)
+	}
+	return &Client{repo: repo, transformers: cfg.transformers}
+}
+
+// Use registers t to run on every result. Transformers run in registration
+// order, each receiving the previous one's output; the first error stops the
+// chain and is returned instead of the results.
+func (c *Client) Use(t ResultTransformer) {
+	c.transformers = append(c.transformers, t)
+}
+
+// Score returns the score of one CVE for date (the latest when empty). When a
+// transformer drops the CVE, Score returns nil and no error.
+func (c *Client) Score(ctx context.Context, cveID string, date string) (*CVE, error) {
+	cve, err := c.repo.GetCVEScore(ctx, cveID, date)
+	if err != nil {
+		return nil, err
+	}
+	cves, err := c.transform([]CVE{*cve})
+	if err != nil || len(cves) == 0 {
+		return nil, err
+	}
+	return &cves[0], nil
+}
+
+// Scores returns the scores of several CVEs for date (the latest when empty).
+func (c *Client) Scores(ctx context.Context, cveIDs []string, date string) ([]CVE, error) {
+	return c.run(c.repo.GetCVEScores(ctx, cveIDs, date))
+}
+
+// TopN returns the n CVEs with the highest current scores.
+func (c *Client) TopN(ctx context.Context, n int) ([]CVE, error) {
+	return c.run(c.repo.GetTopNCVEs(ctx, n))
+}
+
+// ForDate returns every CVE scored on date.
+func (c *Client) ForDate(ctx context.Context, date string) ([]CVE, error) {
+	return c.run(c.repo.GetAllCVEsForDate(ctx, date))
+}
+
+// IterateCVEsForDate streams every CVE scored on date (the latest data when
+// empty) page by page, so millions of rows can be processed with bounded
+// memory. Transformers run on each page as it arrives. Cancelling ctx aborts
+// the request in flight; the iterator's Err then reports ctx's error.
+func (c *Client) IterateCVEsForDate(ctx context.Context, date string) (*CVEIterator, error) {
+	if date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid date format: %w", err)
+		}
+	}
+	pager, ok := c.repo.(ports.CVEPager)
+	if !ok {
+		return nil, fmt.Errorf("the configured source does not support paging")
+	}
+	var transform func([]CVE) ([]CVE, error)
+	if len(c.transformers) > 0 {
+		transform = c.transform
+	}
+	return service.IterateCVEs(ctx, pager, date, transform), nil
+}
+
+// TimeSeries returns the daily scores of a CVE over the last 30 days.
+func (c *Client) TimeSeries(ctx context.Context, cveID string) ([]CVE, error) {
+	return c.run(c.repo.GetTimeSeries(ctx, cveID))
+}
+
+// run transforms the results of a query that succeeded.
+func (c *Client) run(cves []CVE, err error) ([]CVE, error) {
+	if err != nil {
+		return nil, err
+	}
+	return c.transform(cves)
+}
+
+// transform applies the transformers in order.
+func (c *Client) transform(cves []CVE) ([]CVE, error) {
+	for _, t := range c.transformers {
+		var err error
+		if cves, err = t.Transform(cves); err != nil {
+			return nil, err
+		}
+	}
+	return cves, nil
+}
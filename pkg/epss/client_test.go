@@ -0,0 +1,189 @@
+package epss_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshbarros/golang-epsstool-api/pkg/epss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"data":[
+			{"cve":"CVE-2021-44228","epss":"0.97","percentile":"0.99","date":"2024-10-18"},
+			{"cve":"CVE-2023-0001","epss":"0.01","percentile":"0.6","date":"2024-10-18"}
+		]}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// tagger appends its name to each CVE's ID so tests can observe ordering.
+func tagger(name string) epss.TransformerFunc {
+	return func(cves []epss.CVE) ([]epss.CVE, error) {
+		for i := range cves {
+			cves[i].ID += "+" + name
+		}
+		return cves, nil
+	}
+}
+
+func TestClientTransformers(t *testing.T) {
+	t.Run("Success - Applied In Registration Order", func(t *testing.T) {
+		server := newMockServer(t)
+		client := epss.NewClient(epss.WithBaseURL(server.URL), epss.WithTransformer(tagger("first")))
+		client.Use(tagger("second"))
+		client.Use(tagger("third"))
+
+		cves, err := client.Scores(context.Background(), []string{"CVE-2021-44228", "CVE-2023-0001"}, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "CVE-2021-44228+first+second+third", cves[0].ID)
+		assert.Equal(t, "CVE-2023-0001+first+second+third", cves[1].ID)
+	})
+
+	t.Run("Success - Transformer Sees Previous Output", func(t *testing.T) {
+		server := newMockServer(t)
+		client := epss.NewClient(epss.WithBaseURL(server.URL))
+		client.Use(epss.TransformerFunc(func(cves []epss.CVE) ([]epss.CVE, error) {
+			return cves[:1], nil
+		}))
+		var seen int
+		client.Use(epss.TransformerFunc(func(cves []epss.CVE) ([]epss.CVE, error) {
+			seen = len(cves)
+			return cves, nil
+		}))
+
+		cves, err := client.TopN(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.Len(t, cves, 1)
+		assert.Equal(t, 1, seen)
+	})
+
+	t.Run("Success - Single Score Dropped", func(t *testing.T) {
+		server := newMockServer(t)
+		client := epss.NewClient(epss.WithBaseURL(server.URL), epss.WithTransformer(epss.TransformerFunc(func([]epss.CVE) ([]epss.CVE, error) {
+			return nil, nil
+		})))
+
+		cve, err := client.Score(context.Background(), "CVE-2021-44228", "")
+
+		require.NoError(t, err)
+		assert.Nil(t, cve)
+	})
+
+	t.Run("Fail - Error Stops The Chain", func(t *testing.T) {
+		server := newMockServer(t)
+		client := epss.NewClient(epss.WithBaseURL(server.URL))
+		client.Use(epss.TransformerFunc(func([]epss.CVE) ([]epss.CVE, error) {
+			return nil, errors.New("asset inventory unavailable")
+		}))
+		var called bool
+		client.Use(epss.TransformerFunc(func(cves []epss.CVE) ([]epss.CVE, error) {
+			called = true
+			return cves, nil
+		}))
+
+		_, err := client.ForDate(context.Background(), "2024-10-18")
+
+		assert.EqualError(t, err, "asset inventory unavailable")
+		assert.False(t, called)
+	})
+
+	t.Run("Fail - Query Errors Skip Transformers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := epss.NewClient(epss.WithBaseURL(server.URL), epss.WithTransformer(tagger("unused")))
+
+		_, err := client.Scores(context.Background(), []string{"CVE-2021-44228"}, "")
+
+		assert.True(t, epss.IsRetryable(err))
+	})
+}
+
+func TestClientScore(t *testing.T) {
+	t.Run("Success - Every Call Reaches The API", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&calls, 1)
+			fmt.Fprintf(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.%d","percentile":"0.6","date":"2024-10-18"}]}`, n)
+		}))
+		defer server.Close()
+		client := epss.NewClient(epss.WithBaseURL(server.URL))
+
+		first, err := client.Score(context.Background(), "CVE-2023-0001", "")
+		require.NoError(t, err)
+		second, err := client.Score(context.Background(), "CVE-2023-0001", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		assert.Equal(t, 0.1, first.EPSSScore)
+		assert.Equal(t, 0.2, second.EPSSScore)
+	})
+
+	t.Run("Success - Memory Cache Answers Repeated Calls", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			fmt.Fprintln(w, `{"data":[{"cve":"CVE-2023-0001","epss":"0.1","percentile":"0.6","date":"2024-10-18"}]}`)
+		}))
+		defer server.Close()
+		client := epss.NewClient(epss.WithBaseURL(server.URL), epss.WithMemoryCache(time.Hour))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.Score(context.Background(), "CVE-2023-0001", "")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestClientIterateCVEsForDate(t *testing.T) {
+	t.Run("Success - Streams With Transformers", func(t *testing.T) {
+		server := newMockServer(t)
+		client := epss.NewClient(epss.WithBaseURL(server.URL), epss.WithTransformer(tagger("tagged")))
+
+		it, err := client.IterateCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.CVE().ID)
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"CVE-2021-44228+tagged", "CVE-2023-0001+tagged"}, ids)
+	})
+
+	t.Run("Success - Streams Through The Memory Cache", func(t *testing.T) {
+		server := newMockServer(t)
+		client := epss.NewClient(epss.WithBaseURL(server.URL), epss.WithMemoryCache(time.Hour))
+
+		it, err := client.IterateCVEsForDate(context.Background(), "2024-10-18")
+		require.NoError(t, err)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.CVE().ID)
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"CVE-2021-44228", "CVE-2023-0001"}, ids)
+	})
+
+	t.Run("Fail - Invalid Date", func(t *testing.T) {
+		_, err := epss.NewClient().IterateCVEsForDate(context.Background(), "18/10/2024")
+		assert.Error(t, err)
+	})
+}
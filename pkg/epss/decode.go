@@ -0,0 +1,40 @@
+package epss
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeCVEs parses CVE results saved from the tool's JSON output: a single
+// CVE object (score), an array of CVEs, or a --with-meta envelope whose data
+// holds them.
+func DecodeCVEs(data []byte) ([]CVE, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("failed to decode CVEs: empty input")
+	}
+
+	if data[0] == '[' {
+		var cves []CVE
+		if err := json.Unmarshal(data, &cves); err != nil {
+			return nil, fmt.Errorf("failed to decode CVEs: %w", err)
+		}
+		return cves, nil
+	}
+
+	var object struct {
+		Data json.RawMessage `json:"data"`
+		CVE
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to decode CVEs: %w", err)
+	}
+	if object.Data != nil {
+		return DecodeCVEs(object.Data)
+	}
+	if object.ID == "" {
+		return nil, fmt.Errorf("failed to decode CVEs: no cve field or data array")
+	}
+	return []CVE{object.CVE}, nil
+}
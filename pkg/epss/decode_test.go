@@ -0,0 +1,44 @@
+package epss_test
+
+import (
+	"testing"
+
+	"github.com/joshbarros/golang-epsstool-api/pkg/epss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCVEs(t *testing.T) {
+	t.Run("Success - Array", func(t *testing.T) {
+		cves, err := epss.DecodeCVEs([]byte(`[{"cve":"CVE-2021-44228","epss":0.97,"percentile":0.9999,"date":"2024-10-18"},{"cve":"CVE-2023-0001","epss":0.1,"percentile":0.8,"date":"2024-10-18"}]`))
+
+		require.NoError(t, err)
+		require.Len(t, cves, 2)
+		assert.Equal(t, "CVE-2021-44228", cves[0].ID)
+		assert.Equal(t, 0.97, cves[0].EPSSScore)
+	})
+
+	t.Run("Success - Envelope", func(t *testing.T) {
+		cves, err := epss.DecodeCVEs([]byte(`{"meta":{"command":"topn","count":1},"data":[{"cve":"CVE-2021-44228","epss":0.97,"percentile":0.9999,"date":"2024-10-18"}]}`))
+
+		require.NoError(t, err)
+		require.Len(t, cves, 1)
+		assert.Equal(t, "2024-10-18", cves[0].Date)
+	})
+
+	t.Run("Success - Single CVE", func(t *testing.T) {
+		cves, err := epss.DecodeCVEs([]byte(`{"cve":"CVE-2021-44228","epss":0.97,"percentile":0.9999,"date":"2024-10-18"}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, []epss.CVE{{ID: "CVE-2021-44228", EPSSScore: 0.97, Percentile: 0.9999, Date: "2024-10-18"}}, cves)
+	})
+
+	t.Run("Fail - Not A Report", func(t *testing.T) {
+		_, err := epss.DecodeCVEs([]byte(`{"status":"OK"}`))
+		assert.Error(t, err)
+		_, err = epss.DecodeCVEs([]byte(`not json`))
+		assert.Error(t, err)
+		_, err = epss.DecodeCVEs(nil)
+		assert.Error(t, err)
+	})
+}
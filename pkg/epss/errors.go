@@ -0,0 +1,24 @@
+// Package epss is the public API for embedding the EPSS tool in other programs.
+package epss
+
+import "github.com/joshbarros/golang-epsstool-api/internal/domain/apierr"
+
+// StatusError reports an unexpected HTTP status code from the EPSS API.
+// Use errors.As to inspect the status code of a returned error.
+type StatusError = apierr.StatusError
+
+// EnvelopeError reports a failure, such as an invalid parameter, that the
+// EPSS API signalled in the status fields of a response sent with HTTP 200.
+type EnvelopeError = apierr.EnvelopeError
+
+// ErrRequestTimeout is returned when a single request attempt exceeds its
+// timeout. It is retryable.
+var ErrRequestTimeout = apierr.ErrRequestTimeout
+
+// IsRetryable reports whether err is a transient failure worth retrying:
+// a 5xx or 429 response, a timeout, or a connection reset. Wrapped errors
+// are unwrapped, so callers can pass errors through unchanged and build
+// their own retry policy without matching on error strings.
+func IsRetryable(err error) bool {
+	return apierr.IsRetryable(err)
+}